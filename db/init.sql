@@ -1,8 +1,18 @@
-CREATE TABLE IF NOT EXISTS actor (
+CREATE TABLE IF NOT EXISTS person (
     id serial not null unique,
     name text not null unique,
     gender text not null,
-    date_of_birth date not null 
+    date_of_birth date,
+    photo jsonb,
+    date_of_death date,
+    birthplace text not null default '',
+    biography text not null default '',
+    aliases text[] not null default '{}',
+    imdb_id text unique,
+    kinopoisk_id int unique,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    CHECK (date_of_death >= date_of_birth)
 );
 
 CREATE TABLE IF NOT EXISTS film (
@@ -10,21 +20,276 @@ CREATE TABLE IF NOT EXISTS film (
     title text not null,
     description text not null,
     release_date date not null,
-    rating smallint not null
+    rating smallint not null,
+    runtime int not null default 0,
+    countries text[] not null default '{}',
+    original_language text not null default '',
+    spoken_languages text[] not null default '{}',
+    age_rating text not null default '' check (age_rating in ('', '0+', '6+', '12+', '16+', '18+')),
+    budget bigint not null default 0,
+    box_office bigint not null default 0,
+    currency text not null default '',
+    poster jsonb,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
 );
 
 CREATE TABLE IF NOT EXISTS actor_film (
     id serial not null,
     actor_id int,
     film_id int,
-    FOREIGN KEY (actor_id) REFERENCES actor (id) on delete cascade,
+    -- Когда фильм появился в фильмографии актёра
+    created_at timestamptz not null default now(),
+    FOREIGN KEY (actor_id) REFERENCES person (id) on delete cascade,
     FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
 );
 
+CREATE TABLE IF NOT EXISTS film_crew (
+    film_id int not null,
+    person_id int not null,
+    role text not null check (role in ('director', 'writer', 'composer', 'producer')),
+    PRIMARY KEY (film_id, person_id, role),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (person_id) REFERENCES person (id) on delete cascade
+);
+
 CREATE TABLE IF NOT EXISTS service_user (
     id serial not null unique,
     role text default 'Пользователь'
 );
 
 INSERT INTO service_user (role) values ('Пользователь');
-INSERT INTO service_user (role) values ('Администратор');
\ No newline at end of file
+INSERT INTO service_user (role) values ('Администратор');
+INSERT INTO service_user (role) values ('Модератор');
+
+CREATE TABLE IF NOT EXISTS genre (
+    id serial not null unique,
+    name text not null unique
+);
+
+CREATE TABLE IF NOT EXISTS film_genre (
+    film_id int not null,
+    genre_id int not null,
+    PRIMARY KEY (film_id, genre_id),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (genre_id) REFERENCES genre (id) on delete cascade
+);
+
+INSERT INTO genre (name) values ('Драма'), ('Комедия'), ('Мелодрама'), ('Боевик'), ('Фантастика'), ('Триллер');
+
+CREATE TABLE IF NOT EXISTS film_translation (
+    film_id int not null,
+    language text not null,
+    title text not null,
+    description text not null default '',
+    PRIMARY KEY (film_id, language),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
+
+CREATE TABLE IF NOT EXISTS franchise (
+    id serial PRIMARY KEY,
+    name text not null unique,
+    description text not null default ''
+);
+
+CREATE TABLE IF NOT EXISTS franchise_film (
+    franchise_id int not null,
+    film_id int not null,
+    PRIMARY KEY (franchise_id, film_id),
+    FOREIGN KEY (franchise_id) REFERENCES franchise (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
+
+-- related_film_id приходится фильму film_id тем, что указано в type
+CREATE TABLE IF NOT EXISTS film_relation (
+    film_id int not null,
+    related_film_id int not null,
+    type text not null CHECK (type in ('sequel', 'prequel', 'remake', 'spin_off')),
+    PRIMARY KEY (film_id, related_film_id),
+    CHECK (film_id <> related_film_id),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (related_film_id) REFERENCES film (id) on delete cascade
+);
+
+-- Между двумя фильмами может быть только одна связь
+CREATE UNIQUE INDEX IF NOT EXISTS film_relation_pair_idx
+    ON film_relation (least(film_id, related_film_id), greatest(film_id, related_film_id));
+CREATE INDEX IF NOT EXISTS film_relation_related_film_idx ON film_relation (related_film_id);
+
+CREATE TABLE IF NOT EXISTS award (
+    id serial PRIMARY KEY,
+    name text not null unique
+);
+
+CREATE TABLE IF NOT EXISTS award_ceremony (
+    id serial PRIMARY KEY,
+    award_id int not null,
+    year int not null,
+    UNIQUE (award_id, year),
+    FOREIGN KEY (award_id) REFERENCES award (id) on delete cascade
+);
+
+CREATE TABLE IF NOT EXISTS award_category (
+    id serial PRIMARY KEY,
+    award_id int not null,
+    name text not null,
+    UNIQUE (award_id, name),
+    FOREIGN KEY (award_id) REFERENCES award (id) on delete cascade
+);
+
+-- person_id заполняется, если номинирован актёр или участник съёмочной группы
+CREATE TABLE IF NOT EXISTS nomination (
+    id serial PRIMARY KEY,
+    ceremony_id int not null,
+    category_id int not null,
+    film_id int not null,
+    person_id int,
+    won boolean not null default false,
+    FOREIGN KEY (ceremony_id) REFERENCES award_ceremony (id) on delete cascade,
+    FOREIGN KEY (category_id) REFERENCES award_category (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (person_id) REFERENCES person (id) on delete cascade
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS nomination_unique_idx
+    ON nomination (ceremony_id, category_id, film_id, coalesce(person_id, 0));
+CREATE INDEX IF NOT EXISTS nomination_film_idx ON nomination (film_id);
+CREATE INDEX IF NOT EXISTS nomination_person_idx ON nomination (person_id);
+
+-- Оценки и отзывы пользователей, одна оценка пользователя на фильм
+CREATE TABLE IF NOT EXISTS review (
+    id serial PRIMARY KEY,
+    film_id int not null,
+    user_id int not null,
+    rating int not null CHECK (rating BETWEEN 1 AND 10),
+    text text not null default '',
+    -- Пользователям видны и в оценке фильма учитываются только опубликованные отзывы
+    status text not null default 'published' CHECK (status IN ('pending', 'published', 'rejected', 'hidden')),
+    moderation_reason text not null default '',
+    moderated_by int,
+    moderated_at timestamptz,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    UNIQUE (film_id, user_id),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade,
+    FOREIGN KEY (moderated_by) REFERENCES service_user (id) on delete set null
+);
+
+CREATE INDEX IF NOT EXISTS review_user_idx ON review (user_id);
+CREATE INDEX IF NOT EXISTS review_status_idx ON review (status);
+
+-- Жалобы пользователей на опубликованные отзывы, удаляются после решения модератора
+CREATE TABLE IF NOT EXISTS review_flag (
+    review_id int not null,
+    user_id int not null,
+    reason text not null default '',
+    created_at timestamptz not null default now(),
+    PRIMARY KEY (review_id, user_id),
+    FOREIGN KEY (review_id) REFERENCES review (id) on delete cascade,
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade
+);
+
+-- Личные списки пользователей: посмотреть позже, избранное и просмотренные
+CREATE TABLE IF NOT EXISTS user_film_list (
+    user_id int not null,
+    list text not null CHECK (list IN ('watchlist', 'favorites', 'watched')),
+    film_id int not null,
+    -- Дата просмотра, только в списке watched
+    watched_at date,
+    added_at timestamptz not null default now(),
+    PRIMARY KEY (user_id, list, film_id),
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
+
+CREATE INDEX IF NOT EXISTS user_film_list_film_idx ON user_film_list (film_id);
+
+-- Коллекции фильмов пользователей с адресом slug
+CREATE TABLE IF NOT EXISTS collection (
+    id serial PRIMARY KEY,
+    user_id int not null,
+    slug text not null UNIQUE,
+    name text not null,
+    description text not null default '',
+    is_public boolean not null default false,
+    -- Коллекция, скопированная в эту
+    forked_from int,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade,
+    FOREIGN KEY (forked_from) REFERENCES collection (id) on delete set null
+);
+
+CREATE INDEX IF NOT EXISTS collection_user_idx ON collection (user_id);
+CREATE INDEX IF NOT EXISTS collection_forked_from_idx ON collection (forked_from);
+
+CREATE TABLE IF NOT EXISTS collection_film (
+    collection_id int not null,
+    film_id int not null,
+    -- Порядок фильмов в коллекции, начиная с 1
+    position int not null,
+    note text not null default '',
+    PRIMARY KEY (collection_id, film_id),
+    FOREIGN KEY (collection_id) REFERENCES collection (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
+
+-- Статистика каталога, обновляется сервисом по расписанию и по запросу администратора
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_films_per_year AS
+    SELECT extract(year from release_date)::int AS year, count(*)::int AS film_count
+    FROM film
+    GROUP BY 1;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_films_per_year_idx ON stats_films_per_year (year);
+
+-- Сколько фильмов получили каждую редакционную оценку и сколько раз её ставили пользователи
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_rating_distribution AS
+    SELECT s.rating, coalesce(f.film_count, 0) AS film_count, coalesce(r.review_count, 0) AS review_count
+    FROM generate_series(0, 10) AS s (rating)
+    LEFT JOIN (SELECT rating, count(*)::int AS film_count FROM film GROUP BY rating) AS f
+        ON f.rating = s.rating
+    LEFT JOIN (SELECT rating, count(*)::int AS review_count FROM review WHERE status = 'published'
+        GROUP BY rating) AS r ON r.rating = s.rating;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_rating_distribution_idx ON stats_rating_distribution (rating);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_genre_rating AS
+    SELECT g.id AS genre_id, g.name AS genre, count(f.id)::int AS film_count,
+        round(avg(f.rating), 2)::float8 AS average_rating,
+        (SELECT round(avg(r.rating), 2)::float8 FROM review AS r
+            JOIN film_genre AS rfg ON rfg.film_id = r.film_id
+            WHERE rfg.genre_id = g.id AND r.status = 'published') AS average_community_rating
+    FROM genre AS g
+    LEFT JOIN film_genre AS fg ON fg.genre_id = g.id
+    LEFT JOIN film AS f ON f.id = fg.film_id
+    GROUP BY g.id, g.name;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_genre_rating_idx ON stats_genre_rating (genre_id);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_actor_film_count AS
+    SELECT p.id AS actor_id, p.name, count(DISTINCT af.film_id)::int AS film_count
+    FROM person AS p
+    JOIN actor_film AS af ON af.actor_id = p.id
+    GROUP BY p.id, p.name;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_actor_film_count_idx ON stats_actor_film_count (actor_id);
+
+-- Роли в фильмах каждого года по полу актёров
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_cast_gender_per_year AS
+    SELECT extract(year from f.release_date)::int AS year,
+        count(*) FILTER (WHERE p.gender = 'Мужской')::int AS male,
+        count(*) FILTER (WHERE p.gender = 'Женский')::int AS female,
+        count(*) FILTER (WHERE p.gender NOT IN ('Мужской', 'Женский'))::int AS unknown
+    FROM actor_film AS af
+    JOIN film AS f ON f.id = af.film_id
+    JOIN person AS p ON p.id = af.actor_id
+    GROUP BY 1;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_cast_gender_per_year_idx ON stats_cast_gender_per_year (year);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_catalog_growth AS
+    SELECT to_char(m.month, 'YYYY-MM') AS month, m.films_added,
+        (sum(m.films_added) OVER (ORDER BY m.month))::int AS total_films
+    FROM (SELECT date_trunc('month', created_at) AS month, count(*)::int AS films_added
+        FROM film GROUP BY 1) AS m;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_catalog_growth_idx ON stats_catalog_growth (month);
+
+-- Новые фильмы и фильмографии актёров в лентах Atom и RSS
+CREATE INDEX IF NOT EXISTS film_created_at_idx ON film (created_at);
+CREATE INDEX IF NOT EXISTS actor_film_actor_idx ON actor_film (actor_id, created_at);
@@ -0,0 +1,2 @@
+-- Актёры, созданные при добавлении фильма с actor_mode=create, заводятся без даты рождения
+ALTER TABLE actor ALTER COLUMN date_of_birth DROP NOT NULL;
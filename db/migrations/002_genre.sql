@@ -0,0 +1,16 @@
+-- Справочник жанров и жанры фильмов
+CREATE TABLE IF NOT EXISTS genre (
+    id serial not null unique,
+    name text not null unique
+);
+
+CREATE TABLE IF NOT EXISTS film_genre (
+    film_id int not null,
+    genre_id int not null,
+    PRIMARY KEY (film_id, genre_id),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (genre_id) REFERENCES genre (id) on delete cascade
+);
+
+INSERT INTO genre (name) values ('Драма'), ('Комедия'), ('Мелодрама'), ('Боевик'), ('Фантастика'), ('Триллер')
+    ON CONFLICT (name) DO NOTHING;
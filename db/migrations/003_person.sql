@@ -0,0 +1,12 @@
+-- Переход от таблицы actor к общей таблице person для баз, созданных до появления съёмочной группы.
+-- Все существующие актёры становятся персонами, связи actor_film сохраняются
+ALTER TABLE actor RENAME TO person;
+
+CREATE TABLE IF NOT EXISTS film_crew (
+    film_id int not null,
+    person_id int not null,
+    role text not null check (role in ('director', 'writer', 'composer', 'producer')),
+    PRIMARY KEY (film_id, person_id, role),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (person_id) REFERENCES person (id) on delete cascade
+);
@@ -0,0 +1,10 @@
+-- Расширенные данные о фильме. У существующих фильмов поля остаются пустыми
+ALTER TABLE film
+    ADD COLUMN runtime int not null default 0,
+    ADD COLUMN countries text[] not null default '{}',
+    ADD COLUMN original_language text not null default '',
+    ADD COLUMN spoken_languages text[] not null default '{}',
+    ADD COLUMN age_rating text not null default '' check (age_rating in ('', '0+', '6+', '12+', '16+', '18+')),
+    ADD COLUMN budget bigint not null default 0,
+    ADD COLUMN box_office bigint not null default 0,
+    ADD COLUMN currency text not null default '';
@@ -0,0 +1,9 @@
+-- Переводы названия и описания фильма. Колонки title и description таблицы film хранят оригинал
+CREATE TABLE IF NOT EXISTS film_translation (
+    film_id int not null,
+    language text not null,
+    title text not null,
+    description text not null default '',
+    PRIMARY KEY (film_id, language),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
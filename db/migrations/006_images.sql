@@ -0,0 +1,3 @@
+-- Ссылки на загруженные изображения и их миниатюры
+ALTER TABLE film ADD COLUMN poster jsonb;
+ALTER TABLE person ADD COLUMN photo jsonb;
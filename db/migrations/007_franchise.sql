@@ -0,0 +1,30 @@
+-- Франшизы и связи между фильмами
+CREATE TABLE IF NOT EXISTS franchise (
+    id serial PRIMARY KEY,
+    name text not null unique,
+    description text not null default ''
+);
+
+CREATE TABLE IF NOT EXISTS franchise_film (
+    franchise_id int not null,
+    film_id int not null,
+    PRIMARY KEY (franchise_id, film_id),
+    FOREIGN KEY (franchise_id) REFERENCES franchise (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
+
+-- related_film_id приходится фильму film_id тем, что указано в type
+CREATE TABLE IF NOT EXISTS film_relation (
+    film_id int not null,
+    related_film_id int not null,
+    type text not null CHECK (type in ('sequel', 'prequel', 'remake', 'spin_off')),
+    PRIMARY KEY (film_id, related_film_id),
+    CHECK (film_id <> related_film_id),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (related_film_id) REFERENCES film (id) on delete cascade
+);
+
+-- Между двумя фильмами может быть только одна связь
+CREATE UNIQUE INDEX IF NOT EXISTS film_relation_pair_idx
+    ON film_relation (least(film_id, related_film_id), greatest(film_id, related_film_id));
+CREATE INDEX IF NOT EXISTS film_relation_related_film_idx ON film_relation (related_film_id);
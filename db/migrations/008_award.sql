@@ -0,0 +1,40 @@
+-- Премии, их церемонии по годам, категории и номинации фильмов и персон
+CREATE TABLE IF NOT EXISTS award (
+    id serial PRIMARY KEY,
+    name text not null unique
+);
+
+CREATE TABLE IF NOT EXISTS award_ceremony (
+    id serial PRIMARY KEY,
+    award_id int not null,
+    year int not null,
+    UNIQUE (award_id, year),
+    FOREIGN KEY (award_id) REFERENCES award (id) on delete cascade
+);
+
+CREATE TABLE IF NOT EXISTS award_category (
+    id serial PRIMARY KEY,
+    award_id int not null,
+    name text not null,
+    UNIQUE (award_id, name),
+    FOREIGN KEY (award_id) REFERENCES award (id) on delete cascade
+);
+
+-- person_id заполняется, если номинирован актёр или участник съёмочной группы
+CREATE TABLE IF NOT EXISTS nomination (
+    id serial PRIMARY KEY,
+    ceremony_id int not null,
+    category_id int not null,
+    film_id int not null,
+    person_id int,
+    won boolean not null default false,
+    FOREIGN KEY (ceremony_id) REFERENCES award_ceremony (id) on delete cascade,
+    FOREIGN KEY (category_id) REFERENCES award_category (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (person_id) REFERENCES person (id) on delete cascade
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS nomination_unique_idx
+    ON nomination (ceremony_id, category_id, film_id, coalesce(person_id, 0));
+CREATE INDEX IF NOT EXISTS nomination_film_idx ON nomination (film_id);
+CREATE INDEX IF NOT EXISTS nomination_person_idx ON nomination (person_id);
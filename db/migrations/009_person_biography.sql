@@ -0,0 +1,8 @@
+-- Биография актёра, другие имена и внешние идентификаторы
+ALTER TABLE person ADD COLUMN date_of_death date;
+ALTER TABLE person ADD COLUMN birthplace text not null default '';
+ALTER TABLE person ADD COLUMN biography text not null default '';
+ALTER TABLE person ADD COLUMN aliases text[] not null default '{}';
+ALTER TABLE person ADD COLUMN imdb_id text unique;
+ALTER TABLE person ADD COLUMN kinopoisk_id int unique;
+ALTER TABLE person ADD CONSTRAINT person_lifespan_check CHECK (date_of_death >= date_of_birth);
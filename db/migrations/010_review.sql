@@ -0,0 +1,15 @@
+-- Оценки и отзывы пользователей, одна оценка пользователя на фильм
+CREATE TABLE IF NOT EXISTS review (
+    id serial PRIMARY KEY,
+    film_id int not null,
+    user_id int not null,
+    rating int not null CHECK (rating BETWEEN 1 AND 10),
+    text text not null default '',
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    UNIQUE (film_id, user_id),
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade,
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade
+);
+
+CREATE INDEX IF NOT EXISTS review_user_idx ON review (user_id);
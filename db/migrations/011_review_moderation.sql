@@ -0,0 +1,21 @@
+-- Модерация отзывов: статус, решение модератора и жалобы пользователей.
+-- Уже написанные отзывы считаются опубликованными
+ALTER TABLE review ADD COLUMN IF NOT EXISTS status text not null default 'published'
+    CHECK (status IN ('pending', 'published', 'rejected', 'hidden'));
+ALTER TABLE review ADD COLUMN IF NOT EXISTS moderation_reason text not null default '';
+ALTER TABLE review ADD COLUMN IF NOT EXISTS moderated_by int REFERENCES service_user (id) on delete set null;
+ALTER TABLE review ADD COLUMN IF NOT EXISTS moderated_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS review_status_idx ON review (status);
+
+CREATE TABLE IF NOT EXISTS review_flag (
+    review_id int not null,
+    user_id int not null,
+    reason text not null default '',
+    created_at timestamptz not null default now(),
+    PRIMARY KEY (review_id, user_id),
+    FOREIGN KEY (review_id) REFERENCES review (id) on delete cascade,
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade
+);
+
+INSERT INTO service_user (role) values ('Модератор');
@@ -0,0 +1,14 @@
+-- Личные списки пользователей: посмотреть позже, избранное и просмотренные
+CREATE TABLE IF NOT EXISTS user_film_list (
+    user_id int not null,
+    list text not null CHECK (list IN ('watchlist', 'favorites', 'watched')),
+    film_id int not null,
+    -- Дата просмотра, только в списке watched
+    watched_at date,
+    added_at timestamptz not null default now(),
+    PRIMARY KEY (user_id, list, film_id),
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
+
+CREATE INDEX IF NOT EXISTS user_film_list_film_idx ON user_film_list (film_id);
@@ -0,0 +1,29 @@
+-- Коллекции фильмов пользователей с адресом slug
+CREATE TABLE IF NOT EXISTS collection (
+    id serial PRIMARY KEY,
+    user_id int not null,
+    slug text not null UNIQUE,
+    name text not null,
+    description text not null default '',
+    is_public boolean not null default false,
+    -- Коллекция, скопированная в эту
+    forked_from int,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now(),
+    FOREIGN KEY (user_id) REFERENCES service_user (id) on delete cascade,
+    FOREIGN KEY (forked_from) REFERENCES collection (id) on delete set null
+);
+
+CREATE INDEX IF NOT EXISTS collection_user_idx ON collection (user_id);
+CREATE INDEX IF NOT EXISTS collection_forked_from_idx ON collection (forked_from);
+
+CREATE TABLE IF NOT EXISTS collection_film (
+    collection_id int not null,
+    film_id int not null,
+    -- Порядок фильмов в коллекции, начиная с 1
+    position int not null,
+    note text not null default '',
+    PRIMARY KEY (collection_id, film_id),
+    FOREIGN KEY (collection_id) REFERENCES collection (id) on delete cascade,
+    FOREIGN KEY (film_id) REFERENCES film (id) on delete cascade
+);
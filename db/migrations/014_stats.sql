@@ -0,0 +1,58 @@
+-- Статистика каталога в материализованных представлениях. Сервис обновляет их по расписанию
+-- и по запросу администратора, уникальные индексы нужны для REFRESH ... CONCURRENTLY.
+-- Для роста каталога у фильмов появляется дата добавления, у существующих это дата миграции
+ALTER TABLE film ADD COLUMN IF NOT EXISTS created_at timestamptz not null default now();
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_films_per_year AS
+    SELECT extract(year from release_date)::int AS year, count(*)::int AS film_count
+    FROM film
+    GROUP BY 1;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_films_per_year_idx ON stats_films_per_year (year);
+
+-- Сколько фильмов получили каждую редакционную оценку и сколько раз её ставили пользователи
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_rating_distribution AS
+    SELECT s.rating, coalesce(f.film_count, 0) AS film_count, coalesce(r.review_count, 0) AS review_count
+    FROM generate_series(0, 10) AS s (rating)
+    LEFT JOIN (SELECT rating, count(*)::int AS film_count FROM film GROUP BY rating) AS f
+        ON f.rating = s.rating
+    LEFT JOIN (SELECT rating, count(*)::int AS review_count FROM review WHERE status = 'published'
+        GROUP BY rating) AS r ON r.rating = s.rating;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_rating_distribution_idx ON stats_rating_distribution (rating);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_genre_rating AS
+    SELECT g.id AS genre_id, g.name AS genre, count(f.id)::int AS film_count,
+        round(avg(f.rating), 2)::float8 AS average_rating,
+        (SELECT round(avg(r.rating), 2)::float8 FROM review AS r
+            JOIN film_genre AS rfg ON rfg.film_id = r.film_id
+            WHERE rfg.genre_id = g.id AND r.status = 'published') AS average_community_rating
+    FROM genre AS g
+    LEFT JOIN film_genre AS fg ON fg.genre_id = g.id
+    LEFT JOIN film AS f ON f.id = fg.film_id
+    GROUP BY g.id, g.name;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_genre_rating_idx ON stats_genre_rating (genre_id);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_actor_film_count AS
+    SELECT p.id AS actor_id, p.name, count(DISTINCT af.film_id)::int AS film_count
+    FROM person AS p
+    JOIN actor_film AS af ON af.actor_id = p.id
+    GROUP BY p.id, p.name;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_actor_film_count_idx ON stats_actor_film_count (actor_id);
+
+-- Роли в фильмах каждого года по полу актёров
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_cast_gender_per_year AS
+    SELECT extract(year from f.release_date)::int AS year,
+        count(*) FILTER (WHERE p.gender = 'Мужской')::int AS male,
+        count(*) FILTER (WHERE p.gender = 'Женский')::int AS female,
+        count(*) FILTER (WHERE p.gender NOT IN ('Мужской', 'Женский'))::int AS unknown
+    FROM actor_film AS af
+    JOIN film AS f ON f.id = af.film_id
+    JOIN person AS p ON p.id = af.actor_id
+    GROUP BY 1;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_cast_gender_per_year_idx ON stats_cast_gender_per_year (year);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS stats_catalog_growth AS
+    SELECT to_char(m.month, 'YYYY-MM') AS month, m.films_added,
+        (sum(m.films_added) OVER (ORDER BY m.month))::int AS total_films
+    FROM (SELECT date_trunc('month', created_at) AS month, count(*)::int AS films_added
+        FROM film GROUP BY 1) AS m;
+CREATE UNIQUE INDEX IF NOT EXISTS stats_catalog_growth_idx ON stats_catalog_growth (month);
@@ -0,0 +1,10 @@
+-- Даты добавления и изменения для лент Atom и RSS. У существующих записей это дата миграции
+ALTER TABLE film ADD COLUMN IF NOT EXISTS updated_at timestamptz not null default now();
+ALTER TABLE person
+    ADD COLUMN IF NOT EXISTS created_at timestamptz not null default now(),
+    ADD COLUMN IF NOT EXISTS updated_at timestamptz not null default now();
+-- Когда фильм появился в фильмографии актёра
+ALTER TABLE actor_film ADD COLUMN IF NOT EXISTS created_at timestamptz not null default now();
+
+CREATE INDEX IF NOT EXISTS film_created_at_idx ON film (created_at);
+CREATE INDEX IF NOT EXISTS actor_film_actor_idx ON actor_film (actor_id, created_at);
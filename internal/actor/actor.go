@@ -0,0 +1,26 @@
+package actor
+
+import "vk-intern_test-case/utils/apperror"
+
+var ErrNotFound = apperror.NotFound("actor_not_found", "Actor not found")
+
+// Поля сортировки при поиске актёров
+const (
+	SortByName        = "name"
+	SortByDateOfBirth = "date_of_birth"
+	SortByFilmCount   = "film_count"
+)
+
+func IsValidSortField(field string) bool {
+	switch field {
+	case SortByName, SortByDateOfBirth, SortByFilmCount:
+		return true
+	}
+	return false
+}
+
+// Размер страницы при поиске актёров
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
@@ -2,12 +2,23 @@ package delivery
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"vk-intern_test-case/internal/actor"
 	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/images"
+	"vk-intern_test-case/utils/patch"
 	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/storage"
+	"vk-intern_test-case/utils/validation"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -16,11 +27,13 @@ const logMessage = "actor:delivery:"
 
 type actorDelivery struct {
 	actorRepo actor.ActorRepository
+	storage   storage.Storage
 }
 
-func NewActorDelivery(aR actor.ActorRepository) *actorDelivery {
+func NewActorDelivery(aR actor.ActorRepository, store storage.Storage) *actorDelivery {
 	return &actorDelivery{
 		actorRepo: aR,
+		storage:   store,
 	}
 }
 
@@ -32,11 +45,21 @@ func (aD *actorDelivery) HandleActors(w http.ResponseWriter, r *http.Request) {
 		aD.AddActor(w, r)
 	case http.MethodPut:
 		aD.UpdateActor(w, r)
+	case http.MethodPatch:
+		aD.PatchActor(w, r)
 	case http.MethodDelete:
 		aD.DeleteActor(w, r)
 	}
 }
 
+// HandleActorPhoto handles /actors/{id}/photo
+func (aD *actorDelivery) HandleActorPhoto(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		aD.UploadPhoto(w, r)
+	}
+}
+
 // swagger:route POST /actors Actors addActor
 // Добавляет актёра в систему
 // security:
@@ -44,9 +67,10 @@ func (aD *actorDelivery) HandleActors(w http.ResponseWriter, r *http.Request) {
 // responses:
 //
 //	200: actor
-//	400: basicResponse
-//  401: basicResponse
-//	500: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	422: problemResponse
+//	500: problemResponse
 func (aD *actorDelivery) AddActor(w http.ResponseWriter, r *http.Request) {
 	message := logMessage + "AddActor:"
 	log.Info(message + "started")
@@ -54,14 +78,17 @@ func (aD *actorDelivery) AddActor(w http.ResponseWriter, r *http.Request) {
 	var actor models.Actor
 	err := json.NewDecoder(r.Body).Decode(&actor)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+	err = validation.ValidateActor(&actor.ActorRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
 		return
 	}
 	resultActor, err := aD.actorRepo.AddActor(&actor)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
 	response.WriteResponse(w, jsonEnc, http.StatusOK, resultActor)
@@ -69,85 +96,306 @@ func (aD *actorDelivery) AddActor(w http.ResponseWriter, r *http.Request) {
 
 // swagger:route PUT /actors/{id} Actors updateActor
 // Обновляет информацию об актёре. На вход полная информация.
+// Возвращает актёра после изменения.
 // security:
 // - key:
 // responses:
 //
-//	200: basicResponse
-//	400: basicResponse
-//  401: basicResponse
-//  500: basicResponse
+//	200: actor
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//  500: problemResponse
 func (aD *actorDelivery) UpdateActor(w http.ResponseWriter, r *http.Request) {
 	jsonEnc := response.MakeJsonEncoder(w)
 	id := strings.TrimPrefix(r.URL.Path, "/actors/")
 	actorID, err := strconv.Atoi(id)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidID)
 		return
 	}
 
 	var actor models.Actor
 	err = json.NewDecoder(r.Body).Decode(&actor)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
 		return
 	}
 
-	err = aD.actorRepo.UpdateActor(actorID, &actor)
+	err = validation.ValidateActor(&actor.ActorRequest).Err()
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
-	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+
+	resultActor, err := aD.actorRepo.UpdateActor(actorID, &actor)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultActor)
+}
+
+// swagger:route PATCH /actors/{id} Actors patchActor
+// Частично обновляет информацию об актёре. Обновляются только переданные поля.
+// Принимает JSON Merge Patch (RFC 7396) с Content-Type application/merge-patch+json или application/json
+// и JSON Patch (RFC 6902) с Content-Type application/json-patch+json.
+// Актёр после изменения проверяется целиком, поэтому у заглушки, созданной при добавлении фильма,
+// gender и date_of_birth заполняются в одном запросе.
+// Возвращает актёра после изменения.
+// consumes:
+// - application/merge-patch+json
+// - application/json-patch+json
+// security:
+// - key:
+// responses:
+//
+//	200: actor
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	415: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (aD *actorDelivery) PatchActor(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "PatchActor:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/actors/")
+	actorID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	patchBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	// Патч применяется к актёру, заблокированному в транзакции изменения
+	resultActor, err := aD.actorRepo.PatchActor(actorID, func(currentActor *models.ActorRequest) (map[string]any, error) {
+		var patchedActor models.ActorRequest
+		err := patch.ApplyTo(r.Header.Get("Content-Type"), currentActor, patchBody, &patchedActor)
+		if err != nil {
+			return nil, err
+		}
+		err = validation.ValidateActor(&patchedActor).Err()
+		if err != nil {
+			return nil, err
+		}
+		return patch.ChangedFields(currentActor, &patchedActor), nil
+	})
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultActor)
+}
+
+// swagger:route POST /actors/{id}/photo Actors uploadActorPhoto
+// Загружает фотографию актёра (JPEG, PNG или WebP) в поле file multipart-формы.
+// Метаданные изображения удаляются, создаются миниатюры шириной 160, 320 и 640 пикселей.
+// Предыдущая фотография заменяется. Возвращает ссылки на изображения
+// consumes:
+// - multipart/form-data
+// security:
+// - key:
+// responses:
+//
+//	200: image
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	415: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (aD *actorDelivery) UploadPhoto(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "UploadPhoto:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/actors/")
+	actorID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	data, err := images.ReadUpload(w, r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	processed, err := images.Process(data)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	_, err = aD.actorRepo.GetActor(actorID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	version, err := images.Save(aD.storage, fmt.Sprintf("actors/%d/photo", actorID), processed)
+	if err != nil {
+		log.Error(message + err.Error())
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = aD.actorRepo.SetActorPhoto(actorID, version.Image)
+	if err != nil {
+		if err := version.Discard(); err != nil {
+			log.Error(message + err.Error())
+		}
+		response.WriteError(w, r, err)
+		return
+	}
+	// Старые версии удаляются только после того, как в базе записана новая
+	if err := version.Commit(); err != nil {
+		log.Error(message + err.Error())
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, version.Image)
 }
 
 // swagger:route DELETE /actors/{id} Actors deleteActor
-// Удаляет актёра из системы.
+// Удаляет актёра из системы вместе с фотографией.
 // security:
 // - key:
 // responses:
 //
 //	200: basicResponse
-//	400: basicResponse
-//  401: basicResponse
-//	500: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
 func (aD *actorDelivery) DeleteActor(w http.ResponseWriter, r *http.Request) {
 	jsonEnc := response.MakeJsonEncoder(w)
 
 	id := strings.TrimPrefix(r.URL.Path, "/actors/")
 	actorID, err := strconv.Atoi(id)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidID)
 		return
 	}
 
 	err = aD.actorRepo.DeleteActor(actorID)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
+
+	err = aD.storage.DeleteAll(fmt.Sprintf("actors/%d", actorID))
+	if err != nil {
+		log.Error(logMessage + "DeleteActor:" + err.Error())
+	}
 	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
 }
 
 // swagger:route GET /actors Actors getActors
 // Возращает список актёров с их фильмами.
+// Если указан хотя бы один параметр поиска, возвращается страница найденных актёров,
+// а общее число найденных - в заголовке X-Total-Count.
+// name - фрагмент имени или другого имени, gender - пол, born_from и born_to - границы года рождения,
+// film_id - только снимавшиеся в фильме. sort_by - name (по умолчанию), date_of_birth или film_count,
+// limit (по умолчанию 20, не больше 100) и offset - страница
 // responses:
 //
 //	200: []actorWithFilms
-//	500: basicResponse
+//	400: problemResponse
+//	500: problemResponse
 func (aD *actorDelivery) GetActors(w http.ResponseWriter, r *http.Request) {
 	jsonEnc := response.MakeJsonEncoder(w)
+	if isActorSearch(r.URL.Query()) {
+		aD.SearchActors(w, r)
+		return
+	}
 
 	resultActors, err := aD.actorRepo.GetActors()
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
 	response.WriteResponse(w, jsonEnc, http.StatusOK, resultActors)
 }
+
+func (aD *actorDelivery) SearchActors(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "SearchActors:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	filter, err := parseActorFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultActors, total, err := aD.actorRepo.SearchActors(filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultActors)
+}
+
+// Параметры, с которыми GET /actors ищет актёров и возвращает страницу
+var actorSearchParams = []string{"name", "gender", "born_from", "born_to", "film_id", "sort_by", "limit", "offset"}
+
+// isActorSearch reports whether query has any of the search or paging parameters
+func isActorSearch(query url.Values) bool {
+	for _, param := range actorSearchParams {
+		if query.Has(param) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseActorFilter(r *http.Request) (*models.ActorFilter, error) {
+	query := r.URL.Query()
+	filter := &models.ActorFilter{
+		Name:   strings.TrimSpace(query.Get("name")),
+		Gender: query.Get("gender"),
+		SortBy: query.Get("sort_by"),
+		Limit:  actor.DefaultPageSize,
+	}
+	if filter.Gender != "" && !slices.Contains(validation.AllowedGenders, filter.Gender) {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Unknown gender")
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = actor.SortByName
+	}
+	if !actor.IsValidSortField(filter.SortBy) {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Unknown sort_by")
+	}
+
+	intParams := []struct {
+		name     string
+		target   *int
+		min, max int
+	}{
+		{"born_from", &filter.BornFrom, 1, 9999},
+		{"born_to", &filter.BornTo, 1, 9999},
+		{"film_id", &filter.FilmID, 1, math.MaxInt32},
+		{"limit", &filter.Limit, 1, actor.MaxPageSize},
+		{"offset", &filter.Offset, 0, math.MaxInt32},
+	}
+	for _, param := range intParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < param.min || number > param.max {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Invalid " + param.name)
+		}
+		*param.target = number
+	}
+	return filter, nil
+}
+
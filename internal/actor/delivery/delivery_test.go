@@ -6,10 +6,14 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"testing"
+	"vk-intern_test-case/internal/actor"
 	"vk-intern_test-case/internal/actor/mock"
 	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/storage"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +24,13 @@ func prepareTestEnvironment() *httptest.ResponseRecorder {
 	return responseRecorder
 }
 
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
 type addActorTest struct {
 	name               string
 	inputBodyJSON      string
@@ -64,6 +75,27 @@ var addActorTests = []addActorTest{
 		}`,
 		http.StatusOK,
 	},
+	{
+		"Fail to add new Actor with invalid gender",
+		`{ 
+			"name":"Леонардо Ди Каприо", 
+			"gender": "М",
+			"date_of_birth": "2002-07-13"
+		}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/actors",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "gender", "code": "not_allowed", "message": "gender must be one of: Мужской, Женский"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
 }
 
 func TestAddActor(t *testing.T) {
@@ -72,7 +104,7 @@ func TestAddActor(t *testing.T) {
 	for _, test := range addActorTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockActorRepository := mock.NewMockActorRepository(ctrl)
-			filmDeliveryTest := NewActorDelivery(mockActorRepository)
+			filmDeliveryTest := NewActorDelivery(mockActorRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockActorRepository)
 			}
@@ -88,7 +120,7 @@ func TestAddActor(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedFilmJSON, string(data))
 		})
 	}
@@ -121,15 +153,25 @@ var updateActorTests = []updateActorTest{
 						DateOfBirth: "2002-07-13",
 					},
 				}).
-				Return(nil)
+				Return(&models.Actor{
+					ID: 1,
+					ActorRequest: models.ActorRequest{
+						Name: "Леонардо Ди Каприо",
+						Gender: "Мужской",
+						DateOfBirth: "2002-07-13",
+					},
+				}, nil)
 		},
 		`{ 
-			"status":"OK"
+			"id": 1,
+			"name":"Леонардо Ди Каприо", 
+			"gender": "Мужской",
+			"date_of_birth": "2002-07-13"
 		}`,
 		http.StatusOK,
 	},
 	{
-		"Successfully add new Actor",
+		"Fail to update a non-existent Actor",
 		1,
 		`{ 
 			"name":"Леонардо Ди Каприо", 
@@ -145,10 +187,44 @@ var updateActorTests = []updateActorTest{
 						DateOfBirth: "2002-07-13",
 					},
 				}).
-				Return(errors.New("new error"))
+				Return(nil, actor.ErrNotFound)
 		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Actor not found",
+			"instance": "/actors/1",
+			"code": "actor_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully add new Actor",
+		1,
 		`{ 
-			"status":"new error"
+			"name":"Леонардо Ди Каприо", 
+			"gender": "Мужской",
+			"date_of_birth": "2002-07-13"
+		}`,
+		func(mockActorRepository *mock.MockActorRepository) {
+			mockActorRepository.EXPECT().
+				UpdateActor(1, &models.Actor{
+					ActorRequest: models.ActorRequest{
+						Name: "Леонардо Ди Каприо",
+						Gender: "Мужской",
+						DateOfBirth: "2002-07-13",
+					},
+				}).
+				Return(nil, errors.New("new error"))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Internal Server Error",
+			"status": 500,
+			"detail": "Internal server error",
+			"instance": "/actors/1",
+			"code": "internal_error"
 		}`,
 		http.StatusInternalServerError,
 	},
@@ -160,7 +236,7 @@ func TestUpdateActor(t *testing.T) {
 	for _, test := range updateActorTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockActorRepository := mock.NewMockActorRepository(ctrl)
-			filmDeliveryTest := NewActorDelivery(mockActorRepository)
+			filmDeliveryTest := NewActorDelivery(mockActorRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockActorRepository)
 			}
@@ -176,7 +252,164 @@ func TestUpdateActor(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedFilmJSON, string(data))
+		})
+	}
+}
+
+type patchActorTest struct {
+	name               string
+	inputBodyJSON      string
+	beforeTest         func(mockFilmRepository *mock.MockActorRepository)
+	expectedFilmJSON   string
+	expectedStatusCode int
+}
+
+// patchActor emulates PatchActor of the repository: applies the patch to the current actor
+// and checks the fields it changed
+func patchActor(current *models.Actor, expectedFields map[string]any, result *models.Actor) func(int, func(*models.ActorRequest) (map[string]any, error)) (*models.Actor, error) {
+	return func(actorID int, apply func(*models.ActorRequest) (map[string]any, error)) (*models.Actor, error) {
+		currentActor := current.ActorRequest
+		fields, err := apply(&currentActor)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(expectedFields, fields) {
+			return nil, fmt.Errorf("unexpected patched fields %v", fields)
+		}
+		return result, nil
+	}
+}
+
+var patchActorTests = []patchActorTest{
+	{
+		"Successfully patch an Actor",
+		`{"gender": "Женский"}`,
+		func(mockActorRepository *mock.MockActorRepository) {
+			currentActor := &models.Actor{
+				ID: 1,
+				ActorRequest: models.ActorRequest{
+					Name:        "Марго Робби",
+					Gender:      "Мужской",
+					DateOfBirth: "1990-07-02",
+				},
+			}
+			mockActorRepository.EXPECT().
+				PatchActor(1, gomock.Any()).
+				DoAndReturn(patchActor(currentActor, map[string]any{"gender": "Женский"}, &models.Actor{
+					ID: 1,
+					ActorRequest: models.ActorRequest{
+						Name:        "Марго Робби",
+						Gender:      "Женский",
+						DateOfBirth: "1990-07-02",
+					},
+				}))
+		},
+		`{ 
+			"id": 1,
+			"name":"Марго Робби", 
+			"gender": "Женский",
+			"date_of_birth": "1990-07-02"
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to complete a placeholder Actor with date_of_birth only",
+		`{"date_of_birth": "1995-12-27"}`,
+		func(mockActorRepository *mock.MockActorRepository) {
+			currentActor := &models.Actor{
+				ID:           1,
+				ActorRequest: models.ActorRequest{Name: "Тимоти Шаламе"},
+			}
+			mockActorRepository.EXPECT().
+				PatchActor(1, gomock.Any()).
+				DoAndReturn(patchActor(currentActor, nil, nil))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/actors/1",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "gender", "code": "required", "message": "gender is required"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Successfully complete a placeholder Actor",
+		`{"gender": "Мужской", "date_of_birth": "1995-12-27"}`,
+		func(mockActorRepository *mock.MockActorRepository) {
+			currentActor := &models.Actor{
+				ID:           1,
+				ActorRequest: models.ActorRequest{Name: "Тимоти Шаламе"},
+			}
+			mockActorRepository.EXPECT().
+				PatchActor(1, gomock.Any()).
+				DoAndReturn(patchActor(currentActor, map[string]any{"gender": "Мужской", "date_of_birth": "1995-12-27"}, &models.Actor{
+					ID: 1,
+					ActorRequest: models.ActorRequest{
+						Name:        "Тимоти Шаламе",
+						Gender:      "Мужской",
+						DateOfBirth: "1995-12-27",
+					},
+				}))
+		},
+		`{
+			"id": 1,
+			"name": "Тимоти Шаламе",
+			"gender": "Мужской",
+			"date_of_birth": "1995-12-27"
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to patch a non-existent Actor",
+		`{"gender": "Женский"}`,
+		func(mockActorRepository *mock.MockActorRepository) {
+			mockActorRepository.EXPECT().
+				PatchActor(1, gomock.Any()).
+				Return(nil, actor.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Actor not found",
+			"instance": "/actors/1",
+			"code": "actor_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestPatchActor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range patchActorTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockActorRepository := mock.NewMockActorRepository(ctrl)
+			actorDeliveryTest := NewActorDelivery(mockActorRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+			if test.beforeTest != nil {
+				test.beforeTest(mockActorRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%d", "/actors", 1), strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+			request.Header.Set("Content-Type", "application/merge-patch+json")
+
+			actorDeliveryTest.HandleActors(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedFilmJSON, string(data))
 		})
 	}
@@ -212,7 +445,7 @@ func TestDeleteActor(t *testing.T) {
 	for _, test := range deleteActorTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockActorRepository := mock.NewMockActorRepository(ctrl)
-			filmDeliveryTest := NewActorDelivery(mockActorRepository)
+			filmDeliveryTest := NewActorDelivery(mockActorRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockActorRepository)
 			}
@@ -228,7 +461,7 @@ func TestDeleteActor(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedFilmJSON, string(data))
 		})
 	}
@@ -257,23 +490,28 @@ var getActorsTests = []getActorsTest{
 								DateOfBirth: "2014-03-18",
 							},
 						},
-						Films: []models.Film{
+						Films: []models.ActingCredit{
 							{
-								ID: 1,
-								FilmRequest: models.FilmRequest{
-									Title: "Титаник", 
-									Description: "cool film", 
-									ReleaseDate: "2020-06-10", 
-									Rating: 8,
+								Film: models.Film{
+									ID: 1,
+									FilmRequest: models.FilmRequest{
+										Title: "Титаник", 
+										Description: "cool film", 
+										ReleaseDate: "2020-06-10", 
+										Rating: 8,
+									},
 								},
+								AgeAtRelease: intPtr(6),
 							},
 							{
-								ID: 2,
-								FilmRequest: models.FilmRequest{
-									Title: "Титаник 2", 
-									Description: "cool film", 
-									ReleaseDate: "2022-06-10", 
-									Rating: 7,
+								Film: models.Film{
+									ID: 2,
+									FilmRequest: models.FilmRequest{
+										Title: "Титаник 2", 
+										Description: "cool film", 
+										ReleaseDate: "2022-06-10", 
+										Rating: 7,
+									},
 								},
 							},
 						},
@@ -287,14 +525,16 @@ var getActorsTests = []getActorsTest{
 								DateOfBirth: "2014-03-18",
 							},
 						},
-						Films: []models.Film{
+						Films: []models.ActingCredit{
 							{
-								ID: 3,
-								FilmRequest: models.FilmRequest{
-									Title: "Барби", 
-									Description: "cool film", 
-									ReleaseDate: "2020-06-10", 
-									Rating: 8,
+								Film: models.Film{
+									ID: 3,
+									FilmRequest: models.FilmRequest{
+										Title: "Барби", 
+										Description: "cool film", 
+										ReleaseDate: "2020-06-10", 
+										Rating: 8,
+									},
 								},
 							},
 						},
@@ -313,7 +553,8 @@ var getActorsTests = []getActorsTest{
 				  "title": "Титаник",
 				  "description": "cool film",
 				  "release_date": "2020-06-10",
-				  "rating": 8
+				  "rating": 8,
+				  "age_at_release": 6
 				},
 				{
 				  "id": 2,
@@ -344,13 +585,17 @@ var getActorsTests = []getActorsTest{
 	},
 }
 
+func intPtr(value int) *int {
+	return &value
+}
+
 func TestGetActors(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 	for _, test := range getActorsTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockActorRepository := mock.NewMockActorRepository(ctrl)
-			filmDeliveryTest := NewActorDelivery(mockActorRepository)
+			filmDeliveryTest := NewActorDelivery(mockActorRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockActorRepository)
 			}
@@ -366,8 +611,158 @@ func TestGetActors(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedFilmJSON, string(data))
 		})
 	}
-}
\ No newline at end of file
+}
+type searchActorsTest struct {
+	name               string
+	path               string
+	beforeTest         func(mockActorRepository *mock.MockActorRepository)
+	expectedJSON       string
+	expectedTotalCount string
+	expectedStatusCode int
+}
+
+var searchActorsTests = []searchActorsTest{
+	{
+		"Successfully search Actors",
+		"/actors?name=ди&gender=Мужской&born_from=1970&born_to=1980&film_id=1&sort_by=film_count&limit=10&offset=10",
+		func(mockActorRepository *mock.MockActorRepository) {
+			mockActorRepository.EXPECT().
+				SearchActors(&models.ActorFilter{
+					Name:     "ди",
+					Gender:   "Мужской",
+					BornFrom: 1970,
+					BornTo:   1980,
+					FilmID:   1,
+					SortBy:   actor.SortByFilmCount,
+					Limit:    10,
+					Offset:   10,
+				}).
+				Return([]models.ActorWithFilms{
+					{
+						Actor: models.Actor{
+							ID: 1,
+							ActorRequest: models.ActorRequest{
+								Name:        "Леонардо Ди Каприо",
+								Gender:      "Мужской",
+								DateOfBirth: "1974-11-11",
+							},
+						},
+						Films: []models.ActingCredit{},
+					},
+				}, 11, nil)
+		},
+		`[
+			{
+				"id": 1,
+				"name": "Леонардо Ди Каприо",
+				"gender": "Мужской",
+				"date_of_birth": "1974-11-11",
+				"films": []
+			}
+		]`,
+		"11",
+		http.StatusOK,
+	},
+	{
+		"Search Actors with default sorting and page size",
+		"/actors?name=%20Марго%20",
+		func(mockActorRepository *mock.MockActorRepository) {
+			mockActorRepository.EXPECT().
+				SearchActors(&models.ActorFilter{Name: "Марго", SortBy: actor.SortByName, Limit: actor.DefaultPageSize}).
+				Return([]models.ActorWithFilms{}, 0, nil)
+		},
+		`[]`,
+		"0",
+		http.StatusOK,
+	},
+	{
+		"Unrelated query parameters do not switch to search",
+		"/actors?foo=1",
+		func(mockActorRepository *mock.MockActorRepository) {
+			mockActorRepository.EXPECT().
+				GetActors().
+				Return([]models.ActorWithFilms{}, nil)
+		},
+		`[]`,
+		"",
+		http.StatusOK,
+	},
+	{
+		"Fail to search Actors with unknown gender",
+		"/actors?gender=unknown",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown gender",
+			"instance": "/actors",
+			"code": "invalid_query"
+		}`,
+		"",
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to search Actors with unknown sort field",
+		"/actors?sort_by=rating",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown sort_by",
+			"instance": "/actors",
+			"code": "invalid_query"
+		}`,
+		"",
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to search Actors with too large limit",
+		"/actors?limit=1000",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid limit",
+			"instance": "/actors",
+			"code": "invalid_query"
+		}`,
+		"",
+		http.StatusBadRequest,
+	},
+}
+
+func TestSearchActors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range searchActorsTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockActorRepository := mock.NewMockActorRepository(ctrl)
+			actorDeliveryTest := NewActorDelivery(mockActorRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+			if test.beforeTest != nil {
+				test.beforeTest(mockActorRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(http.MethodGet, test.path, nil)
+			assert.Nil(t, err)
+
+			actorDeliveryTest.HandleActors(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, test.expectedTotalCount, result.Header.Get("X-Total-Count"))
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
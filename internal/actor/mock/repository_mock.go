@@ -63,6 +63,21 @@ func (mr *MockActorRepositoryMockRecorder) DeleteActor(arg0 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteActor", reflect.TypeOf((*MockActorRepository)(nil).DeleteActor), arg0)
 }
 
+// GetActor mocks base method.
+func (m *MockActorRepository) GetActor(arg0 int) (*models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActor", arg0)
+	ret0, _ := ret[0].(*models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActor indicates an expected call of GetActor.
+func (mr *MockActorRepositoryMockRecorder) GetActor(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActor", reflect.TypeOf((*MockActorRepository)(nil).GetActor), arg0)
+}
+
 // GetActors mocks base method.
 func (m *MockActorRepository) GetActors() ([]models.ActorWithFilms, error) {
 	m.ctrl.T.Helper()
@@ -78,14 +93,60 @@ func (mr *MockActorRepositoryMockRecorder) GetActors() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActors", reflect.TypeOf((*MockActorRepository)(nil).GetActors))
 }
 
-// UpdateActor mocks base method.
-func (m *MockActorRepository) UpdateActor(arg0 int, arg1 *models.Actor) error {
+// PatchActor mocks base method.
+func (m *MockActorRepository) PatchActor(actorID int, apply func(*models.ActorRequest) (map[string]any, error)) (*models.Actor, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateActor", arg0, arg1)
+	ret := m.ctrl.Call(m, "PatchActor", actorID, apply)
+	ret0, _ := ret[0].(*models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchActor indicates an expected call of PatchActor.
+func (mr *MockActorRepositoryMockRecorder) PatchActor(actorID, apply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchActor", reflect.TypeOf((*MockActorRepository)(nil).PatchActor), actorID, apply)
+}
+
+// SearchActors mocks base method.
+func (m *MockActorRepository) SearchActors(arg0 *models.ActorFilter) ([]models.ActorWithFilms, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchActors", arg0)
+	ret0, _ := ret[0].([]models.ActorWithFilms)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchActors indicates an expected call of SearchActors.
+func (mr *MockActorRepositoryMockRecorder) SearchActors(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchActors", reflect.TypeOf((*MockActorRepository)(nil).SearchActors), arg0)
+}
+
+// SetActorPhoto mocks base method.
+func (m *MockActorRepository) SetActorPhoto(actorID int, photo *models.Image) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetActorPhoto", actorID, photo)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SetActorPhoto indicates an expected call of SetActorPhoto.
+func (mr *MockActorRepositoryMockRecorder) SetActorPhoto(actorID, photo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetActorPhoto", reflect.TypeOf((*MockActorRepository)(nil).SetActorPhoto), actorID, photo)
+}
+
+// UpdateActor mocks base method.
+func (m *MockActorRepository) UpdateActor(arg0 int, arg1 *models.Actor) (*models.Actor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActor", arg0, arg1)
+	ret0, _ := ret[0].(*models.Actor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateActor indicates an expected call of UpdateActor.
 func (mr *MockActorRepositoryMockRecorder) UpdateActor(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
@@ -1,14 +1,67 @@
 package queries
 
+import filmQueries "vk-intern_test-case/internal/film/queries"
+
+// Колонки актёра в порядке, ожидаемом repository.ScanActor. Пустые внешние идентификаторы хранятся как null
+const ActorColumns = `p.id, p.name, p.gender, p.date_of_birth, p.photo, p.date_of_death, p.birthplace, p.biography,
+	p.aliases, coalesce(p.imdb_id, ''), coalesce(p.kinopoisk_id, 0)`
+
+// Актёры хранятся в общей таблице person вместе с остальными участниками съёмочной группы
 const (
-	CreateAnActor     = `insert into actor (name, gender, date_of_birth) values ($1, $2, $3) returning id;`
-	GetActorIdByName  = `select id from actor where name = $1;`
-	UpdateActor       = `update actor set name = $1, gender = $2, date_of_birth = $3 where id = $4;`
-	GetActorByID      = `select * from actor where id = $1;`
-	DeleteActor       = `delete from actor where id = $1;`
-	GetActors         = `select * from actor;`
-	GetFilmsByActorId = `select f.id, f.title, f.description, f.release_date, f.rating from film as f
+	CreateAnActor = `insert into person (name, gender, date_of_birth, date_of_death, birthplace, biography,
+		aliases, imdb_id, kinopoisk_id)
+		values ($1, $2, $3, nullif($4, '')::date, $5, $6, $7, nullif($8, ''), nullif($9, 0)) returning id;`
+	GetActorIdByName = `select id from person where name = $1;`
+	// Актёр из состава фильма ищется по имени или одному из других имён, точное совпадение имени важнее
+	ResolveActorIdByName = `select id from person where name = $1 or $1 = any(aliases)
+		order by name = $1 desc, id limit 1;`
+	CreatePlaceholderActor = `insert into person (name, gender, date_of_birth) values ($1, '', null) returning id;`
+	UpdateActor            = `update person as p set name = $1, gender = $2, date_of_birth = $3,
+		date_of_death = nullif($4, '')::date, birthplace = $5, biography = $6, aliases = $7,
+		imdb_id = nullif($8, ''), kinopoisk_id = nullif($9, 0), updated_at = now()
+		where id = $10 returning ` + ActorColumns + `;`
+	GetActorByID  = `select ` + ActorColumns + ` from person as p where p.id = $1;`
+	// Актёр блокируется до конца транзакции, чтобы параллельные изменения не затёрли друг друга
+	LockActorByID = `select ` + ActorColumns + ` from person as p where p.id = $1 for update;`
+	PatchActor    = `update person as p set %s, updated_at = now() where id = $%d returning ` + ActorColumns + `;`
+	DeleteActor   = `delete from person where id = $1;`
+	SetActorPhoto = `update person set photo = $1, updated_at = now() where id = $2;`
+	// Актёрами считаются все, кроме тех, у кого есть только работы в съёмочной группе
+	actorsWhere = ` from person as p
+		where (exists (select 1 from actor_film as af where af.actor_id = p.id)
+			or not exists (select 1 from film_crew as fc where fc.person_id = p.id))`
+	GetActors    = `select ` + ActorColumns + actorsWhere + `;`
+	SearchActors = `select ` + ActorColumns + actorsWhere
+	CountActors  = `select count(*)` + actorsWhere
+	// Условия поиска актёров, во все места $%d подставляется номер параметра.
+	// В имени для поиска \, % и _ экранируются обратной косой чертой
+	ActorNameCondition = `(lower(p.name) like '%%' || lower($%d) || '%%' escape '\'
+		or exists (select 1 from unnest(p.aliases) as alias
+			where lower(alias) like '%%' || lower($%d) || '%%' escape '\'))`
+	ActorGenderCondition   = `p.gender = $%d`
+	ActorBornFromCondition = `p.date_of_birth >= make_date($%d, 1, 1)`
+	ActorBornToCondition   = `p.date_of_birth < make_date($%d::int + 1, 1, 1)`
+	ActorFilmCondition     = `exists (select 1 from actor_film as af where af.actor_id = p.id and af.film_id = $%d)`
+	GetFilmsByActorId      = `select ` + filmQueries.FilmColumns + `
+		from film as f
 		join actor_film as af on f.id = af.film_id
-		join actor as a on a.id = af.actor_id
-		where a.id = $1;`
+		where af.actor_id = $1;`
 )
+
+// Сортировки при поиске актёров по полю из sort_by. По умолчанию - по имени
+var ActorsOrderBy = map[string]string{
+	"name":          ` order by p.name, p.id`,
+	"date_of_birth": ` order by p.date_of_birth nulls last, p.id`,
+	"film_count":    ` order by (select count(*) from actor_film as af where af.actor_id = p.id) desc, p.name, p.id`,
+}
+
+// Поля актёра, которые можно изменить через PATCH. Имена в JSON совпадают с колонками
+var PatchableActorFields = []string{"name", "gender", "date_of_birth", "date_of_death", "birthplace", "biography",
+	"aliases", "imdb_id", "kinopoisk_id"}
+
+// Выражения для полей, пустое значение которых хранится как null
+var NullableActorFields = map[string]string{
+	"date_of_death": "nullif($%d, '')::date",
+	"imdb_id":       "nullif($%d, '')",
+	"kinopoisk_id":  "nullif($%d, 0)",
+}
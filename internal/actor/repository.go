@@ -4,7 +4,11 @@ import "vk-intern_test-case/models"
 
 type ActorRepository interface {
 	AddActor(*models.Actor) (*models.Actor, error)
-	UpdateActor(int, *models.Actor) error
+	UpdateActor(int, *models.Actor) (*models.Actor, error)
+	GetActor(int) (*models.Actor, error)
+	PatchActor(actorID int, apply func(*models.ActorRequest) (map[string]any, error)) (*models.Actor, error)
 	DeleteActor(int) error
+	SetActorPhoto(actorID int, photo *models.Image) error
 	GetActors() ([]models.ActorWithFilms, error)
+	SearchActors(*models.ActorFilter) ([]models.ActorWithFilms, int, error)
 }
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
+	actorPackage "vk-intern_test-case/internal/actor"
 	"vk-intern_test-case/models"
 	"vk-intern_test-case/utils/database"
 
 	actorQueries "vk-intern_test-case/internal/actor/queries"
+	filmRepository "vk-intern_test-case/internal/film/repository"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -55,19 +59,136 @@ func (aR *ActorRepository) AddActor(actor *models.Actor) (*models.Actor, error)
 		return nil, err
 	}
 
-	row = tx.QueryRow(transactionCtx, actorQueries.CreateAnActor,
-		&actor.Name,
-		&actor.Gender,
-		&actor.DateOfBirth,
-	)
+	row = tx.QueryRow(transactionCtx, actorQueries.CreateAnActor, actorArgs(&actor.ActorRequest)...)
 	err = row.Scan(&actor.ID)
 	if err != nil {
-		return nil, err
+		return nil, database.MapError(err)
 	}
 	return actor, nil
 }
 
-func (aR *ActorRepository) UpdateActor(actorID int, actor *models.Actor) error {
+func (aR *ActorRepository) UpdateActor(actorID int, actor *models.Actor) (*models.Actor, error) {
+	transactionCtx := context.Background()
+	tx, err := aR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, actorQueries.UpdateActor, append(actorArgs(&actor.ActorRequest), &actorID)...)
+	resultActor, err := ScanActor(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, actorPackage.ErrNotFound
+		}
+		return nil, database.MapError(err)
+	}
+
+	return resultActor, nil
+}
+
+func (aR *ActorRepository) GetActor(actorID int) (*models.Actor, error) {
+	transactionCtx := context.Background()
+	tx, err := aR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, actorQueries.GetActorByID, &actorID)
+	resultActor, err := ScanActor(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, actorPackage.ErrNotFound
+		}
+		return nil, err
+	}
+	return resultActor, nil
+}
+
+// PatchActor locks the actor, passes its current state to apply and stores the fields apply returned
+// in the same transaction, so concurrent updates can not be lost between reading and writing
+func (aR *ActorRepository) PatchActor(actorID int, apply func(*models.ActorRequest) (map[string]any, error)) (*models.Actor, error) {
+	message := logMessage + "PatchActor:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := aR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	resultActor, err := ScanActor(tx.QueryRow(transactionCtx, actorQueries.LockActorByID, &actorID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = actorPackage.ErrNotFound
+			return nil, err
+		}
+		log.Error(message + err.Error())
+		return nil, err
+	}
+
+	fields, err := apply(&resultActor.ActorRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	setClauses := []string{}
+	args := []any{}
+	for _, field := range actorQueries.PatchableActorFields {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+		if values, isSlice := value.([]string); isSlice && values == nil {
+			value = []string{}
+		}
+		args = append(args, value)
+		expression, ok := actorQueries.NullableActorFields[field]
+		if !ok {
+			expression = "$%d"
+		}
+		setClauses = append(setClauses, fmt.Sprintf("%s = "+expression, field, len(args)))
+	}
+	if len(setClauses) == 0 {
+		return resultActor, nil
+	}
+
+	args = append(args, actorID)
+	query := fmt.Sprintf(actorQueries.PatchActor, strings.Join(setClauses, ", "), len(args))
+	resultActor, err = ScanActor(tx.QueryRow(transactionCtx, query, args...))
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return resultActor, nil
+}
+
+func (aR *ActorRepository) DeleteActor(actorID int) error {
 	transactionCtx := context.Background()
 	tx, err := aR.pool.Begin(transactionCtx)
 	if err != nil {
@@ -83,15 +204,19 @@ func (aR *ActorRepository) UpdateActor(actorID int, actor *models.Actor) error {
 		}
 	}()
 
-	_, err = tx.Exec(transactionCtx, actorQueries.UpdateActor, &actor.Name, &actor.Gender, &actor.DateOfBirth, &actorID)
+	commandTag, err := tx.Exec(transactionCtx, actorQueries.DeleteActor, &actorID)
 	if err != nil {
 		return err
 	}
+	if commandTag.RowsAffected() == 0 {
+		err = actorPackage.ErrNotFound
+		return err
+	}
 
 	return nil
 }
 
-func (aR *ActorRepository) DeleteActor(actorID int) error {
+func (aR *ActorRepository) SetActorPhoto(actorID int, photo *models.Image) error {
 	transactionCtx := context.Background()
 	tx, err := aR.pool.Begin(transactionCtx)
 	if err != nil {
@@ -107,11 +232,14 @@ func (aR *ActorRepository) DeleteActor(actorID int) error {
 		}
 	}()
 
-	_, err = tx.Exec(transactionCtx, actorQueries.DeleteActor, &actorID)
+	commandTag, err := tx.Exec(transactionCtx, actorQueries.SetActorPhoto, photo, &actorID)
 	if err != nil {
 		return err
 	}
-
+	if commandTag.RowsAffected() == 0 {
+		err = actorPackage.ErrNotFound
+		return err
+	}
 	return nil
 }
 
@@ -131,40 +259,127 @@ func (aR *ActorRepository) GetActors() ([]models.ActorWithFilms, error) {
 		}
 	}()
 
-	actorsWithFilms := []models.ActorWithFilms{}
-	rows, err := tx.Query(transactionCtx, actorQueries.GetActors)
+	actorsWithFilms, err := getActorsWithFilms(transactionCtx, tx, actorQueries.GetActors)
 	if err != nil {
 		return []models.ActorWithFilms{}, err
 	}
+	return actorsWithFilms, nil
+}
+
+// SearchActors возвращает страницу актёров, подходящих под фильтр, и общее число таких актёров
+func (aR *ActorRepository) SearchActors(filter *models.ActorFilter) ([]models.ActorWithFilms, int, error) {
+	message := logMessage + "SearchActors:"
+	log.Debug(message + "started")
+	orderBy, ok := actorQueries.ActorsOrderBy[filter.SortBy]
+	if !ok {
+		orderBy = actorQueries.ActorsOrderBy["name"]
+	}
+	conditions, args := actorConditions(filter)
+
+	transactionCtx := context.Background()
+	tx, err := aR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.ActorWithFilms{}, 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var total int
+	row := tx.QueryRow(transactionCtx, actorQueries.CountActors+conditions, args...)
+	err = row.Scan(&total)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.ActorWithFilms{}, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := actorQueries.SearchActors + conditions + orderBy + fmt.Sprintf(" limit $%d offset $%d", len(args)-1, len(args))
+	actorsWithFilms, err := getActorsWithFilms(transactionCtx, tx, query, args...)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.ActorWithFilms{}, 0, err
+	}
+	return actorsWithFilms, total, nil
+}
+
+// escapeLike escapes wildcards of like so the value is matched literally
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// actorConditions returns conditions of the filter joined with and, starting with " and "
+func actorConditions(filter *models.ActorFilter) (string, []any) {
+	conditions := []string{}
+	args := []any{}
+	// addCondition добавляет условие, во все места $%d которого подставляется номер нового параметра
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		indexes := make([]any, strings.Count(condition, "$%d"))
+		for i := range indexes {
+			indexes[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, indexes...))
+	}
+
+	if filter.Name != "" {
+		addCondition(actorQueries.ActorNameCondition, escapeLike(filter.Name))
+	}
+	if filter.Gender != "" {
+		addCondition(actorQueries.ActorGenderCondition, filter.Gender)
+	}
+	if filter.BornFrom > 0 {
+		addCondition(actorQueries.ActorBornFromCondition, filter.BornFrom)
+	}
+	if filter.BornTo > 0 {
+		addCondition(actorQueries.ActorBornToCondition, filter.BornTo)
+	}
+	if filter.FilmID > 0 {
+		addCondition(actorQueries.ActorFilmCondition, filter.FilmID)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " and " + strings.Join(conditions, " and "), args
+}
+
+// getActorsWithFilms выбирает актёров запросом query и добавляет к ним их фильмы
+func getActorsWithFilms(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]models.ActorWithFilms, error) {
+	actorsWithFilms := []models.ActorWithFilms{}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 
 	var actors []models.Actor
 	for rows.Next() {
-		var actor models.Actor
-		var dateOfBirthPG pgtype.Date
-		err := rows.Scan(&actor.ID, &actor.Name, &actor.Gender, &dateOfBirthPG)
+		actor, err := ScanActor(rows)
 		if err != nil {
-			return []models.ActorWithFilms{}, err
+			return nil, err
 		}
-		actor.DateOfBirth = dateOfBirthPG.Time.Format(time.DateOnly)
-		actors = append(actors, actor)
+		actors = append(actors, *actor)
 	}
 	rows.Close()
 
 	for _, actor := range actors {
-		filmsRows, err := tx.Query(transactionCtx, actorQueries.GetFilmsByActorId, &actor.ID)
+		filmsRows, err := tx.Query(ctx, actorQueries.GetFilmsByActorId, &actor.ID)
 		if err != nil {
-			return []models.ActorWithFilms{}, err
+			return nil, err
 		}
-		var films []models.Film
+		var films []models.ActingCredit
 		for filmsRows.Next() {
-			var film models.Film
-			var releaseDatePG pgtype.Date
-			err := filmsRows.Scan(&film.ID, &film.Title, &film.Description, &releaseDatePG, &film.Rating)
+			film, err := filmRepository.ScanFilm(filmsRows)
 			if err != nil {
-				return []models.ActorWithFilms{}, err
+				return nil, err
 			}
-			film.ReleaseDate = releaseDatePG.Time.Format(time.DateOnly)
-			films = append(films, film)
+			films = append(films, models.ActingCredit{Film: *film, AgeAtRelease: AgeAtRelease(actor.DateOfBirth, film.ReleaseDate)})
 		}
 		actorsWithFilms = append(actorsWithFilms, models.ActorWithFilms{Actor: actor, Films: films})
 		filmsRows.Close()
@@ -172,3 +387,64 @@ func (aR *ActorRepository) GetActors() ([]models.ActorWithFilms, error) {
 
 	return actorsWithFilms, nil
 }
+
+// ScanActor scans a row selected with actorQueries.ActorColumns followed by extra columns and computes the actor's age
+func ScanActor(row pgx.Row, extra ...any) (*models.Actor, error) {
+	actor := &models.Actor{}
+	var dateOfBirthPG, dateOfDeathPG pgtype.Date
+	dest := []any{&actor.ID, &actor.Name, &actor.Gender, &dateOfBirthPG, &actor.Photo, &dateOfDeathPG,
+		&actor.Birthplace, &actor.Biography, &actor.Aliases, &actor.ImdbID, &actor.KinopoiskID}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	if dateOfBirthPG.Valid {
+		actor.DateOfBirth = dateOfBirthPG.Time.Format(time.DateOnly)
+	}
+	if dateOfDeathPG.Valid {
+		actor.DateOfDeath = dateOfDeathPG.Time.Format(time.DateOnly)
+	}
+	if dateOfBirthPG.Valid {
+		end := time.Now()
+		if dateOfDeathPG.Valid {
+			end = dateOfDeathPG.Time
+		}
+		age := Age(dateOfBirthPG.Time, end)
+		actor.Age = &age
+	}
+	return actor, nil
+}
+
+// Age returns number of full years between birth and date
+func Age(birth, date time.Time) int {
+	age := date.Year() - birth.Year()
+	if date.Month() < birth.Month() || (date.Month() == birth.Month() && date.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
+
+// AgeAtRelease returns age of an actor on the release date of a film.
+// If any of the dates is unknown, nil is returned
+func AgeAtRelease(dateOfBirth, releaseDate string) *int {
+	birth, err := time.Parse(time.DateOnly, dateOfBirth)
+	if err != nil {
+		return nil
+	}
+	release, err := time.Parse(time.DateOnly, releaseDate)
+	if err != nil {
+		return nil
+	}
+	age := Age(birth, release)
+	return &age
+}
+
+// actorArgs returns arguments of CreateAnActor and UpdateActor queries
+func actorArgs(actor *models.ActorRequest) []any {
+	aliases := actor.Aliases
+	if aliases == nil {
+		aliases = []string{}
+	}
+	return []any{&actor.Name, &actor.Gender, &actor.DateOfBirth, &actor.DateOfDeath, &actor.Birthplace,
+		&actor.Biography, aliases, &actor.ImdbID, &actor.KinopoiskID}
+}
@@ -2,6 +2,8 @@ package repository
 
 import (
 	"testing"
+	"time"
+	"vk-intern_test-case/internal/actor"
 	"vk-intern_test-case/models"
 
 	"github.com/jackc/pgx/v5"
@@ -18,6 +20,26 @@ func prepareTestEnvironment(t *testing.T) (*ActorRepository, pgxmock.PgxPoolIfac
 	return testActorRepo, mock
 }
 
+var actorColumns = []string{"id", "name", "gender", "date_of_birth", "photo", "date_of_death", "birthplace",
+	"biography", "aliases", "imdb_id", "kinopoisk_id"}
+
+// actorRow returns an actor row without biography
+func actorRow(id int, name, gender, dateOfBirth string) []any {
+	return []any{id, name, gender, dateOfBirth, nil, nil, "", "", []string{}, "", 0}
+}
+
+// changedFields returns patch function which reports fields as changed
+func changedFields(fields map[string]any) func(*models.ActorRequest) (map[string]any, error) {
+	return func(*models.ActorRequest) (map[string]any, error) {
+		return fields, nil
+	}
+}
+
+func expectedActorArgs(actor *models.ActorRequest) []any {
+	return []any{&actor.Name, &actor.Gender, &actor.DateOfBirth, &actor.DateOfDeath, &actor.Birthplace,
+		&actor.Biography, []string{}, &actor.ImdbID, &actor.KinopoiskID}
+}
+
 func TestShouldSuccessfullyAddNewActor(t *testing.T) {
 	actorRepo, mock := prepareTestEnvironment(t)
 	defer mock.Close()
@@ -30,8 +52,8 @@ func TestShouldSuccessfullyAddNewActor(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectQuery("select id from actor").WithArgs(&newActor.Name).WillReturnError(pgx.ErrNoRows)
-	mock.ExpectQuery("insert into actor").WithArgs(&newActor.Name, &newActor.Gender, &newActor.DateOfBirth).
+	mock.ExpectQuery(`select id from person where name = \$1;`).WithArgs(&newActor.Name).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery("insert into person").WithArgs(expectedActorArgs(&newActor.ActorRequest)...).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(1))
 	mock.ExpectCommit()
 
@@ -59,7 +81,7 @@ func TestShouldReturnExistingActorIfAddingTheSame(t *testing.T) {
 		},
 	}
 	mock.ExpectBegin()
-	mock.ExpectQuery("select id from actor").WithArgs(&newActor.Name).
+	mock.ExpectQuery(`select id from person where name = \$1;`).WithArgs(&newActor.Name).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(5))
 	mock.ExpectCommit()
 
@@ -89,20 +111,182 @@ func TestShouldSuccessfullyUpdateAnExistingActor(t *testing.T) {
 	actorID := 5
 
 	mock.ExpectBegin()
-	mock.ExpectExec("update actor").WithArgs(&newActor.Name, &newActor.Gender, &newActor.DateOfBirth, &actorID).
-		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("update person").WithArgs(append(expectedActorArgs(&newActor.ActorRequest), &actorID)...).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorID, newActor.Name, newActor.Gender, "2001-08-06")...))
 	mock.ExpectCommit()
 
-	err := actorRepo.UpdateActor(actorID, newActor)
+	resultActor, err := actorRepo.UpdateActor(actorID, newActor)
 	if err != nil {
-		t.Errorf("error was not expected while adding an actor: %s", err)
+		t.Errorf("error was not expected while updating an actor: %s", err)
 	}
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 
+	assert.Equal(t, actorID, resultActor.ID)
+	assert.Equal(t, "2001-08-06", resultActor.DateOfBirth)
+}
+
+func TestShouldReturnNotFoundWhenUpdatingNonExistentActor(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newActor := &models.Actor{
+		ActorRequest: models.ActorRequest{
+			Name:        "Actor_name",
+			Gender:      "Мужской",
+			DateOfBirth: "2001-08-06",
+		},
+	}
+	actorID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("update person").WithArgs(append(expectedActorArgs(&newActor.ActorRequest), &actorID)...).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultActor, err := actorRepo.UpdateActor(actorID, newActor)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultActor)
+	assert.Equal(t, actor.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyPatchAnExistingActor(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+	newName := "Actor_name"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from person as p where p.id = \\$1 for update").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorID, "Old_name", "Мужской", "2001-08-06")...))
+	mock.ExpectQuery(`update person as p set name = \$1, updated_at = now\(\) where id = \$2`).WithArgs(newName, actorID).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorID, newName, "Мужской", "2001-08-06")...))
+	mock.ExpectCommit()
+
+	resultActor, err := actorRepo.PatchActor(actorID, func(currentActor *models.ActorRequest) (map[string]any, error) {
+		assert.Equal(t, "Old_name", currentActor.Name)
+		return map[string]any{"name": newName}, nil
+	})
+	if err != nil {
+		t.Errorf("error was not expected while patching an actor: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, newName, resultActor.Name)
+	assert.Equal(t, "2001-08-06", resultActor.DateOfBirth)
+}
+
+func TestShouldStoreEmptyNullableFieldsAsNullWhenPatchingActor(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from person as p where p.id = \\$1 for update").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorID, "Actor_name", "Мужской", "2001-08-06")...))
+	mock.ExpectQuery(`update person as p set date_of_death = nullif\(\$1, ''\)::date, biography = \$2, updated_at = now\(\) where id = \$3`).
+		WithArgs("", "Биография", actorID).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorID, "Actor_name", "Мужской", "2001-08-06", nil, nil, "", "Биография", []string{}, "", 0))
+	mock.ExpectCommit()
+
+	resultActor, err := actorRepo.PatchActor(actorID, changedFields(map[string]any{"date_of_death": "", "biography": "Биография"}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Биография", resultActor.Biography)
+	assert.Equal(t, "", resultActor.DateOfDeath)
+}
+
+func TestShouldNotUpdateActorWhenNothingChanged(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from person as p where p.id = \\$1 for update").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorID, "Actor_name", "Мужской", "2001-08-06")...))
+	mock.ExpectCommit()
+
+	resultActor, err := actorRepo.PatchActor(actorID, changedFields(map[string]any{}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
 	assert.Nil(t, err)
+	assert.Equal(t, "Actor_name", resultActor.Name)
+}
+
+func TestShouldFailToPatchNonExistentActor(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from person as p where p.id = \\$1 for update").WithArgs(&actorID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultActor, err := actorRepo.PatchActor(actorID, changedFields(map[string]any{"name": "Actor_name"}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultActor)
+	assert.Equal(t, actor.ErrNotFound, err)
+}
+
+func TestShouldComputeAgeAtDeath(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorID, "Андрей Миронов", "Мужской", "1941-03-07", nil, "1987-08-16", "Москва", "",
+				[]string{"Andrei Mironov"}, "nm0592379", 40585))
+	mock.ExpectCommit()
+
+	resultActor, err := actorRepo.GetActor(actorID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1987-08-16", resultActor.DateOfDeath)
+	assert.Equal(t, 46, *resultActor.Age)
+	assert.Equal(t, []string{"Andrei Mironov"}, resultActor.Aliases)
+}
+
+func TestAge(t *testing.T) {
+	birth := time.Date(1941, time.March, 7, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, 45, Age(birth, time.Date(1987, time.March, 6, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 46, Age(birth, time.Date(1987, time.March, 7, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestAgeAtRelease(t *testing.T) {
+	assert.Equal(t, 23, *AgeAtRelease("1974-11-11", "1997-12-19"))
+	assert.Equal(t, 22, *AgeAtRelease("1974-12-20", "1997-12-19"))
+	assert.Nil(t, AgeAtRelease("", "1997-12-19"))
 }
 
 func TestShouldSuccessfullyDeleteAnExistingActor(t *testing.T) {
@@ -111,7 +295,7 @@ func TestShouldSuccessfullyDeleteAnExistingActor(t *testing.T) {
 	actorID := 5
 
 	mock.ExpectBegin()
-	mock.ExpectExec("delete from actor").WithArgs(&actorID).
+	mock.ExpectExec("delete from person").WithArgs(&actorID).
 		WillReturnResult(pgxmock.NewResult("DELETE", 1))
 	mock.ExpectCommit()
 
@@ -127,21 +311,41 @@ func TestShouldSuccessfullyDeleteAnExistingActor(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestShouldReturnNotFoundWhenDeletingNonExistentActor(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from person").WithArgs(&actorID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := actorRepo.DeleteActor(actorID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, actor.ErrNotFound, err)
+}
+
 func TestShouldSuccessfullyGetActors(t *testing.T) {
 	actorRepo, mock := prepareTestEnvironment(t)
 	defer mock.Close()
 	actorIDs := []int{1, 2}
 	mock.ExpectBegin()
 	mock.ExpectQuery("select").
-		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "gender", "date_of_birth"}).
-		AddRow(actorIDs[0], "Леонардо Ди Каприо", "Мужской", "2024-03-18").
-		AddRow(actorIDs[1], "Марго Робби", "Женский", "2023-03-18")).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorIDs[0], "Леонардо Ди Каприо", "Мужской", "2024-03-18")...).
+			AddRow(actorRow(actorIDs[1], "Марго Робби", "Женский", "2023-03-18")...)).
 		RowsWillBeClosed()
 	for i := 0; i < len(actorIDs); i++ {
 		mock.ExpectQuery("select").WithArgs(&actorIDs[i]).WillReturnRows().
-			WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
-			AddRow(1, "Титаник", "cool film", "2020-06-10", 8).
-			AddRow(2, "Не титаник", "super film", "2018-06-10", 7)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"runtime", "countries", "original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating", "vote_count"}).
+			AddRow(1, "Титаник", "cool film", "2020-06-10", 8, []string{"Драма"}, 0, []string{}, "", []string{}, "", int64(0), int64(0), "", nil, nil, 0).
+			AddRow(2, "Не титаник", "super film", "2018-06-10", 7, []string{}, 0, []string{}, "", []string{}, "", int64(0), int64(0), "", nil, nil, 0)).
 			RowsWillBeClosed()
 	}
 	mock.ExpectCommit()
@@ -156,4 +360,84 @@ func TestShouldSuccessfullyGetActors(t *testing.T) {
 	}
 
 	assert.Nil(t, err)
-}
\ No newline at end of file
+}
+
+func TestShouldSuccessfullySearchActors(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.ActorFilter{Name: "ди", Gender: "Мужской", BornFrom: 1970, SortBy: "date_of_birth", Limit: 10, Offset: 20}
+	actorID := 1
+	mock.ExpectBegin()
+	mock.ExpectQuery(`select count\(\*\) from person as p .* and \(lower\(p.name\) like '%' \|\| lower\(\$1\) .* lower\(\$1\) .* and p.gender = \$2 and p.date_of_birth >= make_date\(\$3, 1, 1\)$`).
+		WithArgs("ди", "Мужской", 1970).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(21))
+	mock.ExpectQuery(`order by p.date_of_birth nulls last, p.id limit \$4 offset \$5$`).
+		WithArgs("ди", "Мужской", 1970, 10, 20).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(actorID, "Леонардо Ди Каприо", "Мужской", "1974-11-11")...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("select").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"runtime", "countries", "original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating", "vote_count"})).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	actors, total, err := actorRepo.SearchActors(filter)
+	if err != nil {
+		t.Errorf("error was not expected while searching actors: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, 21, total)
+	assert.Len(t, actors, 1)
+}
+
+func TestShouldEscapeWildcardsWhenSearchingActorsByName(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.ActorFilter{Name: `100%_\`, SortBy: "name", Limit: 20}
+	mock.ExpectBegin()
+	mock.ExpectQuery(`select count\(\*\) from person as p .* like '%' \|\| lower\(\$1\) \|\| '%' escape '\\'`).
+		WithArgs(`100\%\_\\`).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery(`order by p.name, p.id limit \$2 offset \$3$`).
+		WithArgs(`100\%\_\\`, 20, 0).
+		WillReturnRows(pgxmock.NewRows(actorColumns)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	actors, total, err := actorRepo.SearchActors(filter)
+	if err != nil {
+		t.Errorf("error was not expected while searching actors: %s", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, 0, total)
+	assert.Empty(t, actors)
+}
+
+func TestShouldSuccessfullySetActorPhoto(t *testing.T) {
+	actorRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 5
+	photo := &models.Image{Original: "/media/actors/5/photo/1/original.jpg"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update person set photo").WithArgs(photo, &actorID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectCommit()
+
+	err := actorRepo.SetActorPhoto(actorID, photo)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+}
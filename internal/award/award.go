@@ -0,0 +1,11 @@
+package award
+
+import "vk-intern_test-case/utils/apperror"
+
+var (
+	ErrNotFound           = apperror.NotFound("award_not_found", "Award not found")
+	ErrCeremonyNotFound   = apperror.NotFound("ceremony_not_found", "Ceremony not found")
+	ErrCategoryNotFound   = apperror.NotFound("award_category_not_found", "Award category not found")
+	ErrNominationNotFound = apperror.NotFound("nomination_not_found", "Nomination not found")
+	ErrInvalidNomination  = apperror.Validation("invalid_nomination", "Nomination references unknown records", nil)
+)
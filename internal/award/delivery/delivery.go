@@ -0,0 +1,587 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"vk-intern_test-case/internal/award"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "award:delivery:"
+
+type AwardDelivery struct {
+	awardRepo award.AwardRepository
+}
+
+func NewAwardDelivery(awR award.AwardRepository) *AwardDelivery {
+	return &AwardDelivery{
+		awardRepo: awR,
+	}
+}
+
+func (awD *AwardDelivery) HandleAwards(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.TrimPrefix(r.URL.Path, "/awards") == "" {
+			awD.GetAwards(w, r)
+			return
+		}
+		awD.GetAward(w, r)
+	case http.MethodPost:
+		awD.AddAward(w, r)
+	case http.MethodPut:
+		awD.UpdateAward(w, r)
+	case http.MethodDelete:
+		awD.DeleteAward(w, r)
+	}
+}
+
+// HandleAwardCeremonies handles /awards/{id}/ceremonies and /awards/{id}/ceremonies/{year}
+func (awD *AwardDelivery) HandleAwardCeremonies(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		awD.GetCeremonyNominations(w, r)
+	case http.MethodPost:
+		awD.AddCeremony(w, r)
+	case http.MethodDelete:
+		awD.DeleteCeremony(w, r)
+	}
+}
+
+// HandleAwardCategories handles /awards/{id}/categories and /awards/{id}/categories/{category_id}
+func (awD *AwardDelivery) HandleAwardCategories(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		awD.AddCategory(w, r)
+	case http.MethodDelete:
+		awD.DeleteCategory(w, r)
+	}
+}
+
+func (awD *AwardDelivery) HandleNominations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		awD.AddNomination(w, r)
+	case http.MethodPut:
+		awD.UpdateNomination(w, r)
+	case http.MethodDelete:
+		awD.DeleteNomination(w, r)
+	}
+}
+
+// HandleFilmNominations handles /films/{id}/nominations
+func (awD *AwardDelivery) HandleFilmNominations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		awD.GetFilmNominations(w, r)
+	}
+}
+
+// HandleActorNominations handles /actors/{id}/nominations
+func (awD *AwardDelivery) HandleActorNominations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		awD.GetActorNominations(w, r)
+	}
+}
+
+// swagger:route POST /awards Awards addAward
+// Добавляет премию в систему
+// security:
+// - key:
+// responses:
+//
+//	200: award
+//	400: problemResponse
+//  401: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) AddAward(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddAward:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	var newAward models.AwardRequest
+	err := json.NewDecoder(r.Body).Decode(&newAward)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateAward(&newAward).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultAward, err := awD.awardRepo.AddAward(&newAward)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultAward)
+}
+
+// swagger:route GET /awards/{id} Awards getAward
+// Возвращает премию с церемониями и категориями
+// responses:
+//
+//	200: awardWithDetails
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) GetAward(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/awards/")
+	awardID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultAward, err := awD.awardRepo.GetAward(awardID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultAward)
+}
+
+// swagger:route GET /awards Awards getAwards
+// Возвращает список премий, отсортированный по названию
+// responses:
+//
+//	200: []award
+//	500: problemResponse
+func (awD *AwardDelivery) GetAwards(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+
+	resultAwards, err := awD.awardRepo.GetAwards()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultAwards)
+}
+
+// swagger:route PUT /awards/{id} Awards updateAward
+// Переименовывает премию. Возвращает премию после изменения.
+// security:
+// - key:
+// responses:
+//
+//	200: award
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) UpdateAward(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/awards/")
+	awardID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var awardRequest models.AwardRequest
+	err = json.NewDecoder(r.Body).Decode(&awardRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateAward(&awardRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultAward, err := awD.awardRepo.UpdateAward(awardID, &awardRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultAward)
+}
+
+// swagger:route DELETE /awards/{id} Awards deleteAward
+// Удаляет премию вместе с церемониями, категориями и номинациями
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) DeleteAward(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/awards/")
+	awardID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	err = awD.awardRepo.DeleteAward(awardID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route POST /awards/{id}/ceremonies Awards addCeremony
+// Добавляет церемонию премии за год
+// security:
+// - key:
+// responses:
+//
+//	200: awardCeremony
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) AddCeremony(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddCeremony:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/awards/")
+	awardID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var ceremony models.AwardCeremonyRequest
+	err = json.NewDecoder(r.Body).Decode(&ceremony)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateAwardCeremony(&ceremony).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultCeremony, err := awD.awardRepo.AddCeremony(awardID, &ceremony)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCeremony)
+}
+
+// swagger:route GET /awards/{id}/ceremonies/{year} Awards getCeremonyNominations
+// Возвращает номинации церемонии по категориям, победители идут первыми
+// responses:
+//
+//	200: []nomination
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) GetCeremonyNominations(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	awardID, year, err := parseNestedIDs(r.URL.Path)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultNominations, err := awD.awardRepo.GetCeremonyNominations(awardID, year)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultNominations)
+}
+
+// swagger:route DELETE /awards/{id}/ceremonies/{year} Awards deleteCeremony
+// Удаляет церемонию вместе с её номинациями
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) DeleteCeremony(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	awardID, year, err := parseNestedIDs(r.URL.Path)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = awD.awardRepo.DeleteCeremony(awardID, year)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route POST /awards/{id}/categories Awards addAwardCategory
+// Добавляет категорию премии
+// security:
+// - key:
+// responses:
+//
+//	200: awardCategory
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) AddCategory(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddCategory:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/awards/")
+	awardID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var category models.AwardCategoryRequest
+	err = json.NewDecoder(r.Body).Decode(&category)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateAwardCategory(&category).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultCategory, err := awD.awardRepo.AddCategory(awardID, &category)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCategory)
+}
+
+// swagger:route DELETE /awards/{id}/categories/{category_id} Awards deleteAwardCategory
+// Удаляет категорию премии вместе с её номинациями
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	awardID, categoryID, err := parseNestedIDs(r.URL.Path)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = awD.awardRepo.DeleteCategory(awardID, categoryID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route POST /nominations Awards addNomination
+// Добавляет номинацию фильма и, если указан person_id, актёра или участника съёмочной группы
+// security:
+// - key:
+// responses:
+//
+//	200: nomination
+//	400: problemResponse
+//  401: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) AddNomination(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddNomination:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	var nomination models.NominationRequest
+	err := json.NewDecoder(r.Body).Decode(&nomination)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateNomination(&nomination).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultNomination, err := awD.awardRepo.AddNomination(&nomination)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultNomination)
+}
+
+// swagger:route PUT /nominations/{id} Awards updateNomination
+// Заменяет номинацию, например отмечает победу. Возвращает номинацию после изменения.
+// security:
+// - key:
+// responses:
+//
+//	200: nomination
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) UpdateNomination(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/nominations/")
+	nominationID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var nomination models.NominationRequest
+	err = json.NewDecoder(r.Body).Decode(&nomination)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateNomination(&nomination).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultNomination, err := awD.awardRepo.UpdateNomination(nominationID, &nomination)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultNomination)
+}
+
+// swagger:route DELETE /nominations/{id} Awards deleteNomination
+// Удаляет номинацию
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) DeleteNomination(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/nominations/")
+	nominationID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	err = awD.awardRepo.DeleteNomination(nominationID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route GET /films/{id}/nominations Awards getFilmNominations
+// Возвращает номинации фильма, начиная с последних церемоний
+// responses:
+//
+//	200: []nomination
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) GetFilmNominations(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultNominations, err := awD.awardRepo.GetFilmNominations(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultNominations)
+}
+
+// swagger:route GET /actors/{id}/nominations Awards getActorNominations
+// Возвращает номинации актёра, начиная с последних церемоний
+// responses:
+//
+//	200: []nomination
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (awD *AwardDelivery) GetActorNominations(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/actors/")
+	actorID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultNominations, err := awD.awardRepo.GetPersonNominations(actorID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultNominations)
+}
+
+// parseNestedIDs returns ids from path like /awards/{id}/{subresource}/{nested_id}
+func parseNestedIDs(path string) (int, int, error) {
+	segments := router.PathSegments(path, "/awards/")
+	if len(segments) != 3 {
+		return 0, 0, apperror.ErrInvalidID
+	}
+	id, err := strconv.Atoi(segments[0])
+	if err != nil {
+		return 0, 0, apperror.ErrInvalidID
+	}
+	nestedID, err := strconv.Atoi(segments[2])
+	if err != nil {
+		return 0, 0, apperror.ErrInvalidID
+	}
+	return id, nestedID, nil
+}
@@ -0,0 +1,362 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/award"
+	"vk-intern_test-case/internal/award/mock"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type awardTest struct {
+	name               string
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockAwardRepository *mock.MockAwardRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var oscar = models.Award{ID: 1, AwardRequest: models.AwardRequest{Name: "Оскар"}}
+
+var bestPicture = models.Nomination{
+	ID:         10,
+	AwardID:    1,
+	Award:      "Оскар",
+	CeremonyID: 2,
+	Year:       1998,
+	CategoryID: 3,
+	Category:   "Лучший фильм",
+	FilmID:     4,
+	FilmTitle:  "Титаник",
+	Won:        true,
+}
+
+const bestPictureJSON = `{
+	"id": 10,
+	"award_id": 1,
+	"award": "Оскар",
+	"ceremony_id": 2,
+	"year": 1998,
+	"category_id": 3,
+	"category": "Лучший фильм",
+	"film_id": 4,
+	"film_title": "Титаник",
+	"won": true
+}`
+
+var awardTests = []awardTest{
+	{
+		"Successfully add new Award",
+		http.MethodPost,
+		"/awards",
+		`{"name": "Оскар"}`,
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				AddAward(&models.AwardRequest{Name: "Оскар"}).
+				Return(&oscar, nil)
+		},
+		`{"id": 1, "name": "Оскар"}`,
+		http.StatusOK,
+	},
+	{
+		"Successfully get an Award with ceremonies and categories",
+		http.MethodGet,
+		"/awards/1",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				GetAward(1).
+				Return(&models.AwardWithDetails{
+					Award:      oscar,
+					Ceremonies: []models.AwardCeremony{{ID: 2, AwardCeremonyRequest: models.AwardCeremonyRequest{Year: 1998}}},
+					Categories: []models.AwardCategory{{ID: 3, AwardCategoryRequest: models.AwardCategoryRequest{Name: "Лучший фильм"}}},
+				}, nil)
+		},
+		`{
+			"id": 1,
+			"name": "Оскар",
+			"ceremonies": [{"id": 2, "year": 1998}],
+			"categories": [{"id": 3, "name": "Лучший фильм"}]
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a non-existent Award",
+		http.MethodGet,
+		"/awards/5",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				GetAward(5).
+				Return(nil, award.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Award not found",
+			"instance": "/awards/5",
+			"code": "award_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleAwards(t *testing.T) {
+	runAwardTests(t, awardTests, func(awardDelivery *AwardDelivery) http.HandlerFunc {
+		return awardDelivery.HandleAwards
+	})
+}
+
+var ceremonyTests = []awardTest{
+	{
+		"Successfully add a Ceremony",
+		http.MethodPost,
+		"/awards/1/ceremonies",
+		`{"year": 1998}`,
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				AddCeremony(1, &models.AwardCeremonyRequest{Year: 1998}).
+				Return(&models.AwardCeremony{ID: 2, AwardCeremonyRequest: models.AwardCeremonyRequest{Year: 1998}}, nil)
+		},
+		`{"id": 2, "year": 1998}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to add a Ceremony with too early year",
+		http.MethodPost,
+		"/awards/1/ceremonies",
+		`{"year": 1800}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/awards/1/ceremonies",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "year", "code": "out_of_range", "message": "` + yearRangeMessage() + `"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Successfully get Ceremony nominations",
+		http.MethodGet,
+		"/awards/1/ceremonies/1998",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				GetCeremonyNominations(1, 1998).
+				Return([]models.Nomination{bestPicture}, nil)
+		},
+		`[` + bestPictureJSON + `]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get nominations of a non-existent Ceremony",
+		http.MethodGet,
+		"/awards/1/ceremonies/1950",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				GetCeremonyNominations(1, 1950).
+				Return([]models.Nomination{}, award.ErrCeremonyNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Ceremony not found",
+			"instance": "/awards/1/ceremonies/1950",
+			"code": "ceremony_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleAwardCeremonies(t *testing.T) {
+	runAwardTests(t, ceremonyTests, func(awardDelivery *AwardDelivery) http.HandlerFunc {
+		return awardDelivery.HandleAwardCeremonies
+	})
+}
+
+var nominationTests = []awardTest{
+	{
+		"Successfully add a Nomination",
+		http.MethodPost,
+		"/nominations",
+		`{"ceremony_id": 2, "category_id": 3, "film_id": 4, "won": true}`,
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				AddNomination(&models.NominationRequest{CeremonyID: 2, CategoryID: 3, FilmID: 4, Won: true}).
+				Return(&bestPicture, nil)
+		},
+		bestPictureJSON,
+		http.StatusOK,
+	},
+	{
+		"Fail to add a Nomination without film",
+		http.MethodPost,
+		"/nominations",
+		`{"ceremony_id": 2, "category_id": 3}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/nominations",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "film_id", "code": "required", "message": "film_id is required"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to delete a non-existent Nomination",
+		http.MethodDelete,
+		"/nominations/11",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				DeleteNomination(11).
+				Return(award.ErrNominationNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Nomination not found",
+			"instance": "/nominations/11",
+			"code": "nomination_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleNominations(t *testing.T) {
+	runAwardTests(t, nominationTests, func(awardDelivery *AwardDelivery) http.HandlerFunc {
+		return awardDelivery.HandleNominations
+	})
+}
+
+var filmNominationTests = []awardTest{
+	{
+		"Successfully get Film nominations",
+		http.MethodGet,
+		"/films/4/nominations",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				GetFilmNominations(4).
+				Return([]models.Nomination{bestPicture}, nil)
+		},
+		`[` + bestPictureJSON + `]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get nominations of a non-existent Film",
+		http.MethodGet,
+		"/films/7/nominations",
+		"",
+		func(mockAwardRepository *mock.MockAwardRepository) {
+			mockAwardRepository.EXPECT().
+				GetFilmNominations(7).
+				Return([]models.Nomination{}, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/7/nominations",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleFilmNominations(t *testing.T) {
+	runAwardTests(t, filmNominationTests, func(awardDelivery *AwardDelivery) http.HandlerFunc {
+		return awardDelivery.HandleFilmNominations
+	})
+}
+
+func TestHandleActorNominations(t *testing.T) {
+	runAwardTests(t, []awardTest{
+		{
+			"Successfully get Actor nominations",
+			http.MethodGet,
+			"/actors/5/nominations",
+			"",
+			func(mockAwardRepository *mock.MockAwardRepository) {
+				mockAwardRepository.EXPECT().
+					GetPersonNominations(5).
+					Return([]models.Nomination{}, nil)
+			},
+			`[]`,
+			http.StatusOK,
+		},
+	}, func(awardDelivery *AwardDelivery) http.HandlerFunc {
+		return awardDelivery.HandleActorNominations
+	})
+}
+
+func yearRangeMessage() string {
+	return "year must be between 1900 and " + strconv.Itoa(time.Now().Year()+1)
+}
+
+func runAwardTests(t *testing.T, tests []awardTest, handler func(*AwardDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockAwardRepository := mock.NewMockAwardRepository(ctrl)
+			awardDeliveryTest := NewAwardDelivery(mockAwardRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockAwardRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+
+			handler(awardDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
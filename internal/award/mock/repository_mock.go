@@ -0,0 +1,256 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/award/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAwardRepository is a mock of AwardRepository interface.
+type MockAwardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAwardRepositoryMockRecorder
+}
+
+// MockAwardRepositoryMockRecorder is the mock recorder for MockAwardRepository.
+type MockAwardRepositoryMockRecorder struct {
+	mock *MockAwardRepository
+}
+
+// NewMockAwardRepository creates a new mock instance.
+func NewMockAwardRepository(ctrl *gomock.Controller) *MockAwardRepository {
+	mock := &MockAwardRepository{ctrl: ctrl}
+	mock.recorder = &MockAwardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAwardRepository) EXPECT() *MockAwardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddAward mocks base method.
+func (m *MockAwardRepository) AddAward(arg0 *models.AwardRequest) (*models.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAward", arg0)
+	ret0, _ := ret[0].(*models.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAward indicates an expected call of AddAward.
+func (mr *MockAwardRepositoryMockRecorder) AddAward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAward", reflect.TypeOf((*MockAwardRepository)(nil).AddAward), arg0)
+}
+
+// AddCategory mocks base method.
+func (m *MockAwardRepository) AddCategory(arg0 int, arg1 *models.AwardCategoryRequest) (*models.AwardCategory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCategory", arg0, arg1)
+	ret0, _ := ret[0].(*models.AwardCategory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCategory indicates an expected call of AddCategory.
+func (mr *MockAwardRepositoryMockRecorder) AddCategory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCategory", reflect.TypeOf((*MockAwardRepository)(nil).AddCategory), arg0, arg1)
+}
+
+// AddCeremony mocks base method.
+func (m *MockAwardRepository) AddCeremony(arg0 int, arg1 *models.AwardCeremonyRequest) (*models.AwardCeremony, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCeremony", arg0, arg1)
+	ret0, _ := ret[0].(*models.AwardCeremony)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCeremony indicates an expected call of AddCeremony.
+func (mr *MockAwardRepositoryMockRecorder) AddCeremony(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCeremony", reflect.TypeOf((*MockAwardRepository)(nil).AddCeremony), arg0, arg1)
+}
+
+// AddNomination mocks base method.
+func (m *MockAwardRepository) AddNomination(arg0 *models.NominationRequest) (*models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNomination", arg0)
+	ret0, _ := ret[0].(*models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNomination indicates an expected call of AddNomination.
+func (mr *MockAwardRepositoryMockRecorder) AddNomination(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNomination", reflect.TypeOf((*MockAwardRepository)(nil).AddNomination), arg0)
+}
+
+// DeleteAward mocks base method.
+func (m *MockAwardRepository) DeleteAward(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAward", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAward indicates an expected call of DeleteAward.
+func (mr *MockAwardRepositoryMockRecorder) DeleteAward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAward", reflect.TypeOf((*MockAwardRepository)(nil).DeleteAward), arg0)
+}
+
+// DeleteCategory mocks base method.
+func (m *MockAwardRepository) DeleteCategory(awardID, categoryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCategory", awardID, categoryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCategory indicates an expected call of DeleteCategory.
+func (mr *MockAwardRepositoryMockRecorder) DeleteCategory(awardID, categoryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCategory", reflect.TypeOf((*MockAwardRepository)(nil).DeleteCategory), awardID, categoryID)
+}
+
+// DeleteCeremony mocks base method.
+func (m *MockAwardRepository) DeleteCeremony(awardID, year int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCeremony", awardID, year)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCeremony indicates an expected call of DeleteCeremony.
+func (mr *MockAwardRepositoryMockRecorder) DeleteCeremony(awardID, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCeremony", reflect.TypeOf((*MockAwardRepository)(nil).DeleteCeremony), awardID, year)
+}
+
+// DeleteNomination mocks base method.
+func (m *MockAwardRepository) DeleteNomination(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNomination", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNomination indicates an expected call of DeleteNomination.
+func (mr *MockAwardRepositoryMockRecorder) DeleteNomination(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNomination", reflect.TypeOf((*MockAwardRepository)(nil).DeleteNomination), arg0)
+}
+
+// GetAward mocks base method.
+func (m *MockAwardRepository) GetAward(arg0 int) (*models.AwardWithDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAward", arg0)
+	ret0, _ := ret[0].(*models.AwardWithDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAward indicates an expected call of GetAward.
+func (mr *MockAwardRepositoryMockRecorder) GetAward(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAward", reflect.TypeOf((*MockAwardRepository)(nil).GetAward), arg0)
+}
+
+// GetAwards mocks base method.
+func (m *MockAwardRepository) GetAwards() ([]models.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAwards")
+	ret0, _ := ret[0].([]models.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAwards indicates an expected call of GetAwards.
+func (mr *MockAwardRepositoryMockRecorder) GetAwards() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAwards", reflect.TypeOf((*MockAwardRepository)(nil).GetAwards))
+}
+
+// GetCeremonyNominations mocks base method.
+func (m *MockAwardRepository) GetCeremonyNominations(awardID, year int) ([]models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCeremonyNominations", awardID, year)
+	ret0, _ := ret[0].([]models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCeremonyNominations indicates an expected call of GetCeremonyNominations.
+func (mr *MockAwardRepositoryMockRecorder) GetCeremonyNominations(awardID, year interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCeremonyNominations", reflect.TypeOf((*MockAwardRepository)(nil).GetCeremonyNominations), awardID, year)
+}
+
+// GetFilmNominations mocks base method.
+func (m *MockAwardRepository) GetFilmNominations(arg0 int) ([]models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmNominations", arg0)
+	ret0, _ := ret[0].([]models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmNominations indicates an expected call of GetFilmNominations.
+func (mr *MockAwardRepositoryMockRecorder) GetFilmNominations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmNominations", reflect.TypeOf((*MockAwardRepository)(nil).GetFilmNominations), arg0)
+}
+
+// GetPersonNominations mocks base method.
+func (m *MockAwardRepository) GetPersonNominations(arg0 int) ([]models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonNominations", arg0)
+	ret0, _ := ret[0].([]models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonNominations indicates an expected call of GetPersonNominations.
+func (mr *MockAwardRepositoryMockRecorder) GetPersonNominations(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonNominations", reflect.TypeOf((*MockAwardRepository)(nil).GetPersonNominations), arg0)
+}
+
+// UpdateAward mocks base method.
+func (m *MockAwardRepository) UpdateAward(arg0 int, arg1 *models.AwardRequest) (*models.Award, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAward", arg0, arg1)
+	ret0, _ := ret[0].(*models.Award)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAward indicates an expected call of UpdateAward.
+func (mr *MockAwardRepositoryMockRecorder) UpdateAward(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAward", reflect.TypeOf((*MockAwardRepository)(nil).UpdateAward), arg0, arg1)
+}
+
+// UpdateNomination mocks base method.
+func (m *MockAwardRepository) UpdateNomination(arg0 int, arg1 *models.NominationRequest) (*models.Nomination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNomination", arg0, arg1)
+	ret0, _ := ret[0].(*models.Nomination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateNomination indicates an expected call of UpdateNomination.
+func (mr *MockAwardRepositoryMockRecorder) UpdateNomination(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNomination", reflect.TypeOf((*MockAwardRepository)(nil).UpdateNomination), arg0, arg1)
+}
@@ -0,0 +1,48 @@
+package queries
+
+const (
+	CreateAward        = `insert into award (name) values ($1) returning id, name;`
+	GetAwardByID       = `select id, name from award where id = $1;`
+	GetAwardIDByID     = `select id from award where id = $1;`
+	GetAwards          = `select id, name from award order by name;`
+	UpdateAward        = `update award set name = $1 where id = $2 returning id, name;`
+	DeleteAward        = `delete from award where id = $1;`
+	GetAwardCeremonies = `select id, year from award_ceremony where award_id = $1 order by year desc;`
+	GetAwardCategories = `select id, name from award_category where award_id = $1 order by name;`
+
+	CreateCeremony      = `insert into award_ceremony (award_id, year) values ($1, $2) returning id, year;`
+	GetCeremonyIDByYear = `select id from award_ceremony where award_id = $1 and year = $2;`
+	GetCeremonyAwardID  = `select award_id from award_ceremony where id = $1;`
+	DeleteCeremony      = `delete from award_ceremony where award_id = $1 and year = $2;`
+	CreateCategory      = `insert into award_category (award_id, name) values ($1, $2) returning id, name;`
+	GetCategoryAwardID  = `select award_id from award_category where id = $1;`
+	DeleteCategory      = `delete from award_category where award_id = $1 and id = $2;`
+	GetFilmIDByID       = `select id from film where id = $1;`
+	GetPersonIDByID     = `select id from person where id = $1;`
+
+	CreateNomination = `insert into nomination (ceremony_id, category_id, film_id, person_id, won)
+		values ($1, $2, $3, $4, $5) returning id;`
+	UpdateNomination = `update nomination set ceremony_id = $1, category_id = $2, film_id = $3, person_id = $4, won = $5
+		where id = $6 returning id;`
+	DeleteNomination = `delete from nomination where id = $1;`
+
+	NominationColumns = `n.id, a.id, a.name, c.id, c.year, cat.id, cat.name, f.id, f.title,
+		coalesce(n.person_id, 0), coalesce(p.name, ''), n.won`
+	nominationsFrom = ` from nomination as n
+		join award_ceremony as c on c.id = n.ceremony_id
+		join award as a on a.id = c.award_id
+		join award_category as cat on cat.id = n.category_id
+		join film as f on f.id = n.film_id
+		left join person as p on p.id = n.person_id`
+	GetNominationByID = `select ` + NominationColumns + nominationsFrom + ` where n.id = $1;`
+	// Победители идут первыми в своей категории
+	GetCeremonyNominations = `select ` + NominationColumns + nominationsFrom + `
+		where c.id = $1
+		order by cat.name, n.won desc, f.title, p.name;`
+	GetFilmNominations = `select ` + NominationColumns + nominationsFrom + `
+		where n.film_id = $1
+		order by c.year desc, a.name, cat.name;`
+	GetPersonNominations = `select ` + NominationColumns + nominationsFrom + `
+		where n.person_id = $1
+		order by c.year desc, a.name, cat.name;`
+)
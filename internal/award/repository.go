@@ -0,0 +1,21 @@
+package award
+
+import "vk-intern_test-case/models"
+
+type AwardRepository interface {
+	AddAward(*models.AwardRequest) (*models.Award, error)
+	GetAward(int) (*models.AwardWithDetails, error)
+	GetAwards() ([]models.Award, error)
+	UpdateAward(int, *models.AwardRequest) (*models.Award, error)
+	DeleteAward(int) error
+	AddCeremony(int, *models.AwardCeremonyRequest) (*models.AwardCeremony, error)
+	GetCeremonyNominations(awardID int, year int) ([]models.Nomination, error)
+	DeleteCeremony(awardID int, year int) error
+	AddCategory(int, *models.AwardCategoryRequest) (*models.AwardCategory, error)
+	DeleteCategory(awardID int, categoryID int) error
+	AddNomination(*models.NominationRequest) (*models.Nomination, error)
+	UpdateNomination(int, *models.NominationRequest) (*models.Nomination, error)
+	DeleteNomination(int) error
+	GetFilmNominations(int) ([]models.Nomination, error)
+	GetPersonNominations(int) ([]models.Nomination, error)
+}
@@ -0,0 +1,554 @@
+package repository
+
+import (
+	"context"
+	actorPackage "vk-intern_test-case/internal/actor"
+	awardPackage "vk-intern_test-case/internal/award"
+	awardQueries "vk-intern_test-case/internal/award/queries"
+	filmPackage "vk-intern_test-case/internal/film"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "award:repository:"
+
+type AwardRepository struct {
+	pool database.PgxIface
+}
+
+func NewAwardRepository(pool database.PgxIface) *AwardRepository {
+	return &AwardRepository{
+		pool: pool,
+	}
+}
+
+func (awR *AwardRepository) AddAward(award *models.AwardRequest) (*models.Award, error) {
+	message := logMessage + "AddAward:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	resultAward := &models.Award{}
+	row := tx.QueryRow(transactionCtx, awardQueries.CreateAward, &award.Name)
+	err = row.Scan(&resultAward.ID, &resultAward.Name)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return resultAward, nil
+}
+
+func (awR *AwardRepository) GetAward(awardID int) (*models.AwardWithDetails, error) {
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	award := &models.AwardWithDetails{
+		Ceremonies: []models.AwardCeremony{},
+		Categories: []models.AwardCategory{},
+	}
+	row := tx.QueryRow(transactionCtx, awardQueries.GetAwardByID, &awardID)
+	err = row.Scan(&award.ID, &award.Name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = awardPackage.ErrNotFound
+			return nil, err
+		}
+		return nil, err
+	}
+
+	rows, err := tx.Query(transactionCtx, awardQueries.GetAwardCeremonies, &awardID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		ceremony := models.AwardCeremony{}
+		err := rows.Scan(&ceremony.ID, &ceremony.Year)
+		if err != nil {
+			return nil, err
+		}
+		award.Ceremonies = append(award.Ceremonies, ceremony)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(transactionCtx, awardQueries.GetAwardCategories, &awardID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		category := models.AwardCategory{}
+		err := rows.Scan(&category.ID, &category.Name)
+		if err != nil {
+			return nil, err
+		}
+		award.Categories = append(award.Categories, category)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return award, nil
+}
+
+func (awR *AwardRepository) GetAwards() ([]models.Award, error) {
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Award{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	awards := []models.Award{}
+	rows, err := tx.Query(transactionCtx, awardQueries.GetAwards)
+	if err != nil {
+		return []models.Award{}, err
+	}
+
+	for rows.Next() {
+		award := models.Award{}
+		err := rows.Scan(&award.ID, &award.Name)
+		if err != nil {
+			return []models.Award{}, err
+		}
+		awards = append(awards, award)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Award{}, err
+	}
+	return awards, nil
+}
+
+func (awR *AwardRepository) UpdateAward(awardID int, award *models.AwardRequest) (*models.Award, error) {
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	resultAward := &models.Award{}
+	row := tx.QueryRow(transactionCtx, awardQueries.UpdateAward, &award.Name, &awardID)
+	err = row.Scan(&resultAward.ID, &resultAward.Name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = awardPackage.ErrNotFound
+			return nil, err
+		}
+		return nil, database.MapError(err)
+	}
+	return resultAward, nil
+}
+
+func (awR *AwardRepository) DeleteAward(awardID int) error {
+	return awR.deleteRows(awardQueries.DeleteAward, awardPackage.ErrNotFound, &awardID)
+}
+
+func (awR *AwardRepository) AddCeremony(awardID int, ceremony *models.AwardCeremonyRequest) (*models.AwardCeremony, error) {
+	message := logMessage + "AddCeremony:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkExists(transactionCtx, tx, awardQueries.GetAwardIDByID, awardID, awardPackage.ErrNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	resultCeremony := &models.AwardCeremony{}
+	row := tx.QueryRow(transactionCtx, awardQueries.CreateCeremony, &awardID, &ceremony.Year)
+	err = row.Scan(&resultCeremony.ID, &resultCeremony.Year)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return resultCeremony, nil
+}
+
+func (awR *AwardRepository) GetCeremonyNominations(awardID int, year int) ([]models.Nomination, error) {
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Nomination{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var ceremonyID int
+	row := tx.QueryRow(transactionCtx, awardQueries.GetCeremonyIDByYear, &awardID, &year)
+	err = row.Scan(&ceremonyID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = awardPackage.ErrCeremonyNotFound
+			return []models.Nomination{}, err
+		}
+		return []models.Nomination{}, err
+	}
+
+	nominations, err := getNominations(transactionCtx, tx, awardQueries.GetCeremonyNominations, ceremonyID)
+	if err != nil {
+		return []models.Nomination{}, err
+	}
+	return nominations, nil
+}
+
+func (awR *AwardRepository) DeleteCeremony(awardID int, year int) error {
+	return awR.deleteRows(awardQueries.DeleteCeremony, awardPackage.ErrCeremonyNotFound, &awardID, &year)
+}
+
+func (awR *AwardRepository) AddCategory(awardID int, category *models.AwardCategoryRequest) (*models.AwardCategory, error) {
+	message := logMessage + "AddCategory:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkExists(transactionCtx, tx, awardQueries.GetAwardIDByID, awardID, awardPackage.ErrNotFound)
+	if err != nil {
+		return nil, err
+	}
+
+	resultCategory := &models.AwardCategory{}
+	row := tx.QueryRow(transactionCtx, awardQueries.CreateCategory, &awardID, &category.Name)
+	err = row.Scan(&resultCategory.ID, &resultCategory.Name)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return resultCategory, nil
+}
+
+func (awR *AwardRepository) DeleteCategory(awardID int, categoryID int) error {
+	return awR.deleteRows(awardQueries.DeleteCategory, awardPackage.ErrCategoryNotFound, &awardID, &categoryID)
+}
+
+func (awR *AwardRepository) AddNomination(nomination *models.NominationRequest) (*models.Nomination, error) {
+	message := logMessage + "AddNomination:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkNominationReferences(transactionCtx, tx, nomination)
+	if err != nil {
+		return nil, err
+	}
+
+	var nominationID int
+	row := tx.QueryRow(transactionCtx, awardQueries.CreateNomination, nominationArgs(nomination)...)
+	err = row.Scan(&nominationID)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+
+	resultNomination, err := getNomination(transactionCtx, tx, nominationID)
+	if err != nil {
+		return nil, err
+	}
+	return resultNomination, nil
+}
+
+func (awR *AwardRepository) UpdateNomination(nominationID int, nomination *models.NominationRequest) (*models.Nomination, error) {
+	message := logMessage + "UpdateNomination:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkNominationReferences(transactionCtx, tx, nomination)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRow(transactionCtx, awardQueries.UpdateNomination, append(nominationArgs(nomination), &nominationID)...)
+	err = row.Scan(&nominationID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = awardPackage.ErrNominationNotFound
+			return nil, err
+		}
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+
+	resultNomination, err := getNomination(transactionCtx, tx, nominationID)
+	if err != nil {
+		return nil, err
+	}
+	return resultNomination, nil
+}
+
+func (awR *AwardRepository) DeleteNomination(nominationID int) error {
+	return awR.deleteRows(awardQueries.DeleteNomination, awardPackage.ErrNominationNotFound, &nominationID)
+}
+
+func (awR *AwardRepository) GetFilmNominations(filmID int) ([]models.Nomination, error) {
+	return awR.getNominationsOf(awardQueries.GetFilmIDByID, filmPackage.ErrNotFound,
+		awardQueries.GetFilmNominations, filmID)
+}
+
+func (awR *AwardRepository) GetPersonNominations(personID int) ([]models.Nomination, error) {
+	return awR.getNominationsOf(awardQueries.GetPersonIDByID, actorPackage.ErrNotFound,
+		awardQueries.GetPersonNominations, personID)
+}
+
+// getNominationsOf возвращает номинации фильма или персоны, если они есть в базе
+func (awR *AwardRepository) getNominationsOf(existsQuery string, notFound error, query string, id int) ([]models.Nomination, error) {
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Nomination{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkExists(transactionCtx, tx, existsQuery, id, notFound)
+	if err != nil {
+		return []models.Nomination{}, err
+	}
+
+	nominations, err := getNominations(transactionCtx, tx, query, id)
+	if err != nil {
+		return []models.Nomination{}, err
+	}
+	return nominations, nil
+}
+
+// deleteRows выполняет запрос удаления и возвращает notFound, если ничего не удалено
+func (awR *AwardRepository) deleteRows(query string, notFound error, args ...any) error {
+	transactionCtx := context.Background()
+	tx, err := awR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, query, args...)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = notFound
+		return err
+	}
+	return nil
+}
+
+// checkNominationReferences проверяет, что церемония, категория, фильм и персона есть в базе,
+// а категория относится к той же премии, что и церемония
+func checkNominationReferences(ctx context.Context, tx pgx.Tx, nomination *models.NominationRequest) error {
+	fieldErrors := []models.FieldError{}
+	addError := func(field, code, message string) {
+		fieldErrors = append(fieldErrors, models.FieldError{Field: field, Code: code, Message: message})
+	}
+
+	var ceremonyAwardID, categoryAwardID int
+	err := tx.QueryRow(ctx, awardQueries.GetCeremonyAwardID, &nomination.CeremonyID).Scan(&ceremonyAwardID)
+	if err == pgx.ErrNoRows {
+		addError("ceremony_id", "unknown_ceremony", "ceremony not found")
+	} else if err != nil {
+		return err
+	}
+
+	err = tx.QueryRow(ctx, awardQueries.GetCategoryAwardID, &nomination.CategoryID).Scan(&categoryAwardID)
+	if err == pgx.ErrNoRows {
+		addError("category_id", "unknown_category", "category not found")
+	} else if err != nil {
+		return err
+	} else if ceremonyAwardID != 0 && ceremonyAwardID != categoryAwardID {
+		addError("category_id", "not_allowed", "category belongs to another award")
+	}
+
+	err = checkExists(ctx, tx, awardQueries.GetFilmIDByID, nomination.FilmID, pgx.ErrNoRows)
+	if err == pgx.ErrNoRows {
+		addError("film_id", "unknown_film", "film not found")
+	} else if err != nil {
+		return err
+	}
+
+	if nomination.PersonID != 0 {
+		err = checkExists(ctx, tx, awardQueries.GetPersonIDByID, nomination.PersonID, pgx.ErrNoRows)
+		if err == pgx.ErrNoRows {
+			addError("person_id", "unknown_person", "person not found")
+		} else if err != nil {
+			return err
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return awardPackage.ErrInvalidNomination.WithFields(fieldErrors)
+	}
+	return nil
+}
+
+// checkExists выполняет запрос вида select id from ... where id = $1 и возвращает notFound, если строки нет
+func checkExists(ctx context.Context, tx pgx.Tx, query string, id int, notFound error) error {
+	var foundID int
+	row := tx.QueryRow(ctx, query, &id)
+	err := row.Scan(&foundID)
+	if err == pgx.ErrNoRows {
+		return notFound
+	}
+	return err
+}
+
+// nominationArgs returns arguments of CreateNomination and UpdateNomination queries
+func nominationArgs(nomination *models.NominationRequest) []any {
+	var personID *int
+	if nomination.PersonID != 0 {
+		personID = &nomination.PersonID
+	}
+	return []any{&nomination.CeremonyID, &nomination.CategoryID, &nomination.FilmID, personID, &nomination.Won}
+}
+
+func getNomination(ctx context.Context, tx pgx.Tx, nominationID int) (*models.Nomination, error) {
+	row := tx.QueryRow(ctx, awardQueries.GetNominationByID, &nominationID)
+	return scanNomination(row)
+}
+
+func getNominations(ctx context.Context, tx pgx.Tx, query string, id int) ([]models.Nomination, error) {
+	nominations := []models.Nomination{}
+	rows, err := tx.Query(ctx, query, &id)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		nomination, err := scanNomination(rows)
+		if err != nil {
+			return nil, err
+		}
+		nominations = append(nominations, *nomination)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nominations, nil
+}
+
+func scanNomination(row pgx.Row) (*models.Nomination, error) {
+	nomination := &models.Nomination{}
+	err := row.Scan(&nomination.ID, &nomination.AwardID, &nomination.Award, &nomination.CeremonyID, &nomination.Year,
+		&nomination.CategoryID, &nomination.Category, &nomination.FilmID, &nomination.FilmTitle,
+		&nomination.PersonID, &nomination.PersonName, &nomination.Won)
+	if err != nil {
+		return nil, err
+	}
+	return nomination, nil
+}
@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"testing"
+	"vk-intern_test-case/internal/actor"
+	"vk-intern_test-case/internal/award"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*AwardRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testAwardRepo := NewAwardRepository(mock)
+	return testAwardRepo, mock
+}
+
+var nominationColumns = []string{"id", "award_id", "award", "ceremony_id", "year", "category_id", "category",
+	"film_id", "film_title", "person_id", "person_name", "won"}
+
+func TestShouldSuccessfullyGetAwardWithDetails(t *testing.T) {
+	awardRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	awardID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id, name from award").WithArgs(&awardID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name"}).AddRow(awardID, "Оскар"))
+	mock.ExpectQuery("select id, year from award_ceremony").WithArgs(&awardID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "year"}).AddRow(2, 1998).AddRow(1, 1997)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("select id, name from award_category").WithArgs(&awardID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name"}).AddRow(1, "Лучший фильм")).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultAward, err := awardRepo.GetAward(awardID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Оскар", resultAward.Name)
+	assert.Equal(t, []models.AwardCeremony{
+		{ID: 2, AwardCeremonyRequest: models.AwardCeremonyRequest{Year: 1998}},
+		{ID: 1, AwardCeremonyRequest: models.AwardCeremonyRequest{Year: 1997}},
+	}, resultAward.Ceremonies)
+	assert.Len(t, resultAward.Categories, 1)
+}
+
+func TestShouldSuccessfullyAddNomination(t *testing.T) {
+	awardRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	nomination := &models.NominationRequest{CeremonyID: 1, CategoryID: 2, FilmID: 3, Won: true}
+	nominationID := 10
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select award_id from award_ceremony").WithArgs(&nomination.CeremonyID).
+		WillReturnRows(pgxmock.NewRows([]string{"award_id"}).AddRow(1))
+	mock.ExpectQuery("select award_id from award_category").WithArgs(&nomination.CategoryID).
+		WillReturnRows(pgxmock.NewRows([]string{"award_id"}).AddRow(1))
+	mock.ExpectQuery("select id from film").WithArgs(&nomination.FilmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery("insert into nomination").
+		WithArgs(&nomination.CeremonyID, &nomination.CategoryID, &nomination.FilmID, (*int)(nil), &nomination.Won).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(nominationID))
+	mock.ExpectQuery("select n.id").WithArgs(&nominationID).
+		WillReturnRows(pgxmock.NewRows(nominationColumns).
+			AddRow(nominationID, 1, "Оскар", 1, 1998, 2, "Лучший фильм", 3, "Титаник", 0, "", true))
+	mock.ExpectCommit()
+
+	resultNomination, err := awardRepo.AddNomination(nomination)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.Nomination{ID: nominationID, AwardID: 1, Award: "Оскар", CeremonyID: 1, Year: 1998,
+		CategoryID: 2, Category: "Лучший фильм", FilmID: 3, FilmTitle: "Титаник", Won: true}, resultNomination)
+}
+
+func TestShouldFailToAddNominationWithForeignCategoryAndUnknownPerson(t *testing.T) {
+	awardRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	nomination := &models.NominationRequest{CeremonyID: 1, CategoryID: 5, FilmID: 3, PersonID: 7}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select award_id from award_ceremony").WithArgs(&nomination.CeremonyID).
+		WillReturnRows(pgxmock.NewRows([]string{"award_id"}).AddRow(1))
+	mock.ExpectQuery("select award_id from award_category").WithArgs(&nomination.CategoryID).
+		WillReturnRows(pgxmock.NewRows([]string{"award_id"}).AddRow(2))
+	mock.ExpectQuery("select id from film").WithArgs(&nomination.FilmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery("select id from person").WithArgs(&nomination.PersonID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultNomination, err := awardRepo.AddNomination(nomination)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultNomination)
+	assert.Equal(t, award.ErrInvalidNomination.WithFields([]models.FieldError{
+		{Field: "category_id", Code: "not_allowed", Message: "category belongs to another award"},
+		{Field: "person_id", Code: "unknown_person", Message: "person not found"},
+	}), err)
+}
+
+func TestShouldReturnNotFoundWhenGettingNominationsOfNonExistentActor(t *testing.T) {
+	awardRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 7
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from person").WithArgs(&actorID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultNominations, err := awardRepo.GetPersonNominations(actorID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Empty(t, resultNominations)
+	assert.Equal(t, actor.ErrNotFound, err)
+}
+
+func TestShouldReturnNotFoundWhenDeletingNonExistentCeremony(t *testing.T) {
+	awardRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	awardID := 1
+	year := 1950
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from award_ceremony").WithArgs(&awardID, &year).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := awardRepo.DeleteCeremony(awardID, year)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, award.ErrCeremonyNotFound, err)
+}
@@ -0,0 +1,21 @@
+package collection
+
+import "vk-intern_test-case/utils/apperror"
+
+var (
+	// Чужие приватные коллекции тоже не находятся
+	ErrNotFound            = apperror.NotFound("collection_not_found", "Collection not found")
+	ErrForbidden           = apperror.Forbidden("collection_forbidden", "Only the owner can modify the collection")
+	ErrFilmNotInCollection = apperror.NotFound("collection_film_not_found", "Film is not in the collection")
+	ErrOrderMismatch       = apperror.Validation("collection_order_mismatch", "film_ids must list every film of the collection exactly once", nil)
+	ErrSlugAlreadyExists   = apperror.Conflict("collection_slug_taken", "Collection with this slug already exists")
+)
+
+// Адрес коллекции, если из названия не получилось ни одной латинской буквы или цифры
+const DefaultSlug = "collection"
+
+// Размер страницы списка коллекций
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
@@ -0,0 +1,446 @@
+package delivery
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"vk-intern_test-case/internal/collection"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "collection:delivery:"
+
+type CollectionDelivery struct {
+	collectionRepo collection.CollectionRepository
+}
+
+func NewCollectionDelivery(cR collection.CollectionRepository) *CollectionDelivery {
+	return &CollectionDelivery{
+		collectionRepo: cR,
+	}
+}
+
+// HandleCollections handles /collections and /collections/{slug}
+func (cD *CollectionDelivery) HandleCollections(w http.ResponseWriter, r *http.Request) {
+	slug := collectionSlug(r)
+	switch r.Method {
+	case http.MethodGet:
+		if slug == "" {
+			cD.GetCollections(w, r)
+			return
+		}
+		cD.GetCollection(w, r)
+	case http.MethodPost:
+		if slug == "" {
+			cD.AddCollection(w, r)
+		}
+	case http.MethodPut:
+		cD.UpdateCollection(w, r)
+	case http.MethodDelete:
+		cD.DeleteCollection(w, r)
+	}
+}
+
+// HandleCollectionFilms handles /collections/{slug}/films/{film_id}
+func (cD *CollectionDelivery) HandleCollectionFilms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		cD.SetCollectionFilm(w, r)
+	case http.MethodDelete:
+		cD.DeleteCollectionFilm(w, r)
+	}
+}
+
+// HandleCollectionOrder handles /collections/{slug}/order
+func (cD *CollectionDelivery) HandleCollectionOrder(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPut:
+		cD.ReorderCollection(w, r)
+	}
+}
+
+// HandleCollectionForks handles /collections/{slug}/forks
+func (cD *CollectionDelivery) HandleCollectionForks(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		cD.ForkCollection(w, r)
+	}
+}
+
+// swagger:route GET /collections Collections getCollections
+// Возвращает публичные коллекции, сначала популярные: чаще скопированные, затем с большим числом фильмов.
+// С заголовком Authorization в список попадают и приватные коллекции пользователя.
+// user_id - коллекции одного пользователя, limit (по умолчанию 20, не больше 100) и offset - страница.
+// Общее число коллекций - в заголовке X-Total-Count
+// responses:
+//
+//	200: []collection
+//	400: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) GetCollections(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetCollections:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	viewerID, _ := middleware.UserID(r.Context())
+	filter, err := parseCollectionFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultCollections, total, err := cD.collectionRepo.GetCollections(viewerID, filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCollections)
+}
+
+// swagger:route POST /collections Collections addCollection
+// Создаёт коллекцию пользователя из заголовка Authorization. Если slug не задан, он получается
+// из названия транслитерацией, при совпадении с существующим добавляется номер
+// security:
+// - key:
+// responses:
+//
+//	200: collection
+//	400: problemResponse
+//  401: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) AddCollection(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddCollection:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+
+	var collectionRequest models.CollectionRequest
+	err := json.NewDecoder(r.Body).Decode(&collectionRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateCollection(&collectionRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultCollection, err := cD.collectionRepo.AddCollection(userID, &collectionRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCollection)
+}
+
+// swagger:route GET /collections/{slug} Collections getCollection
+// Возвращает коллекцию с фильмами по порядку. Приватная коллекция видна только владельцу
+// responses:
+//
+//	200: collectionWithFilms
+//	404: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) GetCollection(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	viewerID, _ := middleware.UserID(r.Context())
+
+	resultCollection, err := cD.collectionRepo.GetCollection(collectionSlug(r), viewerID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCollection)
+}
+
+// swagger:route PUT /collections/{slug} Collections updateCollection
+// Меняет название, описание и видимость коллекции. slug коллекции не меняется. Доступно владельцу
+// security:
+// - key:
+// responses:
+//
+//	200: collection
+//	400: problemResponse
+//  401: problemResponse
+//	403: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) UpdateCollection(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "UpdateCollection:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+
+	var collectionRequest models.CollectionRequest
+	err := json.NewDecoder(r.Body).Decode(&collectionRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+	collectionRequest.Slug = ""
+
+	err = validation.ValidateCollection(&collectionRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultCollection, err := cD.collectionRepo.UpdateCollection(collectionSlug(r), userID, &collectionRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCollection)
+}
+
+// swagger:route DELETE /collections/{slug} Collections deleteCollection
+// Удаляет коллекцию. Копии коллекции остаются. Доступно владельцу
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//  401: problemResponse
+//	403: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+
+	err := cD.collectionRepo.DeleteCollection(collectionSlug(r), userID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route PUT /collections/{slug}/films/{film_id} Collections setCollectionFilm
+// Добавляет фильм в конец коллекции или меняет заметку о фильме, который уже в ней. Доступно владельцу
+// security:
+// - key:
+// responses:
+//
+//	200: collectionFilm
+//	400: problemResponse
+//  401: problemResponse
+//	403: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) SetCollectionFilm(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "SetCollectionFilm:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	filmID, err := parseFilmID(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	// Заметка необязательна, тело запроса может быть пустым
+	var collectionFilm models.CollectionFilmRequest
+	err = json.NewDecoder(r.Body).Decode(&collectionFilm)
+	if err != nil && err != io.EOF {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateCollectionFilm(&collectionFilm).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFilm, err := cD.collectionRepo.SetCollectionFilm(collectionSlug(r), userID, filmID, &collectionFilm)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilm)
+}
+
+// swagger:route DELETE /collections/{slug}/films/{film_id} Collections deleteCollectionFilm
+// Убирает фильм из коллекции, следующие фильмы сдвигаются. Доступно владельцу
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	403: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) DeleteCollectionFilm(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	filmID, err := parseFilmID(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = cD.collectionRepo.DeleteCollectionFilm(collectionSlug(r), userID, filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route PUT /collections/{slug}/order Collections reorderCollection
+// Меняет порядок фильмов коллекции. В film_ids должны быть все фильмы коллекции по одному разу.
+// Доступно владельцу
+// security:
+// - key:
+// responses:
+//
+//	200: collectionWithFilms
+//	400: problemResponse
+//  401: problemResponse
+//	403: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) ReorderCollection(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "ReorderCollection:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+
+	var order models.CollectionOrderRequest
+	err := json.NewDecoder(r.Body).Decode(&order)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateCollectionOrder(&order).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultCollection, err := cD.collectionRepo.ReorderCollection(collectionSlug(r), userID, &order)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCollection)
+}
+
+// swagger:route POST /collections/{slug}/forks Collections forkCollection
+// Копирует публичную коллекцию вместе с фильмами и заметками в новую приватную коллекцию
+// пользователя из заголовка Authorization
+// security:
+// - key:
+// responses:
+//
+//	200: collection
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (cD *CollectionDelivery) ForkCollection(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "ForkCollection:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+
+	resultCollection, err := cD.collectionRepo.ForkCollection(collectionSlug(r), userID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCollection)
+}
+
+func parseCollectionFilter(r *http.Request) (*models.CollectionFilter, error) {
+	query := r.URL.Query()
+	filter := &models.CollectionFilter{
+		Limit: collection.DefaultPageSize,
+	}
+
+	intParams := []struct {
+		name     string
+		target   *int
+		min, max int
+	}{
+		{"user_id", &filter.UserID, 1, math.MaxInt32},
+		{"limit", &filter.Limit, 1, collection.MaxPageSize},
+		{"offset", &filter.Offset, 0, math.MaxInt32},
+	}
+	for _, param := range intParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < param.min || number > param.max {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Invalid " + param.name)
+		}
+		*param.target = number
+	}
+	return filter, nil
+}
+
+// collectionSlug returns slug from path like /collections/{slug}/..., empty for /collections
+func collectionSlug(r *http.Request) string {
+	return router.PathSegments(r.URL.Path, "/collections")[0]
+}
+
+// parseFilmID returns film id from path like /collections/{slug}/films/{film_id}
+func parseFilmID(r *http.Request) (int, error) {
+	segments := router.PathSegments(r.URL.Path, "/collections")
+	if len(segments) < 3 {
+		return 0, apperror.ErrInvalidID
+	}
+	filmID, err := strconv.Atoi(segments[2])
+	if err != nil {
+		return 0, apperror.ErrInvalidID
+	}
+	return filmID, nil
+}
@@ -0,0 +1,359 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/collection"
+	"vk-intern_test-case/internal/collection/mock"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type collectionTest struct {
+	name string
+	// ID пользователя, которого определил middleware, 0 - без авторизации
+	userID             int
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockCollectionRepository *mock.MockCollectionRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var collectionTime = time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC)
+
+var comedies = models.Collection{
+	ID:     1,
+	UserID: 1,
+	CollectionRequest: models.CollectionRequest{
+		Slug:     "luchshie-sovetskie-komedii",
+		Name:     "Лучшие советские комедии",
+		IsPublic: true,
+	},
+	FilmCount: 1,
+	ForkCount: 3,
+	CreatedAt: collectionTime,
+	UpdatedAt: collectionTime,
+}
+
+const comediesJSON = `{
+	"id": 1,
+	"user_id": 1,
+	"slug": "luchshie-sovetskie-komedii",
+	"name": "Лучшие советские комедии",
+	"description": "",
+	"is_public": true,
+	"film_count": 1,
+	"fork_count": 3,
+	"created_at": "2024-03-18T12:00:00Z",
+	"updated_at": "2024-03-18T12:00:00Z"
+}`
+
+var collectionsTests = []collectionTest{
+	{
+		"Successfully get popular collections",
+		0,
+		http.MethodGet,
+		"/collections?limit=5",
+		"",
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			mockCollectionRepository.EXPECT().
+				GetCollections(0, &models.CollectionFilter{Limit: 5}).
+				Return([]models.Collection{comedies}, 1, nil)
+		},
+		"[" + comediesJSON + "]",
+		http.StatusOK,
+	},
+	{
+		"Successfully create a collection",
+		1,
+		http.MethodPost,
+		"/collections",
+		`{"name": "Лучшие советские комедии", "is_public": true}`,
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			mockCollectionRepository.EXPECT().
+				AddCollection(1, &models.CollectionRequest{Name: "Лучшие советские комедии", IsPublic: true}).
+				Return(&comedies, nil)
+		},
+		comediesJSON,
+		http.StatusOK,
+	},
+	{
+		"Fail to create a collection with invalid slug",
+		1,
+		http.MethodPost,
+		"/collections",
+		`{"name": "Комедии", "slug": "Комедии"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/collections",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "slug", "code": "invalid_format", "message": "slug must contain only lowercase latin letters, digits and single hyphens"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Successfully get a collection with films",
+		0,
+		http.MethodGet,
+		"/collections/luchshie-sovetskie-komedii",
+		"",
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			mockCollectionRepository.EXPECT().
+				GetCollection("luchshie-sovetskie-komedii", 0).
+				Return(&models.CollectionWithFilms{
+					Collection: comedies,
+					Films: []models.CollectionFilm{{
+						Film: models.Film{ID: 4, FilmRequest: models.FilmRequest{
+							Title: "Бриллиантовая рука", ReleaseDate: "1969-04-28", Rating: 9}},
+						Position: 1,
+						Note:     "Смотреть на Новый год",
+					}},
+				}, nil)
+		},
+		`{
+			"id": 1,
+			"user_id": 1,
+			"slug": "luchshie-sovetskie-komedii",
+			"name": "Лучшие советские комедии",
+			"description": "",
+			"is_public": true,
+			"film_count": 1,
+			"fork_count": 3,
+			"created_at": "2024-03-18T12:00:00Z",
+			"updated_at": "2024-03-18T12:00:00Z",
+			"films": [{
+				"id": 4,
+				"title": "Бриллиантовая рука",
+				"description": "",
+				"release_date": "1969-04-28",
+				"rating": 9,
+				"position": 1,
+				"note": "Смотреть на Новый год"
+			}]
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to delete a collection of another user",
+		2,
+		http.MethodDelete,
+		"/collections/luchshie-sovetskie-komedii",
+		"",
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			mockCollectionRepository.EXPECT().
+				DeleteCollection("luchshie-sovetskie-komedii", 2).
+				Return(collection.ErrForbidden)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Forbidden",
+			"status": 403,
+			"detail": "Only the owner can modify the collection",
+			"instance": "/collections/luchshie-sovetskie-komedii",
+			"code": "collection_forbidden"
+		}`,
+		http.StatusForbidden,
+	},
+}
+
+func TestHandleCollections(t *testing.T) {
+	runCollectionTests(t, collectionsTests, func(collectionDelivery *CollectionDelivery) http.HandlerFunc {
+		return collectionDelivery.HandleCollections
+	})
+}
+
+var collectionFilmsTests = []collectionTest{
+	{
+		"Successfully add a film without note",
+		1,
+		http.MethodPut,
+		"/collections/luchshie-sovetskie-komedii/films/4",
+		"",
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			mockCollectionRepository.EXPECT().
+				SetCollectionFilm("luchshie-sovetskie-komedii", 1, 4, &models.CollectionFilmRequest{}).
+				Return(&models.CollectionFilm{
+					Film: models.Film{ID: 4, FilmRequest: models.FilmRequest{
+						Title: "Бриллиантовая рука", ReleaseDate: "1969-04-28", Rating: 9}},
+					Position: 2,
+				}, nil)
+		},
+		`{
+			"id": 4,
+			"title": "Бриллиантовая рука",
+			"description": "",
+			"release_date": "1969-04-28",
+			"rating": 9,
+			"position": 2
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to remove a film with invalid id",
+		1,
+		http.MethodDelete,
+		"/collections/luchshie-sovetskie-komedii/films/ruka",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid id in request path",
+			"instance": "/collections/luchshie-sovetskie-komedii/films/ruka",
+			"code": "invalid_id"
+		}`,
+		http.StatusBadRequest,
+	},
+}
+
+func TestHandleCollectionFilms(t *testing.T) {
+	runCollectionTests(t, collectionFilmsTests, func(collectionDelivery *CollectionDelivery) http.HandlerFunc {
+		return collectionDelivery.HandleCollectionFilms
+	})
+}
+
+var collectionOrderTests = []collectionTest{
+	{
+		"Fail to reorder a collection without some of its films",
+		1,
+		http.MethodPut,
+		"/collections/luchshie-sovetskie-komedii/order",
+		`{"film_ids": [4]}`,
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			mockCollectionRepository.EXPECT().
+				ReorderCollection("luchshie-sovetskie-komedii", 1, &models.CollectionOrderRequest{FilmIDs: []int{4}}).
+				Return(nil, collection.ErrOrderMismatch)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "film_ids must list every film of the collection exactly once",
+			"instance": "/collections/luchshie-sovetskie-komedii/order",
+			"code": "collection_order_mismatch"
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+}
+
+func TestHandleCollectionOrder(t *testing.T) {
+	runCollectionTests(t, collectionOrderTests, func(collectionDelivery *CollectionDelivery) http.HandlerFunc {
+		return collectionDelivery.HandleCollectionOrder
+	})
+}
+
+var collectionForksTests = []collectionTest{
+	{
+		"Successfully fork a collection",
+		2,
+		http.MethodPost,
+		"/collections/luchshie-sovetskie-komedii/forks",
+		"",
+		func(mockCollectionRepository *mock.MockCollectionRepository) {
+			originalID := 1
+			fork := comedies
+			fork.ID, fork.UserID, fork.Slug, fork.IsPublic, fork.ForkCount = 5, 2, "luchshie-sovetskie-komedii-2", false, 0
+			fork.ForkedFrom = &originalID
+			mockCollectionRepository.EXPECT().
+				ForkCollection("luchshie-sovetskie-komedii", 2).
+				Return(&fork, nil)
+		},
+		`{
+			"id": 5,
+			"user_id": 2,
+			"slug": "luchshie-sovetskie-komedii-2",
+			"name": "Лучшие советские комедии",
+			"description": "",
+			"is_public": false,
+			"forked_from": 1,
+			"film_count": 1,
+			"fork_count": 0,
+			"created_at": "2024-03-18T12:00:00Z",
+			"updated_at": "2024-03-18T12:00:00Z"
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to fork a collection without authorization",
+		0,
+		http.MethodPost,
+		"/collections/luchshie-sovetskie-komedii/forks",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unauthorized",
+			"status": 401,
+			"detail": "Authorization header with a valid user id is required",
+			"instance": "/collections/luchshie-sovetskie-komedii/forks",
+			"code": "unauthorized"
+		}`,
+		http.StatusUnauthorized,
+	},
+}
+
+func TestHandleCollectionForks(t *testing.T) {
+	runCollectionTests(t, collectionForksTests, func(collectionDelivery *CollectionDelivery) http.HandlerFunc {
+		return collectionDelivery.HandleCollectionForks
+	})
+}
+
+func runCollectionTests(t *testing.T, tests []collectionTest, handler func(*CollectionDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCollectionRepository := mock.NewMockCollectionRepository(ctrl)
+			collectionDeliveryTest := NewCollectionDelivery(mockCollectionRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockCollectionRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+			if test.userID != 0 {
+				request = request.WithContext(middleware.WithUserID(request.Context(), test.userID))
+			}
+
+			handler(collectionDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/collection/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCollectionRepository is a mock of CollectionRepository interface.
+type MockCollectionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCollectionRepositoryMockRecorder
+}
+
+// MockCollectionRepositoryMockRecorder is the mock recorder for MockCollectionRepository.
+type MockCollectionRepositoryMockRecorder struct {
+	mock *MockCollectionRepository
+}
+
+// NewMockCollectionRepository creates a new mock instance.
+func NewMockCollectionRepository(ctrl *gomock.Controller) *MockCollectionRepository {
+	mock := &MockCollectionRepository{ctrl: ctrl}
+	mock.recorder = &MockCollectionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCollectionRepository) EXPECT() *MockCollectionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCollection mocks base method.
+func (m *MockCollectionRepository) AddCollection(userID int, collection *models.CollectionRequest) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollection", userID, collection)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCollection indicates an expected call of AddCollection.
+func (mr *MockCollectionRepositoryMockRecorder) AddCollection(userID, collection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollection", reflect.TypeOf((*MockCollectionRepository)(nil).AddCollection), userID, collection)
+}
+
+// DeleteCollection mocks base method.
+func (m *MockCollectionRepository) DeleteCollection(slug string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollection", slug, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollection indicates an expected call of DeleteCollection.
+func (mr *MockCollectionRepositoryMockRecorder) DeleteCollection(slug, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollection", reflect.TypeOf((*MockCollectionRepository)(nil).DeleteCollection), slug, userID)
+}
+
+// DeleteCollectionFilm mocks base method.
+func (m *MockCollectionRepository) DeleteCollectionFilm(slug string, userID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionFilm", slug, userID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionFilm indicates an expected call of DeleteCollectionFilm.
+func (mr *MockCollectionRepositoryMockRecorder) DeleteCollectionFilm(slug, userID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionFilm", reflect.TypeOf((*MockCollectionRepository)(nil).DeleteCollectionFilm), slug, userID, filmID)
+}
+
+// ForkCollection mocks base method.
+func (m *MockCollectionRepository) ForkCollection(slug string, userID int) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForkCollection", slug, userID)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForkCollection indicates an expected call of ForkCollection.
+func (mr *MockCollectionRepositoryMockRecorder) ForkCollection(slug, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForkCollection", reflect.TypeOf((*MockCollectionRepository)(nil).ForkCollection), slug, userID)
+}
+
+// GetCollection mocks base method.
+func (m *MockCollectionRepository) GetCollection(slug string, viewerID int) (*models.CollectionWithFilms, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollection", slug, viewerID)
+	ret0, _ := ret[0].(*models.CollectionWithFilms)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollection indicates an expected call of GetCollection.
+func (mr *MockCollectionRepositoryMockRecorder) GetCollection(slug, viewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollection", reflect.TypeOf((*MockCollectionRepository)(nil).GetCollection), slug, viewerID)
+}
+
+// GetCollections mocks base method.
+func (m *MockCollectionRepository) GetCollections(viewerID int, filter *models.CollectionFilter) ([]models.Collection, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollections", viewerID, filter)
+	ret0, _ := ret[0].([]models.Collection)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCollections indicates an expected call of GetCollections.
+func (mr *MockCollectionRepositoryMockRecorder) GetCollections(viewerID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollections", reflect.TypeOf((*MockCollectionRepository)(nil).GetCollections), viewerID, filter)
+}
+
+// ReorderCollection mocks base method.
+func (m *MockCollectionRepository) ReorderCollection(slug string, userID int, order *models.CollectionOrderRequest) (*models.CollectionWithFilms, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderCollection", slug, userID, order)
+	ret0, _ := ret[0].(*models.CollectionWithFilms)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReorderCollection indicates an expected call of ReorderCollection.
+func (mr *MockCollectionRepositoryMockRecorder) ReorderCollection(slug, userID, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderCollection", reflect.TypeOf((*MockCollectionRepository)(nil).ReorderCollection), slug, userID, order)
+}
+
+// SetCollectionFilm mocks base method.
+func (m *MockCollectionRepository) SetCollectionFilm(slug string, userID, filmID int, collectionFilm *models.CollectionFilmRequest) (*models.CollectionFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCollectionFilm", slug, userID, filmID, collectionFilm)
+	ret0, _ := ret[0].(*models.CollectionFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCollectionFilm indicates an expected call of SetCollectionFilm.
+func (mr *MockCollectionRepositoryMockRecorder) SetCollectionFilm(slug, userID, filmID, collectionFilm interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCollectionFilm", reflect.TypeOf((*MockCollectionRepository)(nil).SetCollectionFilm), slug, userID, filmID, collectionFilm)
+}
+
+// UpdateCollection mocks base method.
+func (m *MockCollectionRepository) UpdateCollection(slug string, userID int, collection *models.CollectionRequest) (*models.Collection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCollection", slug, userID, collection)
+	ret0, _ := ret[0].(*models.Collection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCollection indicates an expected call of UpdateCollection.
+func (mr *MockCollectionRepositoryMockRecorder) UpdateCollection(slug, userID, collection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCollection", reflect.TypeOf((*MockCollectionRepository)(nil).UpdateCollection), slug, userID, collection)
+}
@@ -0,0 +1,59 @@
+package queries
+
+import filmQueries "vk-intern_test-case/internal/film/queries"
+
+const (
+	collectionFilmCount = `(select count(*) from collection_film as cf where cf.collection_id = c.id)`
+	collectionForkCount = `(select count(*) from collection as fc where fc.forked_from = c.id)`
+	collectionColumns   = `c.id, c.user_id, c.slug, c.name, c.description, c.is_public, c.forked_from, ` +
+		collectionFilmCount + `, ` + collectionForkCount + `, c.created_at, c.updated_at`
+)
+
+const (
+	GetCollectionBySlug = `select ` + collectionColumns + ` from collection as c where c.slug = $1;`
+	// Изменения коллекции выполняются по очереди: строка блокируется до конца транзакции,
+	// поэтому одновременно добавленные фильмы не получают одну и ту же позицию
+	LockCollectionBySlug = `select ` + collectionColumns + ` from collection as c where c.slug = $1 for update;`
+	SlugExists           = `select exists (select 1 from collection where slug = $1);`
+	CreateCollection     = `insert into collection (user_id, slug, name, description, is_public, forked_from)
+		values ($1, $2, $3, $4, $5, $6) returning id;`
+	UpdateCollection = `update collection set name = $1, description = $2, is_public = $3, updated_at = now()
+		where id = $4;`
+	TouchCollection  = `update collection set updated_at = now() where id = $1;`
+	DeleteCollection = `delete from collection where id = $1;`
+)
+
+// Список коллекций: публичные и собственные коллекции пользователя $1, самые копируемые - первыми
+const (
+	collectionsFrom       = ` from collection as c where (c.is_public or c.user_id = $1)`
+	GetCollections        = `select ` + collectionColumns + collectionsFrom
+	CountCollections      = `select count(*)` + collectionsFrom
+	CollectionOwnerFilter = ` and c.user_id = $%d`
+	CollectionsOrder      = ` order by ` + collectionForkCount + ` desc, ` + collectionFilmCount + ` desc,
+		c.updated_at desc, c.id limit $%d offset $%d`
+)
+
+const (
+	GetFilmIDByID      = `select id from film where id = $1;`
+	GetCollectionFilms = `select ` + filmQueries.FilmColumns + `, cf.position, cf.note
+		from collection_film as cf join film as f on f.id = cf.film_id
+		where cf.collection_id = $1 order by cf.position;`
+	GetCollectionFilm = `select ` + filmQueries.FilmColumns + `, cf.position, cf.note
+		from collection_film as cf join film as f on f.id = cf.film_id
+		where cf.collection_id = $1 and cf.film_id = $2;`
+	// Новый фильм добавляется в конец коллекции, у добавленного ранее меняется только заметка
+	SetCollectionFilm = `insert into collection_film (collection_id, film_id, position, note)
+		values ($1, $2, (select coalesce(max(position), 0) + 1 from collection_film where collection_id = $1), $3)
+		on conflict (collection_id, film_id) do update set note = excluded.note;`
+	DeleteCollectionFilm = `delete from collection_film where collection_id = $1 and film_id = $2 returning position;`
+	// Фильмы после удалённого сдвигаются, чтобы позиции шли подряд
+	ShiftCollectionFilms = `update collection_film set position = position - 1
+		where collection_id = $1 and position > $2;`
+	GetCollectionFilmIDs = `select film_id from collection_film where collection_id = $1;`
+	// Позиция фильма - его номер в массиве $2
+	ReorderCollection = `update collection_film as cf set position = o.position
+		from unnest($2::int[]) with ordinality as o(film_id, position)
+		where cf.collection_id = $1 and cf.film_id = o.film_id;`
+	ForkCollectionFilms = `insert into collection_film (collection_id, film_id, position, note)
+		select $1, film_id, position, note from collection_film where collection_id = $2;`
+)
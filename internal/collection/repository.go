@@ -0,0 +1,15 @@
+package collection
+
+import "vk-intern_test-case/models"
+
+type CollectionRepository interface {
+	GetCollections(viewerID int, filter *models.CollectionFilter) ([]models.Collection, int, error)
+	AddCollection(userID int, collection *models.CollectionRequest) (*models.Collection, error)
+	GetCollection(slug string, viewerID int) (*models.CollectionWithFilms, error)
+	UpdateCollection(slug string, userID int, collection *models.CollectionRequest) (*models.Collection, error)
+	DeleteCollection(slug string, userID int) error
+	SetCollectionFilm(slug string, userID int, filmID int, collectionFilm *models.CollectionFilmRequest) (*models.CollectionFilm, error)
+	DeleteCollectionFilm(slug string, userID int, filmID int) error
+	ReorderCollection(slug string, userID int, order *models.CollectionOrderRequest) (*models.CollectionWithFilms, error)
+	ForkCollection(slug string, userID int) (*models.Collection, error)
+}
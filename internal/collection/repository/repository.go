@@ -0,0 +1,540 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	collectionPackage "vk-intern_test-case/internal/collection"
+	collectionQueries "vk-intern_test-case/internal/collection/queries"
+	filmPackage "vk-intern_test-case/internal/film"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+	"vk-intern_test-case/utils/slug"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "collection:repository:"
+
+type CollectionRepository struct {
+	pool database.PgxIface
+}
+
+func NewCollectionRepository(pool database.PgxIface) *CollectionRepository {
+	return &CollectionRepository{
+		pool: pool,
+	}
+}
+
+// GetCollections возвращает страницу публичных коллекций и собственных коллекций пользователя viewerID
+// и общее число таких коллекций
+func (cR *CollectionRepository) GetCollections(viewerID int, filter *models.CollectionFilter) ([]models.Collection, int, error) {
+	message := logMessage + "GetCollections:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Collection{}, 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	conditions := ""
+	args := []any{viewerID}
+	if filter.UserID > 0 {
+		args = append(args, filter.UserID)
+		conditions = fmt.Sprintf(collectionQueries.CollectionOwnerFilter, len(args))
+	}
+
+	var total int
+	row := tx.QueryRow(transactionCtx, collectionQueries.CountCollections+conditions, args...)
+	err = row.Scan(&total)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.Collection{}, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := collectionQueries.GetCollections + conditions +
+		fmt.Sprintf(collectionQueries.CollectionsOrder, len(args)-1, len(args))
+	rows, err := tx.Query(transactionCtx, query, args...)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.Collection{}, 0, err
+	}
+
+	collections := []models.Collection{}
+	for rows.Next() {
+		collection, err := scanCollection(rows)
+		if err != nil {
+			return []models.Collection{}, 0, err
+		}
+		collections = append(collections, *collection)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Collection{}, 0, err
+	}
+	return collections, total, nil
+}
+
+func (cR *CollectionRepository) AddCollection(userID int, collection *models.CollectionRequest) (*models.Collection, error) {
+	message := logMessage + "AddCollection:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	collectionSlug := collection.Slug
+	if collectionSlug == "" {
+		collectionSlug, err = uniqueSlug(transactionCtx, tx, collection.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resultCollection, err := createCollection(transactionCtx, tx, userID, collectionSlug, collection, nil)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	return resultCollection, nil
+}
+
+// GetCollection возвращает коллекцию с фильмами. Приватная коллекция видна только владельцу
+func (cR *CollectionRepository) GetCollection(slug string, viewerID int) (*models.CollectionWithFilms, error) {
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	collection, err := getVisibleCollection(transactionCtx, tx, slug, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionWithFilms, err := getCollectionFilms(transactionCtx, tx, collection)
+	if err != nil {
+		return nil, err
+	}
+	return collectionWithFilms, nil
+}
+
+// UpdateCollection меняет название, описание и видимость коллекции. Адрес коллекции не меняется
+func (cR *CollectionRepository) UpdateCollection(slug string, userID int, collection *models.CollectionRequest) (*models.Collection, error) {
+	message := logMessage + "UpdateCollection:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	ownCollection, err := getOwnCollection(transactionCtx, tx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(transactionCtx, collectionQueries.UpdateCollection, &collection.Name, &collection.Description,
+		&collection.IsPublic, &ownCollection.ID)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+
+	row := tx.QueryRow(transactionCtx, collectionQueries.GetCollectionBySlug, &slug)
+	resultCollection, err := scanCollection(row)
+	if err != nil {
+		return nil, err
+	}
+	return resultCollection, nil
+}
+
+func (cR *CollectionRepository) DeleteCollection(slug string, userID int) error {
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	collection, err := getOwnCollection(transactionCtx, tx, slug, userID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(transactionCtx, collectionQueries.DeleteCollection, &collection.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetCollectionFilm добавляет фильм в конец коллекции или меняет заметку о добавленном ранее фильме
+func (cR *CollectionRepository) SetCollectionFilm(slug string, userID int, filmID int, collectionFilm *models.CollectionFilmRequest) (*models.CollectionFilm, error) {
+	message := logMessage + "SetCollectionFilm:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	collection, err := getOwnCollection(transactionCtx, tx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var id int
+	row := tx.QueryRow(transactionCtx, collectionQueries.GetFilmIDByID, &filmID)
+	err = row.Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = filmPackage.ErrNotFound
+			return nil, err
+		}
+		return nil, err
+	}
+
+	_, err = tx.Exec(transactionCtx, collectionQueries.SetCollectionFilm, &collection.ID, &filmID, &collectionFilm.Note)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	_, err = tx.Exec(transactionCtx, collectionQueries.TouchCollection, &collection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	row = tx.QueryRow(transactionCtx, collectionQueries.GetCollectionFilm, &collection.ID, &filmID)
+	resultFilm, err := scanCollectionFilm(row)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	return resultFilm, nil
+}
+
+func (cR *CollectionRepository) DeleteCollectionFilm(slug string, userID int, filmID int) error {
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	collection, err := getOwnCollection(transactionCtx, tx, slug, userID)
+	if err != nil {
+		return err
+	}
+
+	var position int
+	row := tx.QueryRow(transactionCtx, collectionQueries.DeleteCollectionFilm, &collection.ID, &filmID)
+	err = row.Scan(&position)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = collectionPackage.ErrFilmNotInCollection
+			return err
+		}
+		return err
+	}
+
+	_, err = tx.Exec(transactionCtx, collectionQueries.ShiftCollectionFilms, &collection.ID, &position)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(transactionCtx, collectionQueries.TouchCollection, &collection.ID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReorderCollection расставляет фильмы коллекции в порядке order.FilmIDs.
+// В новом порядке должны быть все фильмы коллекции
+func (cR *CollectionRepository) ReorderCollection(slug string, userID int, order *models.CollectionOrderRequest) (*models.CollectionWithFilms, error) {
+	message := logMessage + "ReorderCollection:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	collection, err := getOwnCollection(transactionCtx, tx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(transactionCtx, collectionQueries.GetCollectionFilmIDs, &collection.ID)
+	if err != nil {
+		return nil, err
+	}
+	filmIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		return nil, err
+	}
+
+	newOrder := slices.Clone(order.FilmIDs)
+	slices.Sort(filmIDs)
+	slices.Sort(newOrder)
+	if !slices.Equal(filmIDs, newOrder) {
+		err = collectionPackage.ErrOrderMismatch
+		return nil, err
+	}
+
+	_, err = tx.Exec(transactionCtx, collectionQueries.ReorderCollection, &collection.ID, order.FilmIDs)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	_, err = tx.Exec(transactionCtx, collectionQueries.TouchCollection, &collection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	collectionWithFilms, err := getCollectionFilms(transactionCtx, tx, collection)
+	if err != nil {
+		return nil, err
+	}
+	return collectionWithFilms, nil
+}
+
+// ForkCollection копирует публичную или собственную коллекцию вместе с фильмами и заметками
+// в новую приватную коллекцию пользователя
+func (cR *CollectionRepository) ForkCollection(slug string, userID int) (*models.Collection, error) {
+	message := logMessage + "ForkCollection:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	original, err := getVisibleCollection(transactionCtx, tx, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	forkSlug, err := uniqueSlug(transactionCtx, tx, original.Slug)
+	if err != nil {
+		return nil, err
+	}
+	forkRequest := &models.CollectionRequest{Name: original.Name, Description: original.Description}
+	fork, err := createCollection(transactionCtx, tx, userID, forkSlug, forkRequest, &original.ID)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+
+	_, err = tx.Exec(transactionCtx, collectionQueries.ForkCollectionFilms, &fork.ID, &original.ID)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	fork.FilmCount = original.FilmCount
+	return fork, nil
+}
+
+func createCollection(ctx context.Context, tx pgx.Tx, userID int, collectionSlug string,
+	collection *models.CollectionRequest, forkedFrom *int) (*models.Collection, error) {
+	var id int
+	row := tx.QueryRow(ctx, collectionQueries.CreateCollection, &userID, &collectionSlug, &collection.Name,
+		&collection.Description, &collection.IsPublic, forkedFrom)
+	err := row.Scan(&id)
+	if err != nil {
+		err = database.MapError(err)
+		if errors.Is(err, database.ErrAlreadyExists) {
+			return nil, collectionPackage.ErrSlugAlreadyExists
+		}
+		return nil, err
+	}
+
+	row = tx.QueryRow(ctx, collectionQueries.GetCollectionBySlug, &collectionSlug)
+	return scanCollection(row)
+}
+
+// uniqueSlug returns slug of the name that no collection has yet, adding -2, -3... if needed
+func uniqueSlug(ctx context.Context, tx pgx.Tx, name string) (string, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = collectionPackage.DefaultSlug
+	}
+	candidate := base
+	for number := 2; ; number++ {
+		var exists bool
+		row := tx.QueryRow(ctx, collectionQueries.SlugExists, &candidate)
+		err := row.Scan(&exists)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, number)
+	}
+}
+
+// getVisibleCollection returns public collection or own collection of the viewer
+func getVisibleCollection(ctx context.Context, tx pgx.Tx, slug string, viewerID int) (*models.Collection, error) {
+	return findCollection(ctx, tx, collectionQueries.GetCollectionBySlug, slug, viewerID)
+}
+
+// getOwnCollection returns collection that the user is allowed to modify.
+// The collection row stays locked until the end of the transaction
+func getOwnCollection(ctx context.Context, tx pgx.Tx, slug string, userID int) (*models.Collection, error) {
+	collection, err := findCollection(ctx, tx, collectionQueries.LockCollectionBySlug, slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	if collection.UserID != userID {
+		return nil, collectionPackage.ErrForbidden
+	}
+	return collection, nil
+}
+
+// findCollection reads collection by slug with query, hiding private collections of other users
+func findCollection(ctx context.Context, tx pgx.Tx, query string, slug string, viewerID int) (*models.Collection, error) {
+	row := tx.QueryRow(ctx, query, &slug)
+	collection, err := scanCollection(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, collectionPackage.ErrNotFound
+		}
+		return nil, err
+	}
+	if !collection.IsPublic && collection.UserID != viewerID {
+		return nil, collectionPackage.ErrNotFound
+	}
+	return collection, nil
+}
+
+func getCollectionFilms(ctx context.Context, tx pgx.Tx, collection *models.Collection) (*models.CollectionWithFilms, error) {
+	collectionWithFilms := &models.CollectionWithFilms{
+		Collection: *collection,
+		Films:      []models.CollectionFilm{},
+	}
+	rows, err := tx.Query(ctx, collectionQueries.GetCollectionFilms, &collection.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		collectionFilm, err := scanCollectionFilm(rows)
+		if err != nil {
+			return nil, err
+		}
+		collectionWithFilms.Films = append(collectionWithFilms.Films, *collectionFilm)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return collectionWithFilms, nil
+}
+
+func scanCollection(row pgx.Row) (*models.Collection, error) {
+	collection := &models.Collection{}
+	err := row.Scan(&collection.ID, &collection.UserID, &collection.Slug, &collection.Name, &collection.Description,
+		&collection.IsPublic, &collection.ForkedFrom, &collection.FilmCount, &collection.ForkCount,
+		&collection.CreatedAt, &collection.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return collection, nil
+}
+
+func scanCollectionFilm(row pgx.Row) (*models.CollectionFilm, error) {
+	collectionFilm := &models.CollectionFilm{}
+	film, err := filmRepository.ScanFilm(row, &collectionFilm.Position, &collectionFilm.Note)
+	if err != nil {
+		return nil, err
+	}
+	collectionFilm.Film = *film
+	return collectionFilm, nil
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/collection"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*CollectionRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCollectionRepo := NewCollectionRepository(mock)
+	return testCollectionRepo, mock
+}
+
+var collectionColumns = []string{"id", "user_id", "slug", "name", "description", "is_public", "forked_from",
+	"film_count", "fork_count", "created_at", "updated_at"}
+
+func collectionRow(id, userID int, slug string, isPublic bool, filmCount int, now time.Time) []any {
+	return []any{id, userID, slug, "Лучшие советские комедии", "", isPublic, nil, filmCount, 0, now, now}
+}
+
+func TestShouldAddCollectionWithNumberedSlug(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID := 1
+	newCollection := &models.CollectionRequest{Name: "Лучшие советские комедии", IsPublic: true}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select exists").WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("select exists").WithArgs(pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	slug := "luchshie-sovetskie-komedii-2"
+	mock.ExpectQuery("insert into collection").
+		WithArgs(&userID, &slug, &newCollection.Name, &newCollection.Description, &newCollection.IsPublic, (*int)(nil)).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(2))
+	mock.ExpectQuery("from collection as c where c.slug = \\$1").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(2, userID, slug, true, 0, now)...))
+	mock.ExpectCommit()
+
+	resultCollection, err := collectionRepo.AddCollection(userID, newCollection)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "luchshie-sovetskie-komedii-2", resultCollection.Slug)
+}
+
+func TestShouldFailToAddCollectionWithTakenSlug(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID := 1
+	newCollection := &models.CollectionRequest{Slug: "comedies", Name: "Комедии"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("insert into collection").
+		WithArgs(&userID, &newCollection.Slug, &newCollection.Name, &newCollection.Description, &newCollection.IsPublic, (*int)(nil)).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+	mock.ExpectRollback()
+
+	resultCollection, err := collectionRepo.AddCollection(userID, newCollection)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultCollection)
+	assert.Equal(t, collection.ErrSlugAlreadyExists, err)
+}
+
+func TestShouldHidePrivateCollectionFromOtherUsers(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	slug := "comedies"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from collection as c where c.slug = \\$1").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(1, 1, slug, false, 0, time.Now())...))
+	mock.ExpectRollback()
+
+	resultCollection, err := collectionRepo.GetCollection(slug, 2)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultCollection)
+	assert.Equal(t, collection.ErrNotFound, err)
+}
+
+func TestShouldFailToReorderCollectionWithOtherFilms(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	slug := "comedies"
+	collectionID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from collection as c where c.slug = \\$1 for update").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(collectionID, 1, slug, true, 2, time.Now())...))
+	mock.ExpectQuery("select film_id from collection_film").WithArgs(&collectionID).
+		WillReturnRows(pgxmock.NewRows([]string{"film_id"}).AddRow(4).AddRow(7))
+	mock.ExpectRollback()
+
+	resultCollection, err := collectionRepo.ReorderCollection(slug, 1, &models.CollectionOrderRequest{FilmIDs: []int{7, 5}})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultCollection)
+	assert.Equal(t, collection.ErrOrderMismatch, err)
+}
+
+func TestShouldSuccessfullyReorderCollection(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	slug := "comedies"
+	collectionID := 1
+	order := &models.CollectionOrderRequest{FilmIDs: []int{7, 4}}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from collection as c where c.slug = \\$1 for update").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(collectionID, 1, slug, true, 2, time.Now())...))
+	mock.ExpectQuery("select film_id from collection_film").WithArgs(&collectionID).
+		WillReturnRows(pgxmock.NewRows([]string{"film_id"}).AddRow(4).AddRow(7))
+	mock.ExpectExec("unnest\\(\\$2::int\\[\\]\\) with ordinality").WithArgs(&collectionID, order.FilmIDs).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 2))
+	mock.ExpectExec("update collection set updated_at").WithArgs(&collectionID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("order by cf.position").WithArgs(&collectionID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"runtime", "countries", "original_language", "spoken_languages", "age_rating", "budget", "box_office",
+			"currency", "poster", "community_rating", "vote_count", "position", "note"}).
+			AddRow(7, "Иван Васильевич меняет профессию", "", "1973-09-17", 9, []string{}, 0, []string{}, "", []string{},
+				"", int64(0), int64(0), "", nil, nil, 0, 1, "").
+			AddRow(4, "Бриллиантовая рука", "", "1969-04-28", 9, []string{}, 0, []string{}, "", []string{},
+				"", int64(0), int64(0), "", nil, nil, 0, 2, "Смотреть на Новый год")).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultCollection, err := collectionRepo.ReorderCollection(slug, 1, order)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resultCollection.Films))
+	assert.Equal(t, 7, resultCollection.Films[0].ID)
+	assert.Equal(t, "Смотреть на Новый год", resultCollection.Films[1].Note)
+}
+
+func TestShouldSuccessfullyAddFilmToLockedCollection(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	slug := "comedies"
+	collectionID := 1
+	filmID := 4
+	note := &models.CollectionFilmRequest{Note: "Смотреть на Новый год"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from collection as c where c.slug = \\$1 for update").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(collectionID, 1, slug, true, 1, time.Now())...))
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectExec("insert into collection_film").WithArgs(&collectionID, &filmID, &note.Note).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("update collection set updated_at").WithArgs(&collectionID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("cf.collection_id = \\$1 and cf.film_id = \\$2").WithArgs(&collectionID, &filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"runtime", "countries", "original_language", "spoken_languages", "age_rating", "budget", "box_office",
+			"currency", "poster", "community_rating", "vote_count", "position", "note"}).
+			AddRow(4, "Бриллиантовая рука", "", "1969-04-28", 9, []string{}, 0, []string{}, "", []string{},
+				"", int64(0), int64(0), "", nil, nil, 0, 2, note.Note))
+	mock.ExpectCommit()
+
+	resultFilm, err := collectionRepo.SetCollectionFilm(slug, 1, filmID, note)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, resultFilm.Position)
+}
+
+func TestShouldForbidModifyingPublicCollectionOfAnotherUser(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	slug := "comedies"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from collection as c where c.slug = \\$1 for update").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(1, 1, slug, true, 0, time.Now())...))
+	mock.ExpectRollback()
+
+	err := collectionRepo.DeleteCollection(slug, 2)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, collection.ErrForbidden, err)
+}
+
+func TestShouldSuccessfullyForkCollection(t *testing.T) {
+	collectionRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	slug := "comedies"
+	forkSlug := "comedies-2"
+	userID, originalID, forkID := 2, 1, 5
+	name, description, isPublic := "Лучшие советские комедии", "", false
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from collection as c where c.slug = \\$1").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(originalID, 1, slug, true, 3, now)...))
+	mock.ExpectQuery("select exists").WithArgs(&slug).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectQuery("select exists").WithArgs(&forkSlug).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("insert into collection").
+		WithArgs(&userID, &forkSlug, &name, &description, &isPublic, &originalID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(forkID))
+	mock.ExpectQuery("from collection as c where c.slug = \\$1").WithArgs(&forkSlug).
+		WillReturnRows(pgxmock.NewRows(collectionColumns).AddRow(collectionRow(forkID, userID, forkSlug, false, 0, now)...))
+	mock.ExpectExec("insert into collection_film (.+) select").WithArgs(&forkID, &originalID).
+		WillReturnResult(pgxmock.NewResult("INSERT", 3))
+	mock.ExpectCommit()
+
+	resultCollection, err := collectionRepo.ForkCollection(slug, userID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, forkSlug, resultCollection.Slug)
+	assert.Equal(t, 3, resultCollection.FilmCount)
+}
@@ -0,0 +1,23 @@
+package costar
+
+import (
+	"time"
+	"vk-intern_test-case/utils/apperror"
+)
+
+var ErrPathNotFound = apperror.NotFound("actor_path_not_found", "Actors are not connected within the depth limit")
+
+// Размер страницы списка партнёров по фильмам
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Глубина поиска цепочки между актёрами - число фильмов в ней
+const (
+	DefaultMaxDepth = 6
+	MaxDepth        = 10
+)
+
+// Сколько живёт закэшированный граф актёров и фильмов, прежде чем будет перечитан из actor_film
+const AdjacencyTTL = time.Minute
@@ -0,0 +1,143 @@
+package delivery
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"vk-intern_test-case/internal/costar"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "costar:delivery:"
+
+type CostarDelivery struct {
+	costarRepo costar.CostarRepository
+}
+
+func NewCostarDelivery(cR costar.CostarRepository) *CostarDelivery {
+	return &CostarDelivery{
+		costarRepo: cR,
+	}
+}
+
+// HandleCostars handles /actors/{id}/costars
+func (cD *CostarDelivery) HandleCostars(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		cD.GetCostars(w, r)
+	}
+}
+
+// HandleActorPath handles /actors/path
+func (cD *CostarDelivery) HandleActorPath(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		cD.GetActorPath(w, r)
+	}
+}
+
+// swagger:route GET /actors/{id}/costars Actors getCostars
+// Возвращает актёров, снимавшихся вместе с этим, с числом и названиями общих фильмов.
+// Сначала - с большим числом общих фильмов, общее число партнёров - в заголовке X-Total-Count.
+// limit (по умолчанию 20, не больше 100) и offset - страница
+// responses:
+//
+//	200: []costar
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (cD *CostarDelivery) GetCostars(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetCostars:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	actorID, err := strconv.Atoi(router.PathSegments(r.URL.Path, "/actors/")[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+	filter := &models.CostarFilter{Limit: costar.DefaultPageSize}
+	err = parseIntParams(r, []intParam{
+		{"limit", &filter.Limit, 1, costar.MaxPageSize},
+		{"offset", &filter.Offset, 0, math.MaxInt32},
+	})
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	costars, total, err := cD.costarRepo.GetCostars(actorID, filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	response.WriteResponse(w, jsonEnc, http.StatusOK, costars)
+}
+
+// swagger:route GET /actors/path Actors getActorPath
+// Возвращает кратчайшую цепочку актёр→фильм→актёр от актёра from до актёра to ("степени разделения").
+// max_depth - наибольшее число фильмов в цепочке, по умолчанию 6, не больше 10
+// responses:
+//
+//	200: actorPath
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (cD *CostarDelivery) GetActorPath(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetActorPath:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	var fromID, toID int
+	maxDepth := costar.DefaultMaxDepth
+	err := parseIntParams(r, []intParam{
+		{"from", &fromID, 1, math.MaxInt32},
+		{"to", &toID, 1, math.MaxInt32},
+		{"max_depth", &maxDepth, 1, costar.MaxDepth},
+	})
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	if fromID == 0 {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage("Invalid from"))
+		return
+	}
+	if toID == 0 {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage("Invalid to"))
+		return
+	}
+
+	path, err := cD.costarRepo.GetActorPath(fromID, toID, maxDepth)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, path)
+}
+
+type intParam struct {
+	name     string
+	target   *int
+	min, max int
+}
+
+func parseIntParams(r *http.Request, params []intParam) error {
+	query := r.URL.Query()
+	for _, param := range params {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < param.min || number > param.max {
+			return apperror.ErrInvalidQuery.WithMessage("Invalid " + param.name)
+		}
+		*param.target = number
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vk-intern_test-case/internal/actor"
+	"vk-intern_test-case/internal/costar"
+	"vk-intern_test-case/internal/costar/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type costarTest struct {
+	name                string
+	method              string
+	path                string
+	beforeTest          func(mockCostarRepository *mock.MockCostarRepository)
+	expectedJSON        string
+	expectedStatusCode  int
+	expectedTotalHeader string
+}
+
+var costarsTests = []costarTest{
+	{
+		"Successfully get costars",
+		http.MethodGet,
+		"/actors/1/costars?limit=1&offset=1",
+		func(mockCostarRepository *mock.MockCostarRepository) {
+			mockCostarRepository.EXPECT().
+				GetCostars(1, &models.CostarFilter{Limit: 1, Offset: 1}).
+				Return([]models.Costar{{
+					Actor: models.Actor{ID: 3, ActorRequest: models.ActorRequest{
+						Name: "Билли Зейн", Gender: "Мужской"}},
+					FilmCount: 1,
+					Films:     []string{"Титаник"},
+				}}, 2, nil)
+		},
+		`[{
+			"id": 3,
+			"name": "Билли Зейн",
+			"gender": "Мужской",
+			"date_of_birth": "",
+			"film_count": 1,
+			"films": ["Титаник"]
+		}]`,
+		http.StatusOK,
+		"2",
+	},
+	{
+		"Fail to get costars with invalid offset",
+		http.MethodGet,
+		"/actors/1/costars?offset=-1",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid offset",
+			"instance": "/actors/1/costars",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+		"",
+	},
+	{
+		"Fail to get costars of non-existent actor",
+		http.MethodGet,
+		"/actors/1/costars",
+		func(mockCostarRepository *mock.MockCostarRepository) {
+			mockCostarRepository.EXPECT().
+				GetCostars(1, &models.CostarFilter{Limit: costar.DefaultPageSize}).
+				Return(nil, 0, actor.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Actor not found",
+			"instance": "/actors/1/costars",
+			"code": "actor_not_found"
+		}`,
+		http.StatusNotFound,
+		"",
+	},
+}
+
+var actorPathTests = []costarTest{
+	{
+		"Successfully get path between actors",
+		http.MethodGet,
+		"/actors/path?from=1&to=2&max_depth=3",
+		func(mockCostarRepository *mock.MockCostarRepository) {
+			mockCostarRepository.EXPECT().
+				GetActorPath(1, 2, 3).
+				Return(&models.ActorPath{
+					Degrees: 1,
+					Actors: []models.Actor{
+						{ID: 1, ActorRequest: models.ActorRequest{Name: "Леонардо Ди Каприо"}},
+						{ID: 2, ActorRequest: models.ActorRequest{Name: "Кейт Уинслет"}},
+					},
+					Films: []models.Film{{ID: 10, FilmRequest: models.FilmRequest{
+						Title: "Титаник", ReleaseDate: "1997-12-19", Rating: 8}}},
+				}, nil)
+		},
+		`{
+			"degrees": 1,
+			"actors": [
+				{"id": 1, "name": "Леонардо Ди Каприо", "gender": "", "date_of_birth": ""},
+				{"id": 2, "name": "Кейт Уинслет", "gender": "", "date_of_birth": ""}
+			],
+			"films": [
+				{"id": 10, "title": "Титаник", "description": "", "release_date": "1997-12-19", "rating": 8}
+			]
+		}`,
+		http.StatusOK,
+		"",
+	},
+	{
+		"Fail to get path without to",
+		http.MethodGet,
+		"/actors/path?from=1",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid to",
+			"instance": "/actors/path",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+		"",
+	},
+	{
+		"Fail to get path with too big depth",
+		http.MethodGet,
+		"/actors/path?from=1&to=2&max_depth=11",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid max_depth",
+			"instance": "/actors/path",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+		"",
+	},
+	{
+		"Fail to get path between unconnected actors",
+		http.MethodGet,
+		"/actors/path?from=1&to=2",
+		func(mockCostarRepository *mock.MockCostarRepository) {
+			mockCostarRepository.EXPECT().
+				GetActorPath(1, 2, costar.DefaultMaxDepth).
+				Return(nil, costar.ErrPathNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Actors are not connected within the depth limit",
+			"instance": "/actors/path",
+			"code": "actor_path_not_found"
+		}`,
+		http.StatusNotFound,
+		"",
+	},
+}
+
+func TestHandleCostars(t *testing.T) {
+	runCostarTests(t, costarsTests, func(costarDelivery *CostarDelivery) http.HandlerFunc {
+		return costarDelivery.HandleCostars
+	})
+}
+
+func TestHandleActorPath(t *testing.T) {
+	runCostarTests(t, actorPathTests, func(costarDelivery *CostarDelivery) http.HandlerFunc {
+		return costarDelivery.HandleActorPath
+	})
+}
+
+func runCostarTests(t *testing.T, tests []costarTest, handler func(*CostarDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCostarRepository := mock.NewMockCostarRepository(ctrl)
+			costarDeliveryTest := NewCostarDelivery(mockCostarRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockCostarRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(""))
+			assert.Nil(t, err)
+
+			handler(costarDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, test.expectedTotalHeader, result.Header.Get("X-Total-Count"))
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package costar
+
+import "sort"
+
+// Edge - участие актёра в фильме, ребро двудольного графа актёров и фильмов
+type Edge struct {
+	ActorID int
+	FilmID  int
+}
+
+// Graph - двудольный граф актёров и фильмов из actor_film
+type Graph struct {
+	actorFilms map[int][]int
+	filmActors map[int][]int
+}
+
+// step - как актёр был достигнут при поиске: от какого актёра и через какой фильм
+type step struct {
+	actorID int
+	filmID  int
+}
+
+func NewGraph(edges []Edge) *Graph {
+	graph := &Graph{
+		actorFilms: map[int][]int{},
+		filmActors: map[int][]int{},
+	}
+	for _, edge := range edges {
+		graph.actorFilms[edge.ActorID] = append(graph.actorFilms[edge.ActorID], edge.FilmID)
+		graph.filmActors[edge.FilmID] = append(graph.filmActors[edge.FilmID], edge.ActorID)
+	}
+	for _, neighbours := range []map[int][]int{graph.actorFilms, graph.filmActors} {
+		for id, ids := range neighbours {
+			neighbours[id] = uniqueSorted(ids)
+		}
+	}
+	return graph
+}
+
+// ShortestPath ищет кратчайшую цепочку актёр→фильм→актёр от from до to двунаправленным поиском
+// в ширину: фронты растут с обеих сторон, каждый раз расширяется меньший. Возвращает актёров цепочки
+// и фильмы между ними (films[i] связывает actors[i] и actors[i+1]) или false, если цепочки из не больше
+// чем maxDepth фильмов нет
+func (g *Graph) ShortestPath(from, to, maxDepth int) ([]int, []int, bool) {
+	if from == to {
+		return []int{from}, []int{}, true
+	}
+	if len(g.actorFilms[from]) == 0 || len(g.actorFilms[to]) == 0 {
+		return nil, nil, false
+	}
+
+	fromParents := map[int]step{from: {}}
+	toParents := map[int]step{to: {}}
+	fromFrontier := []int{from}
+	toFrontier := []int{to}
+	for depth := 0; depth < maxDepth && len(fromFrontier) > 0 && len(toFrontier) > 0; depth++ {
+		var meeting int
+		var found bool
+		if len(fromFrontier) <= len(toFrontier) {
+			fromFrontier, meeting, found = g.expand(fromFrontier, fromParents, toParents)
+		} else {
+			toFrontier, meeting, found = g.expand(toFrontier, toParents, fromParents)
+		}
+		if found {
+			actors, films := joinPath(meeting, fromParents, toParents)
+			return actors, films, true
+		}
+	}
+	return nil, nil, false
+}
+
+// expand проходит один уровень поиска от frontier и возвращает следующий фронт.
+// Если достигнут актёр, уже найденный с другой стороны, возвращает его
+func (g *Graph) expand(frontier []int, parents, otherParents map[int]step) ([]int, int, bool) {
+	next := []int{}
+	for _, actorID := range frontier {
+		for _, filmID := range g.actorFilms[actorID] {
+			for _, neighbourID := range g.filmActors[filmID] {
+				if _, ok := parents[neighbourID]; ok {
+					continue
+				}
+				parents[neighbourID] = step{actorID: actorID, filmID: filmID}
+				if _, ok := otherParents[neighbourID]; ok {
+					return nil, neighbourID, true
+				}
+				next = append(next, neighbourID)
+			}
+		}
+	}
+	return next, 0, false
+}
+
+// joinPath собирает цепочку из половин, найденных от from и от to до актёра meeting
+func joinPath(meeting int, fromParents, toParents map[int]step) ([]int, []int) {
+	actors := []int{meeting}
+	films := []int{}
+	for actorID := meeting; fromParents[actorID].filmID != 0; actorID = fromParents[actorID].actorID {
+		actors = append([]int{fromParents[actorID].actorID}, actors...)
+		films = append([]int{fromParents[actorID].filmID}, films...)
+	}
+	for actorID := meeting; toParents[actorID].filmID != 0; actorID = toParents[actorID].actorID {
+		actors = append(actors, toParents[actorID].actorID)
+		films = append(films, toParents[actorID].filmID)
+	}
+	return actors, films
+}
+
+func uniqueSorted(ids []int) []int {
+	sort.Ints(ids)
+	result := ids[:0]
+	for i, id := range ids {
+		if i == 0 || id != ids[i-1] {
+			result = append(result, id)
+		}
+	}
+	return result
+}
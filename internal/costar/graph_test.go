@@ -0,0 +1,55 @@
+package costar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Актёры 1-2 снимались в фильме 10, 2-3 - в 20, 3-4 - в 30, 1-4 - в 40, 5 - один в фильме 50
+var testEdges = []Edge{
+	{ActorID: 1, FilmID: 10}, {ActorID: 2, FilmID: 10},
+	{ActorID: 2, FilmID: 20}, {ActorID: 3, FilmID: 20},
+	{ActorID: 3, FilmID: 30}, {ActorID: 4, FilmID: 30},
+	{ActorID: 1, FilmID: 40}, {ActorID: 4, FilmID: 40}, {ActorID: 4, FilmID: 40},
+	{ActorID: 5, FilmID: 50},
+	{ActorID: 6, FilmID: 30},
+}
+
+func TestShortestPath(t *testing.T) {
+	graph := NewGraph(testEdges)
+
+	actors, films, ok := graph.ShortestPath(1, 3, DefaultMaxDepth)
+	assert.True(t, ok)
+	assert.Equal(t, 2, len(films))
+	assert.Equal(t, 1, actors[0])
+	assert.Equal(t, 3, actors[2])
+
+	actors, films, ok = graph.ShortestPath(2, 6, DefaultMaxDepth)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2, 3, 6}, actors)
+	assert.Equal(t, []int{20, 30}, films)
+
+	actors, films, ok = graph.ShortestPath(1, 4, DefaultMaxDepth)
+	assert.True(t, ok)
+	assert.Equal(t, []int{1, 4}, actors)
+	assert.Equal(t, []int{40}, films)
+
+	actors, films, ok = graph.ShortestPath(2, 2, DefaultMaxDepth)
+	assert.True(t, ok)
+	assert.Equal(t, []int{2}, actors)
+	assert.Empty(t, films)
+}
+
+func TestShortestPathNotFound(t *testing.T) {
+	graph := NewGraph(testEdges)
+
+	_, _, ok := graph.ShortestPath(1, 5, DefaultMaxDepth)
+	assert.False(t, ok)
+
+	_, _, ok = graph.ShortestPath(1, 7, DefaultMaxDepth)
+	assert.False(t, ok)
+
+	_, _, ok = graph.ShortestPath(2, 6, 1)
+	assert.False(t, ok)
+}
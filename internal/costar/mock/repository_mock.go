@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/costar/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCostarRepository is a mock of CostarRepository interface.
+type MockCostarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCostarRepositoryMockRecorder
+}
+
+// MockCostarRepositoryMockRecorder is the mock recorder for MockCostarRepository.
+type MockCostarRepositoryMockRecorder struct {
+	mock *MockCostarRepository
+}
+
+// NewMockCostarRepository creates a new mock instance.
+func NewMockCostarRepository(ctrl *gomock.Controller) *MockCostarRepository {
+	mock := &MockCostarRepository{ctrl: ctrl}
+	mock.recorder = &MockCostarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCostarRepository) EXPECT() *MockCostarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetActorPath mocks base method.
+func (m *MockCostarRepository) GetActorPath(fromID, toID, maxDepth int) (*models.ActorPath, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorPath", fromID, toID, maxDepth)
+	ret0, _ := ret[0].(*models.ActorPath)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorPath indicates an expected call of GetActorPath.
+func (mr *MockCostarRepositoryMockRecorder) GetActorPath(fromID, toID, maxDepth interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorPath", reflect.TypeOf((*MockCostarRepository)(nil).GetActorPath), fromID, toID, maxDepth)
+}
+
+// GetCostars mocks base method.
+func (m *MockCostarRepository) GetCostars(actorID int, filter *models.CostarFilter) ([]models.Costar, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCostars", actorID, filter)
+	ret0, _ := ret[0].([]models.Costar)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetCostars indicates an expected call of GetCostars.
+func (mr *MockCostarRepositoryMockRecorder) GetCostars(actorID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCostars", reflect.TypeOf((*MockCostarRepository)(nil).GetCostars), actorID, filter)
+}
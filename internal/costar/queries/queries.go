@@ -0,0 +1,36 @@
+package queries
+
+import (
+	actorQueries "vk-intern_test-case/internal/actor/queries"
+	filmQueries "vk-intern_test-case/internal/film/queries"
+)
+
+const GetActorIDByID = `select id from person where id = $1;`
+
+// Партнёры актёра $1 по фильмам: сначала с большим числом общих фильмов
+const (
+	GetCostars = `with costars as (
+		select af2.actor_id, count(distinct af1.film_id)::int as film_count,
+			array_agg(distinct f.title order by f.title) as films
+		from actor_film as af1
+		join actor_film as af2 on af2.film_id = af1.film_id and af2.actor_id <> af1.actor_id
+		join film as f on f.id = af1.film_id
+		where af1.actor_id = $1
+		group by af2.actor_id)
+		select ` + actorQueries.ActorColumns + `, c.film_count, c.films
+		from costars as c join person as p on p.id = c.actor_id
+		order by c.film_count desc, p.name, p.id
+		limit $2 offset $3;`
+	CountCostars = `select count(distinct af2.actor_id)
+		from actor_film as af1
+		join actor_film as af2 on af2.film_id = af1.film_id and af2.actor_id <> af1.actor_id
+		where af1.actor_id = $1;`
+)
+
+const (
+	// Рёбра графа актёров и фильмов
+	GetActorFilmEdges = `select distinct actor_id, film_id from actor_film
+		where actor_id is not null and film_id is not null;`
+	GetActorsByIDs = `select ` + actorQueries.ActorColumns + ` from person as p where p.id = any($1);`
+	GetFilmsByIDs  = `select ` + filmQueries.FilmColumns + ` from film as f where f.id = any($1);`
+)
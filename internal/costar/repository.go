@@ -0,0 +1,8 @@
+package costar
+
+import "vk-intern_test-case/models"
+
+type CostarRepository interface {
+	GetCostars(actorID int, filter *models.CostarFilter) ([]models.Costar, int, error)
+	GetActorPath(fromID, toID, maxDepth int) (*models.ActorPath, error)
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+	actorPackage "vk-intern_test-case/internal/actor"
+	actorRepository "vk-intern_test-case/internal/actor/repository"
+	costarPackage "vk-intern_test-case/internal/costar"
+	costarQueries "vk-intern_test-case/internal/costar/queries"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "costar:repository:"
+
+type CostarRepository struct {
+	pool database.PgxIface
+
+	// Граф актёров и фильмов, перечитывается из actor_film не чаще раза в costar.AdjacencyTTL
+	mu            sync.Mutex
+	graph         *costarPackage.Graph
+	graphLoadedAt time.Time
+}
+
+func NewCostarRepository(pool database.PgxIface) *CostarRepository {
+	return &CostarRepository{
+		pool: pool,
+	}
+}
+
+// GetCostars возвращает актёров, снимавшихся вместе с этим, и общее их число
+func (cR *CostarRepository) GetCostars(actorID int, filter *models.CostarFilter) ([]models.Costar, int, error) {
+	message := logMessage + "GetCostars:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Costar{}, 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var id int
+	row := tx.QueryRow(transactionCtx, costarQueries.GetActorIDByID, &actorID)
+	err = row.Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = actorPackage.ErrNotFound
+			return []models.Costar{}, 0, err
+		}
+		return []models.Costar{}, 0, err
+	}
+
+	var total int
+	row = tx.QueryRow(transactionCtx, costarQueries.CountCostars, &actorID)
+	err = row.Scan(&total)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.Costar{}, 0, err
+	}
+
+	rows, err := tx.Query(transactionCtx, costarQueries.GetCostars, &actorID, filter.Limit, filter.Offset)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.Costar{}, 0, err
+	}
+	costars := []models.Costar{}
+	for rows.Next() {
+		costar := models.Costar{}
+		actor, err := actorRepository.ScanActor(rows, &costar.FilmCount, &costar.Films)
+		if err != nil {
+			return []models.Costar{}, 0, err
+		}
+		costar.Actor = *actor
+		costars = append(costars, costar)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Costar{}, 0, err
+	}
+	return costars, total, nil
+}
+
+// GetActorPath ищет кратчайшую цепочку актёр→фильм→актёр из не больше чем maxDepth фильмов
+func (cR *CostarRepository) GetActorPath(fromID, toID, maxDepth int) (*models.ActorPath, error) {
+	message := logMessage + "GetActorPath:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := cR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	actors, err := getActors(transactionCtx, tx, []int{fromID, toID})
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	if _, ok := actors[fromID]; !ok {
+		err = actorPackage.ErrNotFound
+		return nil, err
+	}
+	if _, ok := actors[toID]; !ok {
+		err = actorPackage.ErrNotFound
+		return nil, err
+	}
+
+	graph, err := cR.adjacency(transactionCtx, tx)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	actorIDs, filmIDs, ok := graph.ShortestPath(fromID, toID, maxDepth)
+	if !ok {
+		err = costarPackage.ErrPathNotFound
+		return nil, err
+	}
+
+	if len(actorIDs) > 2 {
+		var intermediate map[int]models.Actor
+		intermediate, err = getActors(transactionCtx, tx, actorIDs[1:len(actorIDs)-1])
+		if err != nil {
+			log.Error(message + err.Error())
+			return nil, err
+		}
+		for id, actor := range intermediate {
+			actors[id] = actor
+		}
+	}
+	films, err := getFilms(transactionCtx, tx, filmIDs)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+
+	path := &models.ActorPath{Degrees: len(filmIDs), Actors: []models.Actor{}, Films: []models.Film{}}
+	for _, id := range actorIDs {
+		path.Actors = append(path.Actors, actors[id])
+	}
+	for _, id := range filmIDs {
+		path.Films = append(path.Films, films[id])
+	}
+	return path, nil
+}
+
+// adjacency возвращает закэшированный граф актёров и фильмов, перечитывая устаревший
+func (cR *CostarRepository) adjacency(ctx context.Context, tx pgx.Tx) (*costarPackage.Graph, error) {
+	cR.mu.Lock()
+	defer cR.mu.Unlock()
+	if cR.graph != nil && time.Since(cR.graphLoadedAt) < costarPackage.AdjacencyTTL {
+		return cR.graph, nil
+	}
+
+	rows, err := tx.Query(ctx, costarQueries.GetActorFilmEdges)
+	if err != nil {
+		return nil, err
+	}
+	edges := []costarPackage.Edge{}
+	for rows.Next() {
+		edge := costarPackage.Edge{}
+		if err := rows.Scan(&edge.ActorID, &edge.FilmID); err != nil {
+			return nil, err
+		}
+		edges = append(edges, edge)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	cR.graph = costarPackage.NewGraph(edges)
+	cR.graphLoadedAt = time.Now()
+	return cR.graph, nil
+}
+
+func getActors(ctx context.Context, tx pgx.Tx, actorIDs []int) (map[int]models.Actor, error) {
+	rows, err := tx.Query(ctx, costarQueries.GetActorsByIDs, actorIDs)
+	if err != nil {
+		return nil, err
+	}
+	actors := map[int]models.Actor{}
+	for rows.Next() {
+		actor, err := actorRepository.ScanActor(rows)
+		if err != nil {
+			return nil, err
+		}
+		actors[actor.ID] = *actor
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return actors, nil
+}
+
+func getFilms(ctx context.Context, tx pgx.Tx, filmIDs []int) (map[int]models.Film, error) {
+	films := map[int]models.Film{}
+	if len(filmIDs) == 0 {
+		return films, nil
+	}
+	rows, err := tx.Query(ctx, costarQueries.GetFilmsByIDs, filmIDs)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		film, err := filmRepository.ScanFilm(rows)
+		if err != nil {
+			return nil, err
+		}
+		films[film.ID] = *film
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return films, nil
+}
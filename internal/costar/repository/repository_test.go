@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"testing"
+	"vk-intern_test-case/internal/actor"
+	"vk-intern_test-case/internal/costar"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*CostarRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCostarRepo := NewCostarRepository(mock)
+	return testCostarRepo, mock
+}
+
+var actorColumns = []string{"id", "name", "gender", "date_of_birth", "photo", "date_of_death", "birthplace",
+	"biography", "aliases", "imdb_id", "kinopoisk_id"}
+
+var filmColumns = []string{"id", "title", "description", "release_date", "rating", "genres", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster",
+	"community_rating", "vote_count"}
+
+func actorRow(id int, name string, extra ...any) []any {
+	row := []any{id, name, "Мужской", nil, nil, nil, "", "", []string{}, "", 0}
+	return append(row, extra...)
+}
+
+func filmRow(id int, title string) []any {
+	return []any{id, title, "", "1997-12-19", 8, []string{}, 0, []string{}, "", []string{}, "",
+		int64(0), int64(0), "", nil, nil, 0}
+}
+
+func TestShouldSuccessfullyGetCostars(t *testing.T) {
+	costarRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from person").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(actorID))
+	mock.ExpectQuery("select count").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(3))
+	mock.ExpectQuery("with costars as").WithArgs(&actorID, 2, 0).
+		WillReturnRows(pgxmock.NewRows(append(actorColumns, "film_count", "films")).
+			AddRow(actorRow(2, "Кейт Уинслет", 2, []string{"Дорога перемен", "Титаник"})...).
+			AddRow(actorRow(3, "Билли Зейн", 1, []string{"Титаник"})...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	costars, total, err := costarRepo.GetCostars(actorID, &models.CostarFilter{Limit: 2})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, total)
+	assert.Equal(t, 2, len(costars))
+	assert.Equal(t, 2, costars[0].FilmCount)
+	assert.Equal(t, []string{"Дорога перемен", "Титаник"}, costars[0].Films)
+}
+
+func TestShouldFailToGetCostarsOfNonExistentActor(t *testing.T) {
+	costarRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from person").WithArgs(&actorID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	costars, _, err := costarRepo.GetCostars(actorID, &models.CostarFilter{Limit: 20})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Empty(t, costars)
+	assert.Equal(t, actor.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyGetActorPathWithCachedGraph(t *testing.T) {
+	costarRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("where p.id = any").WithArgs([]int{1, 3}).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(1, "Леонардо Ди Каприо")...).
+			AddRow(actorRow(3, "Мэтт Дэймон")...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("select distinct actor_id, film_id from actor_film").
+		WillReturnRows(pgxmock.NewRows([]string{"actor_id", "film_id"}).
+			AddRow(1, 10).AddRow(2, 10).AddRow(2, 20).AddRow(3, 20)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("where p.id = any").WithArgs([]int{2}).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(2, "Джек Николсон")...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("where f.id = any").WithArgs([]int{10, 20}).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(20, "Второй фильм")...).
+			AddRow(filmRow(10, "Первый фильм")...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	// Второй запрос берёт граф из кэша
+	mock.ExpectBegin()
+	mock.ExpectQuery("where p.id = any").WithArgs([]int{1, 2}).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(1, "Леонардо Ди Каприо")...).
+			AddRow(actorRow(2, "Джек Николсон")...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("where f.id = any").WithArgs([]int{10}).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(10, "Первый фильм")...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	path, err := costarRepo.GetActorPath(1, 3, costar.DefaultMaxDepth)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, path.Degrees)
+	assert.Equal(t, []string{"Леонардо Ди Каприо", "Джек Николсон", "Мэтт Дэймон"},
+		[]string{path.Actors[0].Name, path.Actors[1].Name, path.Actors[2].Name})
+	assert.Equal(t, 10, path.Films[0].ID)
+	assert.Equal(t, 20, path.Films[1].ID)
+
+	path, err = costarRepo.GetActorPath(1, 2, costar.DefaultMaxDepth)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, path.Degrees)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestShouldFailToFindPathBetweenUnconnectedActors(t *testing.T) {
+	costarRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("where p.id = any").WithArgs([]int{1, 3}).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(1, "Леонардо Ди Каприо")...).
+			AddRow(actorRow(3, "Мэтт Дэймон")...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("select distinct actor_id, film_id from actor_film").
+		WillReturnRows(pgxmock.NewRows([]string{"actor_id", "film_id"}).
+			AddRow(1, 10).AddRow(3, 20)).
+		RowsWillBeClosed()
+	mock.ExpectRollback()
+
+	path, err := costarRepo.GetActorPath(1, 3, costar.DefaultMaxDepth)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, path)
+	assert.Equal(t, costar.ErrPathNotFound, err)
+}
+
+func TestShouldFailToFindPathFromNonExistentActor(t *testing.T) {
+	costarRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("where p.id = any").WithArgs([]int{1, 3}).
+		WillReturnRows(pgxmock.NewRows(actorColumns).
+			AddRow(actorRow(3, "Мэтт Дэймон")...)).
+		RowsWillBeClosed()
+	mock.ExpectRollback()
+
+	path, err := costarRepo.GetActorPath(1, 3, costar.DefaultMaxDepth)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, path)
+	assert.Equal(t, actor.ErrNotFound, err)
+}
@@ -0,0 +1,212 @@
+package delivery
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+	"vk-intern_test-case/internal/feed"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	feedFormat "vk-intern_test-case/utils/feed"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "feed:delivery:"
+
+// Постоянные идентификаторы лент и записей (RFC 4151), не зависят от адреса сервера
+const tagPrefix = "tag:vk-intern,2024:"
+
+type FeedDelivery struct {
+	feedRepo feed.FeedRepository
+}
+
+func NewFeedDelivery(fR feed.FeedRepository) *FeedDelivery {
+	return &FeedDelivery{
+		feedRepo: fR,
+	}
+}
+
+// HandleFeeds handles /feeds/films.{atom,rss} and /feeds/actors/{id}.{atom,rss}
+func (fD *FeedDelivery) HandleFeeds(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		segments := router.PathSegments(r.URL.Path, "/feeds/")
+		switch {
+		case len(segments) == 1 && strings.TrimSuffix(segments[0], path.Ext(segments[0])) == "films":
+			fD.GetFilmsFeed(w, r)
+		case len(segments) == 2 && segments[0] == "actors":
+			fD.GetActorFeed(w, r)
+		default:
+			response.WriteError(w, r, feed.ErrNotFound)
+		}
+	}
+}
+
+// swagger:route GET /feeds/films.atom Feeds getFilmsFeed
+// Лента последних добавленных в каталог фильмов в формате Atom, /feeds/films.rss - та же лента в RSS 2.0.
+// Поддерживает условные запросы: If-None-Match с ETag ответа
+// produces:
+// - application/atom+xml
+// - application/rss+xml
+// responses:
+//
+//	200: feed
+//	304: notModified
+//	404: problemResponse
+//	500: problemResponse
+func (fD *FeedDelivery) GetFilmsFeed(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetFilmsFeed:"
+	log.Debug(message + "started")
+	format, err := feedFormatOf(r.URL.Path)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	films, err := fD.feedRepo.GetRecentFilms(feed.FeedSize)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	base := baseURL(r)
+	result := &feedFormat.Feed{
+		ID:       tagPrefix + "films",
+		Title:    "Новые фильмы",
+		Link:     base + "/films",
+		SelfLink: base + r.URL.Path,
+		Updated:  latestUpdate(films),
+		Entries:  filmEntries(base, films),
+	}
+	writeFeed(w, r, format, result)
+}
+
+// swagger:route GET /feeds/actors/{id}.atom Feeds getActorFeed
+// Лента фильмов, последними появившихся в фильмографии актёра, в формате Atom,
+// /feeds/actors/{id}.rss - та же лента в RSS 2.0. Поддерживает условные запросы, как и GET /feeds/films.atom
+// produces:
+// - application/atom+xml
+// - application/rss+xml
+// responses:
+//
+//	200: feed
+//	304: notModified
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (fD *FeedDelivery) GetActorFeed(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetActorFeed:"
+	log.Debug(message + "started")
+	format, err := feedFormatOf(r.URL.Path)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	segment := router.PathSegments(r.URL.Path, "/feeds/actors/")[0]
+	actorID, err := strconv.Atoi(strings.TrimSuffix(segment, path.Ext(segment)))
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	actorFeed, err := fD.feedRepo.GetActorFeed(actorID, feed.FeedSize)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	base := baseURL(r)
+	result := &feedFormat.Feed{
+		ID:       fmt.Sprintf("%sactor:%d:films", tagPrefix, actorFeed.ID),
+		Title:    "Новые фильмы с участием " + actorFeed.Name,
+		Link:     fmt.Sprintf("%s/actors/%d", base, actorFeed.ID),
+		SelfLink: base + r.URL.Path,
+		Updated:  latestUpdate(actorFeed.Films),
+		Entries:  filmEntries(base, actorFeed.Films),
+	}
+	// Переименование актёра меняет заголовок ленты
+	if actorFeed.UpdatedAt.After(result.Updated) {
+		result.Updated = actorFeed.UpdatedAt
+	}
+	writeFeed(w, r, format, result)
+}
+
+// feedFormatOf returns format of the feed by the extension of the last path segment
+func feedFormatOf(urlPath string) (string, error) {
+	switch format := strings.TrimPrefix(path.Ext(urlPath), "."); format {
+	case feed.FormatAtom, feed.FormatRSS:
+		return format, nil
+	default:
+		return "", feed.ErrNotFound
+	}
+}
+
+// baseURL returns scheme and host the client used to reach the server
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	// За обратным прокси TLS завершается на нём
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
+
+func filmEntries(base string, films []models.FeedFilm) []feedFormat.Entry {
+	entries := []feedFormat.Entry{}
+	for _, film := range films {
+		title := film.Title
+		if len(film.ReleaseDate) >= 4 {
+			title = fmt.Sprintf("%s (%s)", film.Title, film.ReleaseDate[:4])
+		}
+		entries = append(entries, feedFormat.Entry{
+			ID:        fmt.Sprintf("%sfilm:%d", tagPrefix, film.ID),
+			Title:     title,
+			Link:      fmt.Sprintf("%s/films/%d", base, film.ID),
+			Summary:   film.Description,
+			Published: film.AddedAt,
+			Updated:   film.UpdatedAt,
+		})
+	}
+	return entries
+}
+
+// latestUpdate returns the time the newest of films was updated
+func latestUpdate(films []models.FeedFilm) time.Time {
+	updated := time.Time{}
+	for _, film := range films {
+		if film.UpdatedAt.After(updated) {
+			updated = film.UpdatedAt
+		}
+	}
+	return updated
+}
+
+// writeFeed encodes feed and writes it, answering 304 Not Modified to conditional requests
+// for the unchanged feed. ETag is the hash of the body. Last-Modified is not sent: feed update time
+// moves backwards when the newest film is deleted, so If-Modified-Since could hide a changed feed
+func writeFeed(w http.ResponseWriter, r *http.Request, format string, result *feedFormat.Feed) {
+	encode, contentType := feedFormat.Atom, feedFormat.AtomContentType
+	if format == feed.FormatRSS {
+		encode, contentType = feedFormat.RSS, feedFormat.RSSContentType
+	}
+	body, err := encode(result)
+	if err != nil {
+		log.Error(logMessage + "writeFeed:" + err.Error())
+		response.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sha256.Sum256(body)))
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(body))
+}
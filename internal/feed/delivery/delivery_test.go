@@ -0,0 +1,221 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/actor"
+	"vk-intern_test-case/internal/feed/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/feed"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+var (
+	filmAdded   = time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+	filmUpdated = time.Date(2024, time.March, 19, 9, 30, 0, 0, time.UTC)
+)
+
+var recentFilms = []models.FeedFilm{{
+	Film: models.Film{ID: 2, FilmRequest: models.FilmRequest{
+		Title: "Дюна: Часть вторая", Description: "Пол Атрейдес", ReleaseDate: "2024-02-29"}},
+	AddedAt:   filmAdded,
+	UpdatedAt: filmUpdated,
+}}
+
+type feedTest struct {
+	name                string
+	path                string
+	headers             map[string]string
+	beforeTest          func(mockFeedRepository *mock.MockFeedRepository)
+	expectedBody        []string
+	expectedContentType string
+	expectedStatusCode  int
+}
+
+var feedTests = []feedTest{
+	{
+		"Successfully get films feed in Atom",
+		"/feeds/films.atom",
+		nil,
+		func(mockFeedRepository *mock.MockFeedRepository) {
+			mockFeedRepository.EXPECT().
+				GetRecentFilms(50).
+				Return(recentFilms, nil)
+		},
+		[]string{
+			"<id>tag:vk-intern,2024:films</id>",
+			"<updated>2024-03-19T09:30:00Z</updated>",
+			`<link href="http://example.com/feeds/films.atom" rel="self" type="application/atom+xml"></link>`,
+			"<title>Дюна: Часть вторая (2024)</title>",
+			`<link href="http://example.com/films/2" rel="alternate"></link>`,
+			"<published>2024-03-18T12:00:00Z</published>",
+		},
+		feed.AtomContentType,
+		http.StatusOK,
+	},
+	{
+		"Successfully get films feed in RSS",
+		"/feeds/films.rss",
+		map[string]string{"X-Forwarded-Proto": "https"},
+		func(mockFeedRepository *mock.MockFeedRepository) {
+			mockFeedRepository.EXPECT().
+				GetRecentFilms(50).
+				Return(recentFilms, nil)
+		},
+		[]string{
+			`<rss version="2.0"`,
+			"<link>https://example.com/films/2</link>",
+			`<guid isPermaLink="false">tag:vk-intern,2024:film:2</guid>`,
+			"<pubDate>Mon, 18 Mar 2024 12:00:00 +0000</pubDate>",
+		},
+		feed.RSSContentType,
+		http.StatusOK,
+	},
+	{
+		"If-Modified-Since does not hide changes of the films feed",
+		"/feeds/films.atom",
+		map[string]string{"If-Modified-Since": filmUpdated.Add(time.Hour).Format(http.TimeFormat)},
+		func(mockFeedRepository *mock.MockFeedRepository) {
+			mockFeedRepository.EXPECT().
+				GetRecentFilms(50).
+				Return(recentFilms, nil)
+		},
+		[]string{"<title>Дюна: Часть вторая (2024)</title>"},
+		feed.AtomContentType,
+		http.StatusOK,
+	},
+	{
+		"Successfully get actor feed",
+		"/feeds/actors/1.atom",
+		nil,
+		func(mockFeedRepository *mock.MockFeedRepository) {
+			mockFeedRepository.EXPECT().
+				GetActorFeed(1, 50).
+				Return(&models.ActorFeed{
+					Actor:     models.Actor{ID: 1, ActorRequest: models.ActorRequest{Name: "Тимоти Шаламе"}},
+					UpdatedAt: time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC),
+					Films:     recentFilms,
+				}, nil)
+		},
+		[]string{
+			"<id>tag:vk-intern,2024:actor:1:films</id>",
+			"<title>Новые фильмы с участием Тимоти Шаламе</title>",
+			"<updated>2024-03-20T00:00:00Z</updated>",
+			`<link href="http://example.com/actors/1" rel="alternate"></link>`,
+		},
+		feed.AtomContentType,
+		http.StatusOK,
+	},
+	{
+		"Fail to get feed of non-existent actor",
+		"/feeds/actors/1.rss",
+		nil,
+		func(mockFeedRepository *mock.MockFeedRepository) {
+			mockFeedRepository.EXPECT().
+				GetActorFeed(1, 50).
+				Return(nil, actor.ErrNotFound)
+		},
+		[]string{`"code":"actor_not_found"`},
+		response.ProblemContentType,
+		http.StatusNotFound,
+	},
+	{
+		"Fail to get actor feed with invalid id",
+		"/feeds/actors/abc.atom",
+		nil,
+		nil,
+		[]string{`"code":"invalid_id"`},
+		response.ProblemContentType,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get feed in unknown format",
+		"/feeds/films.json",
+		nil,
+		nil,
+		[]string{`"code":"feed_not_found"`},
+		response.ProblemContentType,
+		http.StatusNotFound,
+	},
+	{
+		"Fail to get unknown feed",
+		"/feeds/reviews.atom",
+		nil,
+		nil,
+		[]string{`"code":"feed_not_found"`},
+		response.ProblemContentType,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleFeeds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range feedTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockFeedRepository := mock.NewMockFeedRepository(ctrl)
+			feedDeliveryTest := NewFeedDelivery(mockFeedRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockFeedRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request := httptest.NewRequest(http.MethodGet, test.path, strings.NewReader(""))
+			for key, value := range test.headers {
+				request.Header.Set(key, value)
+			}
+
+			feedDeliveryTest.HandleFeeds(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			if test.expectedStatusCode != http.StatusNotModified {
+				assert.Equal(t, test.expectedContentType, result.Header.Get("Content-Type"))
+			}
+			for _, expected := range test.expectedBody {
+				assert.Contains(t, string(data), expected)
+			}
+		})
+	}
+}
+
+func TestFeedNotModifiedWithMatchingETag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFeedRepository := mock.NewMockFeedRepository(ctrl)
+	feedDeliveryTest := NewFeedDelivery(mockFeedRepository)
+	mockFeedRepository.EXPECT().
+		GetRecentFilms(50).
+		Return(recentFilms, nil).
+		Times(2)
+
+	responseRecorder := prepareTestEnvironment()
+	feedDeliveryTest.HandleFeeds(responseRecorder, httptest.NewRequest(http.MethodGet, "/feeds/films.rss", nil))
+	etag := responseRecorder.Header().Get("ETag")
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.NotEmpty(t, etag)
+	assert.Empty(t, responseRecorder.Header().Get("Last-Modified"))
+
+	request := httptest.NewRequest(http.MethodGet, "/feeds/films.rss", nil)
+	request.Header.Set("If-None-Match", etag)
+	responseRecorder = prepareTestEnvironment()
+	feedDeliveryTest.HandleFeeds(responseRecorder, request)
+
+	assert.Equal(t, http.StatusNotModified, responseRecorder.Code)
+	assert.Empty(t, responseRecorder.Body.String())
+}
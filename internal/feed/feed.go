@@ -0,0 +1,14 @@
+package feed
+
+import "vk-intern_test-case/utils/apperror"
+
+var ErrNotFound = apperror.NotFound("feed_not_found", "Feed not found")
+
+// Число записей в ленте
+const FeedSize = 50
+
+// Форматы лент - расширение последнего сегмента пути /feeds/...
+const (
+	FormatAtom = "atom"
+	FormatRSS  = "rss"
+)
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/feed/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFeedRepository is a mock of FeedRepository interface.
+type MockFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepositoryMockRecorder
+}
+
+// MockFeedRepositoryMockRecorder is the mock recorder for MockFeedRepository.
+type MockFeedRepositoryMockRecorder struct {
+	mock *MockFeedRepository
+}
+
+// NewMockFeedRepository creates a new mock instance.
+func NewMockFeedRepository(ctrl *gomock.Controller) *MockFeedRepository {
+	mock := &MockFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepository) EXPECT() *MockFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetActorFeed mocks base method.
+func (m *MockFeedRepository) GetActorFeed(actorID, limit int) (*models.ActorFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActorFeed", actorID, limit)
+	ret0, _ := ret[0].(*models.ActorFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActorFeed indicates an expected call of GetActorFeed.
+func (mr *MockFeedRepositoryMockRecorder) GetActorFeed(actorID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActorFeed", reflect.TypeOf((*MockFeedRepository)(nil).GetActorFeed), actorID, limit)
+}
+
+// GetRecentFilms mocks base method.
+func (m *MockFeedRepository) GetRecentFilms(limit int) ([]models.FeedFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentFilms", limit)
+	ret0, _ := ret[0].([]models.FeedFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentFilms indicates an expected call of GetRecentFilms.
+func (mr *MockFeedRepositoryMockRecorder) GetRecentFilms(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentFilms", reflect.TypeOf((*MockFeedRepository)(nil).GetRecentFilms), limit)
+}
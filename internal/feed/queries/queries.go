@@ -0,0 +1,22 @@
+package queries
+
+import (
+	actorQueries "vk-intern_test-case/internal/actor/queries"
+	filmQueries "vk-intern_test-case/internal/film/queries"
+)
+
+// Последние добавленные в каталог фильмы
+const GetRecentFilms = `select ` + filmQueries.FilmColumns + `, f.created_at, f.updated_at
+	from film as f
+	order by f.created_at desc, f.id desc
+	limit $1;`
+
+const GetActorByID = `select ` + actorQueries.ActorColumns + `, p.updated_at from person as p where p.id = $1;`
+
+// Фильмы актёра $1 в порядке появления в его фильмографии. Запись меняется и при изменении фильма
+const GetActorFilms = `select ` + filmQueries.FilmColumns + `, af.added_at, greatest(f.updated_at, af.added_at)
+	from (select film_id, min(created_at) as added_at from actor_film
+		where actor_id = $1 group by film_id) as af
+	join film as f on f.id = af.film_id
+	order by af.added_at desc, f.id desc
+	limit $2;`
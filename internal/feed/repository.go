@@ -0,0 +1,8 @@
+package feed
+
+import "vk-intern_test-case/models"
+
+type FeedRepository interface {
+	GetRecentFilms(limit int) ([]models.FeedFilm, error)
+	GetActorFeed(actorID, limit int) (*models.ActorFeed, error)
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	actorPackage "vk-intern_test-case/internal/actor"
+	actorRepository "vk-intern_test-case/internal/actor/repository"
+	feedQueries "vk-intern_test-case/internal/feed/queries"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "feed:repository:"
+
+type FeedRepository struct {
+	pool database.PgxIface
+}
+
+func NewFeedRepository(pool database.PgxIface) *FeedRepository {
+	return &FeedRepository{
+		pool: pool,
+	}
+}
+
+// GetRecentFilms возвращает последние добавленные в каталог фильмы
+func (fR *FeedRepository) GetRecentFilms(limit int) ([]models.FeedFilm, error) {
+	message := logMessage + "GetRecentFilms:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.FeedFilm{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	rows, err := tx.Query(transactionCtx, feedQueries.GetRecentFilms, limit)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.FeedFilm{}, err
+	}
+	films, err := scanFeedFilms(rows)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.FeedFilm{}, err
+	}
+	return films, nil
+}
+
+// GetActorFeed возвращает актёра и фильмы, последними появившиеся в его фильмографии
+func (fR *FeedRepository) GetActorFeed(actorID, limit int) (*models.ActorFeed, error) {
+	message := logMessage + "GetActorFeed:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	actorFeed := &models.ActorFeed{}
+	row := tx.QueryRow(transactionCtx, feedQueries.GetActorByID, &actorID)
+	actor, err := actorRepository.ScanActor(row, &actorFeed.UpdatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = actorPackage.ErrNotFound
+			return nil, err
+		}
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	actorFeed.Actor = *actor
+
+	rows, err := tx.Query(transactionCtx, feedQueries.GetActorFilms, &actorID, limit)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	actorFeed.Films, err = scanFeedFilms(rows)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	return actorFeed, nil
+}
+
+// scanFeedFilms reads films followed by the time they were added and updated
+func scanFeedFilms(rows pgx.Rows) ([]models.FeedFilm, error) {
+	defer rows.Close()
+	films := []models.FeedFilm{}
+	for rows.Next() {
+		feedFilm := models.FeedFilm{}
+		film, err := filmRepository.ScanFilm(rows, &feedFilm.AddedAt, &feedFilm.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		feedFilm.Film = *film
+		films = append(films, feedFilm)
+	}
+	return films, rows.Err()
+}
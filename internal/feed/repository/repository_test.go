@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/actor"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*FeedRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFeedRepo := NewFeedRepository(mock)
+	return testFeedRepo, mock
+}
+
+var actorColumns = []string{"id", "name", "gender", "date_of_birth", "photo", "date_of_death", "birthplace",
+	"biography", "aliases", "imdb_id", "kinopoisk_id"}
+
+var filmColumns = []string{"id", "title", "description", "release_date", "rating", "genres", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster",
+	"community_rating", "vote_count"}
+
+func actorRow(id int, name string, extra ...any) []any {
+	row := []any{id, name, "Мужской", nil, nil, nil, "", "", []string{}, "", 0}
+	return append(row, extra...)
+}
+
+func filmRow(id int, title string, extra ...any) []any {
+	row := []any{id, title, "", "1997-12-19", 8, []string{}, 0, []string{}, "", []string{}, "",
+		int64(0), int64(0), "", nil, nil, 0}
+	return append(row, extra...)
+}
+
+func TestShouldSuccessfullyGetRecentFilms(t *testing.T) {
+	feedRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	added := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+	updated := added.Add(time.Hour)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("order by f.created_at desc").WithArgs(2).
+		WillReturnRows(pgxmock.NewRows(append(filmColumns, "created_at", "updated_at")).
+			AddRow(filmRow(2, "Дюна", added, updated)...).
+			AddRow(filmRow(1, "Титаник", added.Add(-time.Hour), added.Add(-time.Hour))...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	films, err := feedRepo.GetRecentFilms(2)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(films))
+	assert.Equal(t, "Дюна", films[0].Title)
+	assert.Equal(t, added, films[0].AddedAt)
+	assert.Equal(t, updated, films[0].UpdatedAt)
+}
+
+func TestShouldSuccessfullyGetActorFeed(t *testing.T) {
+	feedRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 1
+	actorUpdated := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)
+	added := time.Date(2024, time.March, 18, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("p.updated_at from person").WithArgs(&actorID).
+		WillReturnRows(pgxmock.NewRows(append(actorColumns, "updated_at")).
+			AddRow(actorRow(actorID, "Леонардо Ди Каприо", actorUpdated)...))
+	mock.ExpectQuery("from actor_film").WithArgs(&actorID, 50).
+		WillReturnRows(pgxmock.NewRows(append(filmColumns, "added_at", "updated_at")).
+			AddRow(filmRow(1, "Титаник", added, added)...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	actorFeed, err := feedRepo.GetActorFeed(actorID, 50)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Леонардо Ди Каприо", actorFeed.Name)
+	assert.Equal(t, actorUpdated, actorFeed.UpdatedAt)
+	assert.Equal(t, 1, len(actorFeed.Films))
+	assert.Equal(t, added, actorFeed.Films[0].AddedAt)
+}
+
+func TestShouldFailToGetFeedOfNonExistentActor(t *testing.T) {
+	feedRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actorID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("p.updated_at from person").WithArgs(&actorID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	actorFeed, err := feedRepo.GetActorFeed(actorID, 50)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, actorFeed)
+	assert.Equal(t, actor.ErrNotFound, err)
+}
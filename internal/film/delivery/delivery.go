@@ -2,12 +2,25 @@ package delivery
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/middleware"
 	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/ical"
+	"vk-intern_test-case/utils/images"
+	"vk-intern_test-case/utils/language"
+	"vk-intern_test-case/utils/patch"
 	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/storage"
+	"vk-intern_test-case/utils/validation"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -16,11 +29,16 @@ const logMessage = "film:delivery:"
 
 type FilmDelivery struct {
 	filmRepo film.FilmRepository
+	storage  storage.Storage
+	// Текущее время, подменяется в тестах
+	now func() time.Time
 }
 
-func NewFilmDelivery(fR film.FilmRepository) *FilmDelivery {
+func NewFilmDelivery(fR film.FilmRepository, store storage.Storage) *FilmDelivery {
 	return &FilmDelivery{
 		filmRepo: fR,
+		storage:  store,
+		now:      time.Now,
 	}
 }
 
@@ -34,37 +52,78 @@ func (fD *FilmDelivery) HandleFilms(w http.ResponseWriter, r *http.Request) {
 		fD.AddFilm(w, r)
 	case http.MethodPut:
 		fD.UpdateFilm(w, r)
+	case http.MethodPatch:
+		fD.PatchFilm(w, r)
 	case http.MethodDelete:
 		fD.DeleteFilm(w, r)
 	}
 }
 
+// HandleFilmTranslations handles /films/{id}/translations and /films/{id}/translations/{lang}
+func (fD *FilmDelivery) HandleFilmTranslations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		fD.GetFilmTranslations(w, r)
+	case http.MethodPut:
+		fD.SetFilmTranslation(w, r)
+	case http.MethodDelete:
+		fD.DeleteFilmTranslation(w, r)
+	}
+}
+
+// HandleFilmPoster handles /films/{id}/poster
+func (fD *FilmDelivery) HandleFilmPoster(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		fD.UploadPoster(w, r)
+	}
+}
+
 // swagger:route POST /films Films addFilm
 // Добавляет новый фильм в систему, совместно со списком актёров. 
-// Если актёра нет в базе - он пропускается и не записывается.
 // Актёр добавляется заранее. Поиск происходит по имени.
+// Что делать с актёрами, которых нет в базе, задаётся параметром actor_mode:
+// skip - актёр пропускается, его имя возвращается в warnings (по умолчанию);
+// strict - фильм не добавляется, возвращается список неизвестных актёров;
+// create - актёр создаётся как заглушка в той же транзакции, без пола и даты рождения.
+// При ошибках валидации полей также возвращается 422 со списком ошибок.
 // security:
 // - key:
 // responses:
 //
-//	200: basicResponse
-//  401: basicResponse
+//	200: addedFilm
+//	400: problemResponse
+//  401: problemResponse
+//	422: problemResponse
 func (fD *FilmDelivery) AddFilm(w http.ResponseWriter, r *http.Request) {
 	message := logMessage + "AddFilm:"
 	log.Debug(message + "started")
 	jsonEnc := response.MakeJsonEncoder(w)
-	var film models.FilmWithActors
-	err := json.NewDecoder(r.Body).Decode(&film)
+	actorMode := r.URL.Query().Get("actor_mode")
+	if actorMode == "" {
+		actorMode = film.ActorModeSkip
+	}
+	if !film.IsValidActorMode(actorMode) {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage("Unknown actor_mode"))
+		return
+	}
+
+	var newFilm models.FilmWithActors
+	err := json.NewDecoder(r.Body).Decode(&newFilm)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateFilmWithActors(&newFilm).Err()
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
 
-	resultFilm, err := fD.filmRepo.AddFilm(&film)
+	resultFilm, err := fD.filmRepo.AddFilm(&newFilm, actorMode)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
 	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilm)
@@ -72,87 +131,243 @@ func (fD *FilmDelivery) AddFilm(w http.ResponseWriter, r *http.Request) {
 
 // swagger:route PUT /films/{id} Films updateFilm
 // Обновляет информацию о фильме, на вход полный поступает вся информация о фильме.
+// Возвращает фильм после изменения.
 // security:
 // - key:
 // responses:
 //
-//	200: basicResponse
-//	400: basicResponse
-//  401: basicResponse
-//	500: basicResponse
+//	200: film
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
 func (fD *FilmDelivery) UpdateFilm(w http.ResponseWriter, r *http.Request) {
 	jsonEnc := response.MakeJsonEncoder(w)
 	var film models.Film
 	id := strings.TrimPrefix(r.URL.Path, "/films/")
 	filmID, err := strconv.Atoi(id)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidID)
 		return
 	}
 
 	err = json.NewDecoder(r.Body).Decode(&film)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
 		return
 	}
-	err = fD.filmRepo.UpdateFilm(filmID, &film)
+	err = validation.ValidateFilm(&film.FilmRequest).Err()
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
 
-	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+	resultFilm, err := fD.filmRepo.UpdateFilm(filmID, &film)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilm)
+}
+
+// swagger:route PATCH /films/{id} Films patchFilm
+// Частично обновляет информацию о фильме. Обновляются только переданные поля.
+// Принимает JSON Merge Patch (RFC 7396) с Content-Type application/merge-patch+json или application/json
+// и JSON Patch (RFC 6902) с Content-Type application/json-patch+json.
+// Возвращает фильм после изменения.
+// consumes:
+// - application/merge-patch+json
+// - application/json-patch+json
+// security:
+// - key:
+// responses:
+//
+//	200: film
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	415: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) PatchFilm(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "PatchFilm:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	patchBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	// Патч применяется к фильму, заблокированному в транзакции изменения
+	resultFilm, err := fD.filmRepo.PatchFilm(filmID, func(currentFilm *models.FilmRequest) (map[string]any, error) {
+		var patchedFilm models.FilmRequest
+		err := patch.ApplyTo(r.Header.Get("Content-Type"), currentFilm, patchBody, &patchedFilm)
+		if err != nil {
+			return nil, err
+		}
+		err = validation.ValidateFilm(&patchedFilm).Err()
+		if err != nil {
+			return nil, err
+		}
+		return patch.ChangedFields(currentFilm, &patchedFilm), nil
+	})
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilm)
+}
+
+// swagger:route POST /films/{id}/poster Films uploadFilmPoster
+// Загружает постер фильма (JPEG, PNG или WebP) в поле file multipart-формы.
+// Метаданные изображения удаляются, создаются миниатюры шириной 160, 320 и 640 пикселей.
+// Предыдущий постер заменяется. Возвращает ссылки на изображения
+// consumes:
+// - multipart/form-data
+// security:
+// - key:
+// responses:
+//
+//	200: image
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	415: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) UploadPoster(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "UploadPoster:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	data, err := images.ReadUpload(w, r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	processed, err := images.Process(data)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	_, err = fD.filmRepo.GetFilm(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	version, err := images.Save(fD.storage, fmt.Sprintf("films/%d/poster", filmID), processed)
+	if err != nil {
+		log.Error(message + err.Error())
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = fD.filmRepo.SetFilmPoster(filmID, version.Image)
+	if err != nil {
+		if err := version.Discard(); err != nil {
+			log.Error(message + err.Error())
+		}
+		response.WriteError(w, r, err)
+		return
+	}
+	// Старые версии удаляются только после того, как в базе записана новая
+	if err := version.Commit(); err != nil {
+		log.Error(message + err.Error())
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, version.Image)
 }
 
 // swagger:route DELETE /films/{id} Films deleteFilm
-// Удаляет фильм из системы
+// Удаляет фильм из системы вместе с загруженными изображениями
 // security:
 // - key:
 // responses:
 //
 //	200: basicResponse
-//	400: basicResponse
-//  401: basicResponse
-//	500: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
 func (fD *FilmDelivery) DeleteFilm(w http.ResponseWriter, r *http.Request) {
 	jsonEnc := response.MakeJsonEncoder(w)
 	id := strings.TrimPrefix(r.URL.Path, "/films/")
 	filmID, err := strconv.Atoi(id)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, apperror.ErrInvalidID)
 		return
 	}
 	err = fD.filmRepo.DeleteFilm(filmID)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusBadRequest, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
 
+	err = fD.storage.DeleteAll(fmt.Sprintf("films/%d", filmID))
+	if err != nil {
+		log.Error(logMessage + "DeleteFilm:" + err.Error())
+	}
+
 	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
 }
 
 // swagger:route GET /films Films getFilms
-// Возвращает список фильмов. Можно указать поле для сортировки, по умолчанию - по рейтингу
+// Возвращает список фильмов. Можно указать поле для сортировки, по умолчанию - по рейтингу.
+// sort_by=community_rating - по байесовскому среднему оценок пользователей.
+// Фильмы можно отфильтровать по жанрам: genre=Драма&genre=Комедия или genre=Драма,Комедия.
+// genre_mode=any - хотя бы один из жанров (по умолчанию), genre_mode=all - все жанры сразу.
+// director - фильтр по фрагменту имени режиссёра.
+// Название и описание возвращаются на языке из параметра lang или заголовка Accept-Language,
+// если перевода нет - на языке оригинала.
+// max_age_rating=12+ - фильмы с возрастным рейтингом не выше указанного,
+// country=RU - снятые хотя бы в одной из стран, language=en - с языком оригинала или озвучки,
+// min_runtime и max_runtime - границы продолжительности в минутах,
+// actor_min_age и actor_max_age - границы возраста хотя бы одного актёра на дату выхода фильма.
+// С заголовком Authorization у фильмов есть отметки in_watchlist и watched из списков пользователя
 // responses:
 //
 //	200: []film
-//	500: basicResponse
+//	400: problemResponse
+//	500: problemResponse
 func (fD *FilmDelivery) GetFilms(w http.ResponseWriter, r *http.Request) {
 	message := logMessage + "GetFilms:"
 	log.Debug(message + "started")
 	jsonEnc := response.MakeJsonEncoder(w)
 	sortBy := r.URL.Query().Get("sort_by")
+	filter, err := parseFilmFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
 
-	resultFilms, err := fD.filmRepo.GetFilmsSorted(sortBy)
+	resultFilms, err := fD.filmRepo.GetFilmsSorted(sortBy, filter)
 
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
+		return
+	}
+	err = fD.setListFlags(r, resultFilms)
+	if err != nil {
+		response.WriteError(w, r, err)
 		return
 	}
 
@@ -160,11 +375,15 @@ func (fD *FilmDelivery) GetFilms(w http.ResponseWriter, r *http.Request) {
 }
 
 // swagger:route GET /film Films getFilm
-// Возвращает список фильмов по фрагменту названия или фрагмена имени актёра
+// Возвращает список фильмов по фрагменту названия или фрагмена имени актёра.
+// Название ищется среди оригинального и всех переведённых названий.
+// Поддерживает те же фильтры, что и GET /films. При поиске по актёру actor_min_age и actor_max_age
+// относятся к возрасту этого актёра
 // responses:
 //
 //	200: []film
-//	500: basicResponse
+//	400: problemResponse
+//	500: problemResponse
 func (fD *FilmDelivery) HandleFilm(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodGet:
@@ -187,12 +406,21 @@ func (fD *FilmDelivery) GetFilmByTitle(w http.ResponseWriter, r *http.Request) {
 	log.Debug(message + "started")
 	jsonEnc := response.MakeJsonEncoder(w)
 	title := r.URL.Query().Get("title")
+	filter, err := parseFilmFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
 
-	resultFilms, err := fD.filmRepo.GetFilmsByTitle(title)
+	resultFilms, err := fD.filmRepo.GetFilmsByTitle(title, filter)
 
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
+		return
+	}
+	err = fD.setListFlags(r, resultFilms)
+	if err != nil {
+		response.WriteError(w, r, err)
 		return
 	}
 
@@ -204,14 +432,419 @@ func (fD *FilmDelivery) GetFilmByActor(w http.ResponseWriter, r *http.Request) {
 	log.Debug(message + "started")
 	jsonEnc := response.MakeJsonEncoder(w)
 	actor := r.URL.Query().Get("actor")
+	filter, err := parseFilmFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFilms, err := fD.filmRepo.GetFilmsByActor(actor, filter)
+
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	err = fD.setListFlags(r, resultFilms)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilms)
+}
+
+// swagger:route GET /films/{id}/translations Films getFilmTranslations
+// Возвращает переводы названия и описания фильма
+// responses:
+//
+//	200: []filmTranslation
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) GetFilmTranslations(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultTranslations, err := fD.filmRepo.GetFilmTranslations(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultTranslations)
+}
+
+// swagger:route PUT /films/{id}/translations/{lang} Films setFilmTranslation
+// Добавляет или заменяет перевод названия и описания фильма на язык lang (ISO 639-1)
+// security:
+// - key:
+// responses:
+//
+//	200: filmTranslation
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) SetFilmTranslation(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "SetFilmTranslation:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	filmID, lang, err := parseTranslationPath(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	translation := models.FilmTranslation{Language: lang}
+	err = json.NewDecoder(r.Body).Decode(&translation.FilmTranslationRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateFilmTranslation(&translation).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
 
-	resultFilms, err := fD.filmRepo.GetFilmsByActor(actor)
+	resultTranslation, err := fD.filmRepo.SetFilmTranslation(filmID, &translation)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultTranslation)
+}
+
+// swagger:route DELETE /films/{id}/translations/{lang} Films deleteFilmTranslation
+// Удаляет перевод фильма на язык lang
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) DeleteFilmTranslation(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	filmID, lang, err := parseTranslationPath(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
 
+	err = fD.filmRepo.DeleteFilmTranslation(filmID, lang)
 	if err != nil {
-		log.Error(err)
-		response.WriteBasicResponse(w, jsonEnc, http.StatusInternalServerError, err.Error())
+		response.WriteError(w, r, err)
 		return
 	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// HandleUpcomingFilms handles /films/upcoming
+func (fD *FilmDelivery) HandleUpcomingFilms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		fD.GetUpcomingFilms(w, r)
+	}
+}
+
+// HandleReleaseCalendar handles /films/calendar
+func (fD *FilmDelivery) HandleReleaseCalendar(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		fD.GetReleaseCalendar(w, r)
+	}
+}
 
+// HandleReleaseFeed handles /films/upcoming.ics
+func (fD *FilmDelivery) HandleReleaseFeed(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		fD.GetReleaseFeed(w, r)
+	}
+}
+
+// swagger:route GET /films/upcoming Films getUpcomingFilms
+// Возвращает фильмы, которые выходят сегодня или позже (по UTC), по дате выхода.
+// actor_id - только фильмы с этим актёром, поддерживает те же фильтры, что и GET /films
+// responses:
+//
+//	200: []film
+//	400: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) GetUpcomingFilms(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetUpcomingFilms:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	filter, err := parseReleaseFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	filter.ReleasedFrom = fD.today().Format(time.DateOnly)
+
+	resultFilms, err := fD.filmRepo.GetFilmsSorted("release_date", filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	err = fD.setListFlags(r, resultFilms)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
 	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilms)
 }
+
+// swagger:route GET /films/calendar Films getReleaseCalendar
+// Календарь релизов: фильмы, выходящие с from по to включительно (YYYY-MM-DD, не больше 366 дней),
+// сгруппированные по дате выхода. actor_id - только фильмы с этим актёром,
+// поддерживает те же фильтры, что и GET /films
+// responses:
+//
+//	200: []releaseDay
+//	400: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) GetReleaseCalendar(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetReleaseCalendar:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	filter, err := parseReleaseFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	from, err := time.Parse(time.DateOnly, r.URL.Query().Get("from"))
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage("Invalid from"))
+		return
+	}
+	to, err := time.Parse(time.DateOnly, r.URL.Query().Get("to"))
+	if err != nil || to.Before(from) {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage("Invalid to"))
+		return
+	}
+	if to.Sub(from) > film.MaxCalendarDays*24*time.Hour {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage(
+			fmt.Sprintf("Calendar range must not exceed %d days", film.MaxCalendarDays)))
+		return
+	}
+	filter.ReleasedFrom = from.Format(time.DateOnly)
+	filter.ReleasedTo = to.Format(time.DateOnly)
+
+	resultFilms, err := fD.filmRepo.GetFilmsSorted("release_date", filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	err = fD.setListFlags(r, resultFilms)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	days := []models.ReleaseDay{}
+	for _, resultFilm := range resultFilms {
+		if len(days) == 0 || days[len(days)-1].Date != resultFilm.ReleaseDate {
+			days = append(days, models.ReleaseDay{Date: resultFilm.ReleaseDate, Films: []models.Film{}})
+		}
+		days[len(days)-1].Films = append(days[len(days)-1].Films, resultFilm)
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, days)
+}
+
+// swagger:route GET /films/upcoming.ics Films getReleaseFeed
+// Лента релизов в формате iCalendar для подписки в календаре: событие на весь день выхода каждого
+// фильма. Вышедшие фильмы остаются в ленте 30 дней. actor_id - только фильмы с этим актёром,
+// genre - только фильмы этих жанров, поддерживает те же фильтры, что и GET /films
+// produces:
+// - text/calendar
+// responses:
+//
+//	200: releaseFeed
+//	400: problemResponse
+//	500: problemResponse
+func (fD *FilmDelivery) GetReleaseFeed(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetReleaseFeed:"
+	log.Debug(message + "started")
+	filter, err := parseReleaseFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	now := fD.now()
+	filter.ReleasedFrom = fD.today().AddDate(0, 0, -film.CalendarFeedPastDays).Format(time.DateOnly)
+
+	resultFilms, err := fD.filmRepo.GetFilmsSorted("release_date", filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	events := []ical.Event{}
+	for _, resultFilm := range resultFilms {
+		releaseDate, err := time.Parse(time.DateOnly, resultFilm.ReleaseDate)
+		if err != nil {
+			continue
+		}
+		events = append(events, ical.Event{
+			UID:         fmt.Sprintf("film-%d@vk-intern", resultFilm.ID),
+			Date:        releaseDate,
+			Summary:     resultFilm.Title,
+			Description: resultFilm.Description,
+		})
+	}
+	w.Header().Set("Content-Type", ical.ContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(ical.Marshal(film.CalendarFeedName, events, now))
+}
+
+// today returns current date in UTC, release dates are compared with it regardless of the server time zone
+func (fD *FilmDelivery) today() time.Time {
+	year, month, day := fD.now().UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// parseReleaseFilter читает фильтры списка фильмов и актёра из actor_id
+func parseReleaseFilter(r *http.Request) (*models.FilmFilter, error) {
+	filter, err := parseFilmFilter(r)
+	if err != nil {
+		return nil, err
+	}
+	if value := r.URL.Query().Get("actor_id"); value != "" {
+		filter.ActorID, err = strconv.Atoi(value)
+		if err != nil || filter.ActorID < 1 {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Invalid actor_id")
+		}
+	}
+	return filter, nil
+}
+
+// setListFlags marks films from the lists of the authenticated user,
+// anonymous responses stay without flags
+func (fD *FilmDelivery) setListFlags(r *http.Request, films []models.Film) error {
+	userID, ok := middleware.UserID(r.Context())
+	if !ok || len(films) == 0 {
+		return nil
+	}
+
+	filmIDs := make([]int, 0, len(films))
+	for _, film := range films {
+		filmIDs = append(filmIDs, film.ID)
+	}
+	flags, err := fD.filmRepo.GetFilmListFlags(userID, filmIDs)
+	if err != nil {
+		return err
+	}
+	for index := range films {
+		filmFlags := flags[films[index].ID]
+		films[index].InWatchlist = &filmFlags.InWatchlist
+		films[index].Watched = &filmFlags.Watched
+	}
+	return nil
+}
+
+// parseTranslationPath читает id фильма и язык из пути /films/{id}/translations/{lang}
+func parseTranslationPath(r *http.Request) (int, string, error) {
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	if len(segments) != 3 {
+		return 0, "", apperror.ErrInvalidID
+	}
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		return 0, "", apperror.ErrInvalidID
+	}
+	return filmID, strings.ToLower(segments[2]), nil
+}
+
+// parseFilmFilter читает фильтры списка фильмов из query.
+// Жанры и страны можно передать несколькими параметрами или через запятую
+func parseFilmFilter(r *http.Request) (*models.FilmFilter, error) {
+	query := r.URL.Query()
+	filter := &models.FilmFilter{
+		GenreMode: query.Get("genre_mode"),
+		Director:  strings.TrimSpace(query.Get("director")),
+		Language:  strings.ToLower(strings.TrimSpace(query.Get("language"))),
+		Languages: language.Preferred(r),
+	}
+	if filter.GenreMode == "" {
+		filter.GenreMode = film.GenreModeAny
+	}
+	if !film.IsValidGenreMode(filter.GenreMode) {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Unknown genre_mode")
+	}
+
+	filter.Genres = splitQueryValues(query["genre"])
+	for _, country := range splitQueryValues(query["country"]) {
+		country = strings.ToUpper(country)
+		if !slices.Contains(filter.Countries, country) {
+			filter.Countries = append(filter.Countries, country)
+		}
+	}
+
+	maxAgeRating := query.Get("max_age_rating")
+	if maxAgeRating != "" {
+		index := slices.Index(validation.AllowedAgeRatings, maxAgeRating)
+		if index == -1 {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Unknown max_age_rating")
+		}
+		filter.AgeRatings = validation.AllowedAgeRatings[:index+1]
+	}
+
+	var err error
+	filter.MinRuntime, err = parseNonNegative(query.Get("min_runtime"))
+	if err != nil {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Invalid min_runtime")
+	}
+	filter.MaxRuntime, err = parseNonNegative(query.Get("max_runtime"))
+	if err != nil {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Invalid max_runtime")
+	}
+	filter.MinActorAge, err = parseNonNegative(query.Get("actor_min_age"))
+	if err != nil {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Invalid actor_min_age")
+	}
+	filter.MaxActorAge, err = parseNonNegative(query.Get("actor_max_age"))
+	if err != nil {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Invalid actor_max_age")
+	}
+	if wonAward := query.Get("won_award"); wonAward != "" {
+		filter.WonAward, err = strconv.ParseBool(wonAward)
+		if err != nil {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Invalid won_award")
+		}
+	}
+	return filter, nil
+}
+
+func splitQueryValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" && !slices.Contains(result, item) {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+func parseNonNegative(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 0 {
+		return 0, apperror.ErrInvalidQuery
+	}
+	return number, nil
+}
@@ -1,15 +1,28 @@
 package delivery
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"image"
+	"image/png"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
+	"vk-intern_test-case/internal/film"
 	"vk-intern_test-case/internal/film/mock"
+	"vk-intern_test-case/internal/middleware"
 	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/storage"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -20,8 +33,16 @@ func prepareTestEnvironment() *httptest.ResponseRecorder {
 	return responseRecorder
 }
 
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
 type addFilmTest struct {
 	name               string
+	queryActorMode     string
 	inputBodyJSON      string
 	beforeTest         func(mockFilmRepository *mock.MockFilmRepository)
 	expectedFilmJSON   string
@@ -31,6 +52,7 @@ type addFilmTest struct {
 var addFilmTests = []addFilmTest{
 	{
 		"Successfully add new Film",
+		"",
 		`{ 
 			"title":"Titanic", 
 			"description": "Cool film",
@@ -48,10 +70,44 @@ var addFilmTests = []addFilmTest{
 							Rating:      8,
 						},
 					},
-				}).
+				}, "skip").
 				Return(
-					&models.Film{
-						ID: 1,
+					&models.AddedFilm{
+						Film: models.Film{
+							ID: 1,
+							FilmRequest: models.FilmRequest{
+								Title:       "Titanic",
+								Description: "Cool film",
+								ReleaseDate: "2001-08-06",
+								Rating:      8,
+							},
+						},
+					},
+					nil)
+		},
+		`{ 
+			"id": 1,
+			"title":"Titanic", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Add new Film and skip unknown actors",
+		"skip",
+		`{ 
+			"title":"Titanic", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8,
+			"actors": ["Леонардо Ди Каприо", "Кейт Уинслет"]
+		}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				AddFilm(&models.FilmWithActors{
+					Film: models.Film{
 						FilmRequest: models.FilmRequest{
 							Title:       "Titanic",
 							Description: "Cool film",
@@ -59,6 +115,21 @@ var addFilmTests = []addFilmTest{
 							Rating:      8,
 						},
 					},
+					Actors: []string{"Леонардо Ди Каприо", "Кейт Уинслет"},
+				}, "skip").
+				Return(
+					&models.AddedFilm{
+						Film: models.Film{
+							ID: 1,
+							FilmRequest: models.FilmRequest{
+								Title:       "Titanic",
+								Description: "Cool film",
+								ReleaseDate: "2001-08-06",
+								Rating:      8,
+							},
+						},
+						Warnings: []string{"Кейт Уинслет"},
+					},
 					nil)
 		},
 		`{ 
@@ -66,10 +137,88 @@ var addFilmTests = []addFilmTest{
 			"title":"Titanic", 
 			"description": "Cool film",
 			"release_date": "2001-08-06",
-			"rating": 8
+			"rating": 8,
+			"warnings": ["Кейт Уинслет"]
 		}`,
 		http.StatusOK,
 	},
+	{
+		"Fail to add new Film with unknown actors in strict mode",
+		"strict",
+		`{ 
+			"title":"Titanic", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8,
+			"actors": ["Кейт Уинслет"]
+		}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				AddFilm(gomock.Any(), "strict").
+				Return(nil, film.ErrUnknownActors.
+					WithMessage("Unknown actors: Кейт Уинслет").
+					WithFields([]models.FieldError{
+						{Field: "actors[0]", Code: "unknown_actor", Message: "actor Кейт Уинслет not found"},
+					}))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Unknown actors: Кейт Уинслет",
+			"instance": "/films",
+			"code": "unknown_actors",
+			"errors": [
+				{"field": "actors[0]", "code": "unknown_actor", "message": "actor Кейт Уинслет not found"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to add new Film with invalid fields",
+		"",
+		`{ 
+			"title":"", 
+			"description": "Cool film",
+			"release_date": "06.08.2001",
+			"rating": 11
+		}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/films",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "title", "code": "required", "message": "title is required"},
+				{"field": "rating", "code": "out_of_range", "message": "rating must be between 0 and 10"},
+				{"field": "release_date", "code": "invalid_format", "message": "release_date must be a date in YYYY-MM-DD format"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to add new Film with unknown actor mode",
+		"ignore",
+		`{ 
+			"title":"Titanic", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8
+		}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown actor_mode",
+			"instance": "/films",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
 }
 
 func TestAddFilm(t *testing.T) {
@@ -78,13 +227,13 @@ func TestAddFilm(t *testing.T) {
 	for _, test := range addFilmTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
-			filmDeliveryTest := NewFilmDelivery(mockFilmRepository)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockFilmRepository)
 			}
 			responseRecorder := prepareTestEnvironment()
 
-			request, err := http.NewRequest(http.MethodPost, "/films", strings.NewReader(test.inputBodyJSON))
+			request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s?actor_mode=%s", "/films", test.queryActorMode), strings.NewReader(test.inputBodyJSON))
 			assert.Nil(t, err)
 
 			filmDeliveryTest.HandleFilms(responseRecorder, request)
@@ -94,7 +243,7 @@ func TestAddFilm(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedFilmJSON, string(data))
 		})
 	}
@@ -131,13 +280,48 @@ var updateFilmTests = []updateFilmTest{
 						Rating:      8,
 					},
 				}).
-				Return(nil)
+				Return(titanic, nil)
 		},
 		`{
-			"status": "OK"
+			"id": 1,
+			"title": "Titanic",
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8
 		}`,
 		http.StatusOK,
 	},
+	{
+		"Fail to update a non-existent Film",
+		1,
+		`{ 
+			"title":"Titanic", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8
+		}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				UpdateFilm(1, &models.Film{
+					FilmRequest: models.FilmRequest{
+						Title:       "Titanic",
+						Description: "Cool film",
+						ReleaseDate: "2001-08-06",
+						Rating:      8,
+					},
+				}).
+				Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/1",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
 }
 
 func TestUpdateFilm(t *testing.T) {
@@ -146,7 +330,7 @@ func TestUpdateFilm(t *testing.T) {
 	for _, test := range updateFilmTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
-			filmDeliveryTest := NewFilmDelivery(mockFilmRepository)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockFilmRepository)
 			}
@@ -162,7 +346,235 @@ func TestUpdateFilm(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
+
+type patchFilmTest struct {
+	name               string
+	contentType        string
+	inputBodyJSON      string
+	beforeTest         func(mockFilmRepository *mock.MockFilmRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var titanic = &models.Film{
+	ID: 1,
+	FilmRequest: models.FilmRequest{
+		Title:       "Titanic",
+		Description: "Cool film",
+		ReleaseDate: "2001-08-06",
+		Rating:      8,
+	},
+}
+
+// patchFilm emulates PatchFilm of the repository: applies the patch to the current film
+// and checks the fields it changed
+func patchFilm(current *models.Film, expectedFields map[string]any, result *models.Film) func(int, func(*models.FilmRequest) (map[string]any, error)) (*models.Film, error) {
+	return func(filmID int, apply func(*models.FilmRequest) (map[string]any, error)) (*models.Film, error) {
+		currentFilm := current.FilmRequest
+		fields, err := apply(&currentFilm)
+		if err != nil {
+			return nil, err
+		}
+		if !reflect.DeepEqual(expectedFields, fields) {
+			return nil, fmt.Errorf("unexpected patched fields %v", fields)
+		}
+		return result, nil
+	}
+}
+
+var patchFilmTests = []patchFilmTest{
+	{
+		"Successfully patch a Film with merge patch",
+		"application/merge-patch+json",
+		`{"rating": 9}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				PatchFilm(1, gomock.Any()).
+				DoAndReturn(patchFilm(titanic, map[string]any{"rating": 9}, &models.Film{
+					ID: 1,
+					FilmRequest: models.FilmRequest{
+						Title:       "Titanic",
+						Description: "Cool film",
+						ReleaseDate: "2001-08-06",
+						Rating:      9,
+					},
+				}))
+		},
+		`{ 
+			"id": 1,
+			"title":"Titanic", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 9
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Successfully patch a Film leaving empty arrays untouched",
+		"application/merge-patch+json",
+		`{"rating": 9}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			currentFilm := &models.Film{
+				ID: 1,
+				FilmRequest: models.FilmRequest{
+					Title:           "Titanic",
+					Description:     "Cool film",
+					ReleaseDate:     "2001-08-06",
+					Rating:          8,
+					Genres:          []string{},
+					Countries:       []string{},
+					SpokenLanguages: []string{},
+				},
+			}
+			mockFilmRepository.EXPECT().
+				PatchFilm(1, gomock.Any()).
+				DoAndReturn(patchFilm(currentFilm, map[string]any{"rating": 9}, &models.Film{
+					ID: 1,
+					FilmRequest: models.FilmRequest{
+						Title:       "Titanic",
+						Description: "Cool film",
+						ReleaseDate: "2001-08-06",
+						Rating:      9,
+					},
+				}))
+		},
+		`{
+			"id": 1,
+			"title": "Titanic",
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 9
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Successfully patch a Film with JSON patch",
+		"application/json-patch+json",
+		`[{"op": "test", "path": "/rating", "value": 8}, {"op": "replace", "path": "/title", "value": "Titanic 2"}]`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				PatchFilm(1, gomock.Any()).
+				DoAndReturn(patchFilm(titanic, map[string]any{"title": "Titanic 2"}, &models.Film{
+					ID: 1,
+					FilmRequest: models.FilmRequest{
+						Title:       "Titanic 2",
+						Description: "Cool film",
+						ReleaseDate: "2001-08-06",
+						Rating:      8,
+					},
+				}))
+		},
+		`{ 
+			"id": 1,
+			"title":"Titanic 2", 
+			"description": "Cool film",
+			"release_date": "2001-08-06",
+			"rating": 8
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to patch a Film with failed test operation",
+		"application/json-patch+json",
+		`[{"op": "test", "path": "/rating", "value": 7}, {"op": "replace", "path": "/rating", "value": 9}]`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().PatchFilm(1, gomock.Any()).DoAndReturn(patchFilm(titanic, nil, nil))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Conflict",
+			"status": 409,
+			"detail": "Patch test operation 0 failed",
+			"instance": "/films/1",
+			"code": "patch_test_failed"
+		}`,
+		http.StatusConflict,
+	},
+	{
+		"Fail to patch a Film removing the title",
+		"application/merge-patch+json",
+		`{"title": null}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().PatchFilm(1, gomock.Any()).DoAndReturn(patchFilm(titanic, nil, nil))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/films/1",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "title", "code": "required", "message": "title is required"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to patch a non-existent Film",
+		"application/merge-patch+json",
+		`{"rating": 9}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().PatchFilm(1, gomock.Any()).Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/1",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Fail to patch a Film with unsupported content type",
+		"text/plain",
+		`rating=9`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().PatchFilm(1, gomock.Any()).DoAndReturn(patchFilm(titanic, nil, nil))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Unsupported Media Type",
+			"status": 415,
+			"detail": "Unsupported patch content type",
+			"instance": "/films/1",
+			"code": "unsupported_patch_content_type"
+		}`,
+		http.StatusUnsupportedMediaType,
+	},
+}
+
+func TestPatchFilm(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range patchFilmTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+			if test.beforeTest != nil {
+				test.beforeTest(mockFilmRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(http.MethodPatch, fmt.Sprintf("%s/%d", "/films", 1), strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+			request.Header.Set("Content-Type", test.contentType)
+
+			filmDeliveryTest.HandleFilms(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedJSON, string(data))
 		})
 	}
@@ -198,7 +610,7 @@ func TestDeleteFilm(t *testing.T) {
 	for _, test := range deleteFilmTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
-			filmDeliveryTest := NewFilmDelivery(mockFilmRepository)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockFilmRepository)
 			}
@@ -214,7 +626,7 @@ func TestDeleteFilm(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedJSON, string(data))
 		})
 	}
@@ -222,7 +634,7 @@ func TestDeleteFilm(t *testing.T) {
 
 type getFilmsTest struct {
 	name               string
-	querySortBy        string
+	query              string
 	beforeTest         func(mockFilmRepository *mock.MockFilmRepository)
 	expectedJSON       string
 	expectedStatusCode int
@@ -231,10 +643,10 @@ type getFilmsTest struct {
 var getFilmsTests = []getFilmsTest{
 	{
 		"Successfully get a list of Film with no query param",
-		"",
+		"sort_by=",
 		func(mockFilmRepository *mock.MockFilmRepository) {
 			mockFilmRepository.EXPECT().
-				GetFilmsSorted("").
+				GetFilmsSorted("", &models.FilmFilter{GenreMode: "any"}).
 				Return([]models.Film{
 					{
 						ID: 1,
@@ -274,6 +686,174 @@ var getFilmsTests = []getFilmsTest{
 		]`,
 		http.StatusOK,
 	},
+	{
+		"Successfully get a list of Film with all of genres",
+		"sort_by=title&genre=Драма,%20Мелодрама&genre=Драма&genre_mode=all",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("title", &models.FilmFilter{
+					Genres:    []string{"Драма", "Мелодрама"},
+					GenreMode: "all",
+				}).
+				Return([]models.Film{
+					{
+						ID: 1,
+						FilmRequest: models.FilmRequest{
+							Title:       "Titanic",
+							Description: "Cool film",
+							ReleaseDate: "2001-08-06",
+							Rating:      8,
+							Genres:      []string{"Драма", "Мелодрама"},
+						},
+					},
+				}, nil)
+		},
+		`[
+			{ 
+				"id": 1,
+				"title":"Titanic", 
+				"description": "Cool film",
+				"release_date": "2001-08-06",
+				"rating": 8,
+				"genres": ["Драма", "Мелодрама"]
+			}
+		]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a list of Film with unknown genre mode",
+		"genre=Драма&genre_mode=some",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown genre_mode",
+			"instance": "/films",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Successfully get a list of Film with metadata filters",
+		"max_age_rating=12%2B&country=ru,US&country=RU&language=EN&min_runtime=90&max_runtime=200",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("", &models.FilmFilter{
+					GenreMode:  "any",
+					AgeRatings: []string{"0+", "6+", "12+"},
+					Countries:  []string{"RU", "US"},
+					Language:   "en",
+					MinRuntime: 90,
+					MaxRuntime: 200,
+				}).
+				Return([]models.Film{
+					{
+						ID: 1,
+						FilmRequest: models.FilmRequest{
+							Title:       "Titanic",
+							Description: "Cool film",
+							ReleaseDate: "1997-12-19",
+							Rating:      8,
+							Runtime:     194,
+							Countries:   []string{"US"},
+							AgeRating:   "12+",
+						},
+					},
+				}, nil)
+		},
+		`[
+			{
+				"id": 1,
+				"title": "Titanic",
+				"description": "Cool film",
+				"release_date": "1997-12-19",
+				"rating": 8,
+				"runtime": 194,
+				"countries": ["US"],
+				"age_rating": "12+"
+			}
+		]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a list of Film with unknown max age rating",
+		"max_age_rating=13%2B",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown max_age_rating",
+			"instance": "/films",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Successfully get a list of Film that won an award",
+		"won_award=true",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("", &models.FilmFilter{GenreMode: "any", WonAward: true}).
+				Return([]models.Film{}, nil)
+		},
+		`[]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a list of Film with invalid won_award",
+		"won_award=maybe",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid won_award",
+			"instance": "/films",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Successfully get a list of Film with young actors",
+		"actor_max_age=24",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("", &models.FilmFilter{GenreMode: "any", MaxActorAge: 24}).
+				Return([]models.Film{}, nil)
+		},
+		`[]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a list of Film with invalid actor age",
+		"actor_min_age=old",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid actor_min_age",
+			"instance": "/films",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get a list of Film with negative min runtime",
+		"min_runtime=-5",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid min_runtime",
+			"instance": "/films",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
 }
 
 func TestGetFilms(t *testing.T) {
@@ -282,13 +862,13 @@ func TestGetFilms(t *testing.T) {
 	for _, test := range getFilmsTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
-			filmDeliveryTest := NewFilmDelivery(mockFilmRepository)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockFilmRepository)
 			}
 			responseRecorder := prepareTestEnvironment()
 
-			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?sort_by=%s", "/films", test.querySortBy), nil)
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s", "/films", test.query), nil)
 			assert.Nil(t, err)
 
 			filmDeliveryTest.HandleFilms(responseRecorder, request)
@@ -298,7 +878,7 @@ func TestGetFilms(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedJSON, string(data))
 		})
 	}
@@ -318,7 +898,7 @@ var getFilmByTitleTests = []getFilmByTitleTest{
 		"Tit",
 		func(mockFilmRepository *mock.MockFilmRepository) {
 			mockFilmRepository.EXPECT().
-				GetFilmsByTitle("Tit").
+				GetFilmsByTitle("Tit", &models.FilmFilter{GenreMode: "any"}).
 				Return([]models.Film{
 					{
 						ID: 1,
@@ -363,14 +943,85 @@ var getFilmByTitleTests = []getFilmByTitleTest{
 		"Tit",
 		func(mockFilmRepository *mock.MockFilmRepository) {
 			mockFilmRepository.EXPECT().
-				GetFilmsByTitle("Tit").
+				GetFilmsByTitle("Tit", &models.FilmFilter{GenreMode: "any"}).
 				Return([]models.Film{}, errors.New("error text"))
 		},
 		`{
-			"status": "error text"
+			"type": "about:blank",
+			"title": "Internal Server Error",
+			"status": 500,
+			"detail": "Internal server error",
+			"instance": "/film",
+			"code": "internal_error"
 		}`,
 		http.StatusInternalServerError,
 	},
+	{
+		"Successfully get a list of localized Film by title",
+		"Тит&lang=ru",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmsByTitle("Тит", &models.FilmFilter{GenreMode: "any", Languages: []string{"ru"}}).
+				Return([]models.Film{
+					{
+						ID: 1,
+						FilmRequest: models.FilmRequest{
+							Title:            "Титаник",
+							Description:      "Классный фильм",
+							ReleaseDate:      "1997-12-19",
+							Rating:           8,
+							OriginalLanguage: "en",
+						},
+						OriginalTitle: "Titanic",
+						Language:      "ru",
+					},
+				}, nil)
+		},
+		`[
+			{
+				"id": 1,
+				"title": "Титаник",
+				"original_title": "Titanic",
+				"language": "ru",
+				"original_language": "en",
+				"description": "Классный фильм",
+				"release_date": "1997-12-19",
+				"rating": 8
+			}
+		]`,
+		http.StatusOK,
+	},
+}
+
+func TestGetFilmsWithListFlags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+	filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+	mockFilmRepository.EXPECT().
+		GetFilmsSorted("", &models.FilmFilter{GenreMode: "any"}).
+		Return([]models.Film{
+			{ID: 1, FilmRequest: models.FilmRequest{Title: "Титаник", ReleaseDate: "1997-12-19", Rating: 8}},
+			{ID: 2, FilmRequest: models.FilmRequest{Title: "Аватар", ReleaseDate: "2009-12-17", Rating: 8}},
+		}, nil)
+	mockFilmRepository.EXPECT().
+		GetFilmListFlags(1, []int{1, 2}).
+		Return(map[int]models.FilmListFlags{1: {Watched: true}}, nil)
+	responseRecorder := prepareTestEnvironment()
+
+	request, err := http.NewRequest(http.MethodGet, "/films", nil)
+	assert.Nil(t, err)
+	request = request.WithContext(middleware.WithUserID(request.Context(), 1))
+
+	filmDeliveryTest.HandleFilms(responseRecorder, request)
+	data, err := io.ReadAll(responseRecorder.Result().Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.JSONEq(t, `[
+		{"id": 1, "title": "Титаник", "description": "", "release_date": "1997-12-19", "rating": 8, "in_watchlist": false, "watched": true},
+		{"id": 2, "title": "Аватар", "description": "", "release_date": "2009-12-17", "rating": 8, "in_watchlist": false, "watched": false}
+	]`, string(data))
 }
 
 func TestGetFilmsByTitle(t *testing.T) {
@@ -379,7 +1030,7 @@ func TestGetFilmsByTitle(t *testing.T) {
 	for _, test := range getFilmByTitleTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
-			filmDeliveryTest := NewFilmDelivery(mockFilmRepository)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockFilmRepository)
 			}
@@ -395,7 +1046,7 @@ func TestGetFilmsByTitle(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedJSON, string(data))
 		})
 	}
@@ -415,7 +1066,7 @@ var getFilmByActorTests = []getFilmByActorTest{
 		"Лео",
 		func(mockFilmRepository *mock.MockFilmRepository) {
 			mockFilmRepository.EXPECT().
-				GetFilmsByActor("Лео").
+				GetFilmsByActor("Лео", &models.FilmFilter{GenreMode: "any"}).
 				Return([]models.Film{
 					{
 						ID: 1,
@@ -463,7 +1114,7 @@ func TestGetFilmsByActor(t *testing.T) {
 	for _, test := range getFilmByActorTests {
 		t.Run(test.name, func(t *testing.T) {
 			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
-			filmDeliveryTest := NewFilmDelivery(mockFilmRepository)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
 			if test.beforeTest != nil {
 				test.beforeTest(mockFilmRepository)
 			}
@@ -479,8 +1130,500 @@ func TestGetFilmsByActor(t *testing.T) {
 
 			assert.Nil(t, err)
 			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
-			assert.Equal(t, "application/json", result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
+
+type filmTranslationTest struct {
+	name               string
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockFilmRepository *mock.MockFilmRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var filmTranslationTests = []filmTranslationTest{
+	{
+		"Successfully get translations of Film",
+		http.MethodGet,
+		"/films/1/translations",
+		"",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmTranslations(1).
+				Return([]models.FilmTranslation{
+					{Language: "ru", FilmTranslationRequest: models.FilmTranslationRequest{Title: "Титаник"}},
+				}, nil)
+		},
+		`[{"language": "ru", "title": "Титаник", "description": ""}]`,
+		http.StatusOK,
+	},
+	{
+		"Successfully set translation of Film",
+		http.MethodPut,
+		"/films/1/translations/RU",
+		`{"title": "Титаник", "description": "Классный фильм"}`,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			translation := &models.FilmTranslation{
+				Language:               "ru",
+				FilmTranslationRequest: models.FilmTranslationRequest{Title: "Титаник", Description: "Классный фильм"},
+			}
+			mockFilmRepository.EXPECT().
+				SetFilmTranslation(1, translation).
+				Return(translation, nil)
+		},
+		`{"language": "ru", "title": "Титаник", "description": "Классный фильм"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to set translation of Film with invalid language",
+		http.MethodPut,
+		"/films/1/translations/russian",
+		`{"title": "Титаник"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/films/1/translations/russian",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "language", "code": "invalid_format", "message": "language must be an ISO 639-1 language code"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to delete a non-existent translation of Film",
+		http.MethodDelete,
+		"/films/1/translations/de",
+		"",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				DeleteFilmTranslation(1, "de").
+				Return(film.ErrTranslationNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Translation not found",
+			"instance": "/films/1/translations/de",
+			"code": "translation_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleFilmTranslations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range filmTranslationTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+			if test.beforeTest != nil {
+				test.beforeTest(mockFilmRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+
+			filmDeliveryTest.HandleFilmTranslations(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
+
+func multipartFile(t *testing.T, data []byte) (*bytes.Buffer, string) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, err := writer.CreateFormFile("file", "poster")
+	assert.Nil(t, err)
+	_, err = part.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, writer.Close())
+	return body, writer.FormDataContentType()
+}
+
+func testPNG(t *testing.T) []byte {
+	var buffer bytes.Buffer
+	assert.Nil(t, png.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, 400, 600))))
+	return buffer.Bytes()
+}
+
+func TestUploadPoster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mediaDir := t.TempDir()
+	mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+	filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(mediaDir, "/media"))
+
+	mockFilmRepository.EXPECT().GetFilm(1).Return(titanic, nil)
+	mockFilmRepository.EXPECT().SetFilmPoster(1, gomock.Any()).Return(nil)
+	body, contentType := multipartFile(t, testPNG(t))
+	request := httptest.NewRequest(http.MethodPost, "/films/1/poster", body)
+	request.Header.Set("Content-Type", contentType)
+	responseRecorder := prepareTestEnvironment()
+
+	filmDeliveryTest.HandleFilmPoster(responseRecorder, request)
+
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var poster models.Image
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &poster))
+	assert.True(t, strings.HasPrefix(poster.Medium, "/media/films/1/poster/"))
+	assert.True(t, strings.HasSuffix(poster.Medium, "/medium.png"))
+	_, err := os.Stat(filepath.Join(mediaDir, strings.TrimPrefix(poster.Medium, "/media/")))
+	assert.Nil(t, err)
+
+	mockFilmRepository.EXPECT().DeleteFilm(1).Return(nil)
+	request = httptest.NewRequest(http.MethodDelete, "/films/1", nil)
+
+	filmDeliveryTest.HandleFilms(prepareTestEnvironment(), request)
+
+	_, err = os.Stat(filepath.Join(mediaDir, "films", "1"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestUploadPosterKeepsOldPosterWhenFilmUpdateFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mediaDir := t.TempDir()
+	mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+	filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(mediaDir, "/media"))
+	upload := func() *httptest.ResponseRecorder {
+		body, contentType := multipartFile(t, testPNG(t))
+		request := httptest.NewRequest(http.MethodPost, "/films/1/poster", body)
+		request.Header.Set("Content-Type", contentType)
+		responseRecorder := prepareTestEnvironment()
+		filmDeliveryTest.HandleFilmPoster(responseRecorder, request)
+		return responseRecorder
+	}
+
+	mockFilmRepository.EXPECT().GetFilm(1).Return(titanic, nil).Times(2)
+	gomock.InOrder(
+		mockFilmRepository.EXPECT().SetFilmPoster(1, gomock.Any()).Return(nil),
+		mockFilmRepository.EXPECT().SetFilmPoster(1, gomock.Any()).Return(film.ErrNotFound),
+	)
+
+	responseRecorder := upload()
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	var poster models.Image
+	assert.Nil(t, json.Unmarshal(responseRecorder.Body.Bytes(), &poster))
+
+	responseRecorder = upload()
+	assert.Equal(t, http.StatusNotFound, responseRecorder.Code)
+
+	_, err := os.Stat(filepath.Join(mediaDir, strings.TrimPrefix(poster.Original, "/media/")))
+	assert.Nil(t, err)
+	versions, err := os.ReadDir(filepath.Join(mediaDir, "films", "1", "poster"))
+	assert.Nil(t, err)
+	assert.Len(t, versions, 1)
+}
+
+type uploadPosterTest struct {
+	name               string
+	path               string
+	data               []byte
+	beforeTest         func(mockFilmRepository *mock.MockFilmRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var uploadPosterTests = []uploadPosterTest{
+	{
+		"Fail to upload poster in unsupported format",
+		"/films/1/poster",
+		[]byte("GIF89a"),
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unsupported Media Type",
+			"status": 415,
+			"detail": "Image must be JPEG, PNG or WebP",
+			"instance": "/films/1/poster",
+			"code": "unsupported_image_format"
+		}`,
+		http.StatusUnsupportedMediaType,
+	},
+	{
+		"Fail to upload poster of a non-existent Film",
+		"/films/5/poster",
+		nil,
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().GetFilm(5).Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/5/poster",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestUploadPosterErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range uploadPosterTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+			if test.beforeTest != nil {
+				test.beforeTest(mockFilmRepository)
+			}
+			data := test.data
+			if data == nil {
+				data = testPNG(t)
+			}
+			body, contentType := multipartFile(t, data)
+			request := httptest.NewRequest(http.MethodPost, test.path, body)
+			request.Header.Set("Content-Type", contentType)
+			responseRecorder := prepareTestEnvironment()
+
+			filmDeliveryTest.HandleFilmPoster(responseRecorder, request)
+
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, response.ProblemContentType, responseRecorder.Header().Get("Content-Type"))
+			assert.JSONEq(t, test.expectedJSON, responseRecorder.Body.String())
+		})
+	}
+}
+
+type releaseTest struct {
+	name               string
+	path               string
+	beforeTest         func(mockFilmRepository *mock.MockFilmRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var upcomingFilm = models.Film{
+	ID: 3,
+	FilmRequest: models.FilmRequest{
+		Title:       "Аватар 4",
+		Description: "Продолжение",
+		ReleaseDate: "2029-12-21",
+		Rating:      0,
+	},
+}
+
+// Вечер 1 мая у сервера западнее Гринвича, по UTC уже 2 мая
+var releaseNow = time.Date(2024, time.May, 1, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+
+var releaseTests = []releaseTest{
+	{
+		"Successfully get upcoming films of actor",
+		"/films/upcoming?actor_id=5",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("release_date", &models.FilmFilter{
+					GenreMode:    "any",
+					ActorID:      5,
+					ReleasedFrom: "2024-05-02",
+				}).
+				Return([]models.Film{upcomingFilm}, nil)
+		},
+		`[{
+			"id": 3,
+			"title": "Аватар 4",
+			"description": "Продолжение",
+			"release_date": "2029-12-21",
+			"rating": 0
+		}]`,
+		http.StatusOK,
+	},
+	{
+		"Upcoming films include films released today by UTC",
+		"/films/upcoming",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			todayFilm := upcomingFilm
+			todayFilm.ReleaseDate = "2024-05-02"
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("release_date", &models.FilmFilter{
+					GenreMode:    "any",
+					ReleasedFrom: "2024-05-02",
+				}).
+				Return([]models.Film{todayFilm}, nil)
+		},
+		`[{
+			"id": 3,
+			"title": "Аватар 4",
+			"description": "Продолжение",
+			"release_date": "2024-05-02",
+			"rating": 0
+		}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get upcoming films with invalid actor_id",
+		"/films/upcoming?actor_id=abc",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid actor_id",
+			"instance": "/films/upcoming",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Successfully get release calendar grouped by date",
+		"/films/calendar?from=2029-12-01&to=2029-12-31&genre=Фантастика",
+		func(mockFilmRepository *mock.MockFilmRepository) {
+			secondFilm := upcomingFilm
+			secondFilm.ID, secondFilm.Title = 4, "Дюна 3"
+			thirdFilm := upcomingFilm
+			thirdFilm.ID, thirdFilm.Title, thirdFilm.ReleaseDate = 5, "Аватар 5", "2029-12-25"
+			mockFilmRepository.EXPECT().
+				GetFilmsSorted("release_date", &models.FilmFilter{
+					GenreMode:    "any",
+					Genres:       []string{"Фантастика"},
+					ReleasedFrom: "2029-12-01",
+					ReleasedTo:   "2029-12-31",
+				}).
+				Return([]models.Film{upcomingFilm, secondFilm, thirdFilm}, nil)
+		},
+		`[
+			{
+				"date": "2029-12-21",
+				"films": [
+					{"id": 3, "title": "Аватар 4", "description": "Продолжение", "release_date": "2029-12-21", "rating": 0},
+					{"id": 4, "title": "Дюна 3", "description": "Продолжение", "release_date": "2029-12-21", "rating": 0}
+				]
+			},
+			{
+				"date": "2029-12-25",
+				"films": [
+					{"id": 5, "title": "Аватар 5", "description": "Продолжение", "release_date": "2029-12-25", "rating": 0}
+				]
+			}
+		]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get release calendar without from",
+		"/films/calendar?to=2029-12-31",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid from",
+			"instance": "/films/calendar",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get release calendar ending before start",
+		"/films/calendar?from=2029-12-31&to=2029-12-01",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid to",
+			"instance": "/films/calendar",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get too long release calendar",
+		"/films/calendar?from=2029-01-01&to=2030-06-01",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Calendar range must not exceed 366 days",
+			"instance": "/films/calendar",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+}
+
+func TestReleaseCalendar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range releaseTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+			filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+			filmDeliveryTest.now = func() time.Time { return releaseNow }
+			if test.beforeTest != nil {
+				test.beforeTest(mockFilmRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(http.MethodGet, test.path, nil)
+			assert.Nil(t, err)
+
+			if strings.HasPrefix(test.path, "/films/calendar") {
+				filmDeliveryTest.HandleReleaseCalendar(responseRecorder, request)
+			} else {
+				filmDeliveryTest.HandleUpcomingFilms(responseRecorder, request)
+			}
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
 			assert.JSONEq(t, test.expectedJSON, string(data))
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestGetReleaseFeed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFilmRepository := mock.NewMockFilmRepository(ctrl)
+	filmDeliveryTest := NewFilmDelivery(mockFilmRepository, storage.NewLocalStorage(t.TempDir(), "/media"))
+	filmDeliveryTest.now = func() time.Time { return releaseNow }
+	mockFilmRepository.EXPECT().
+		GetFilmsSorted("release_date", &models.FilmFilter{
+			GenreMode:    "any",
+			Genres:       []string{"Фантастика"},
+			ActorID:      5,
+			ReleasedFrom: "2024-04-02",
+		}).
+		Return([]models.Film{upcomingFilm}, nil)
+	responseRecorder := prepareTestEnvironment()
+
+	request, err := http.NewRequest(http.MethodGet, "/films/upcoming.ics?actor_id=5&genre=Фантастика", nil)
+	assert.Nil(t, err)
+
+	filmDeliveryTest.HandleReleaseFeed(responseRecorder, request)
+	result := responseRecorder.Result()
+	defer result.Body.Close()
+	data, err := io.ReadAll(result.Body)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", result.Header.Get("Content-Type"))
+	assert.Contains(t, string(data), "UID:film-3@vk-intern\r\n")
+	assert.Contains(t, string(data), "DTSTART;VALUE=DATE:20291221\r\n")
+	assert.Contains(t, string(data), "SUMMARY:Аватар 4\r\n")
+}
@@ -0,0 +1,49 @@
+package film
+
+import "vk-intern_test-case/utils/apperror"
+
+var (
+	ErrNotFound      = apperror.NotFound("film_not_found", "Film not found")
+	ErrUnknownActors = apperror.Validation("unknown_actors", "Unknown actors", nil)
+	ErrUnknownGenres = apperror.Validation("unknown_genres", "Unknown genres", nil)
+
+	ErrTranslationNotFound = apperror.NotFound("translation_not_found", "Translation not found")
+)
+
+// Режимы обработки актёров, которых нет в базе, при добавлении фильма
+const (
+	ActorModeSkip   = "skip"
+	ActorModeStrict = "strict"
+	ActorModeCreate = "create"
+)
+
+func IsValidActorMode(mode string) bool {
+	switch mode {
+	case ActorModeSkip, ActorModeStrict, ActorModeCreate:
+		return true
+	}
+	return false
+}
+
+// Режимы фильтрации списка фильмов по нескольким жанрам
+const (
+	GenreModeAny = "any"
+	GenreModeAll = "all"
+)
+
+func IsValidGenreMode(mode string) bool {
+	switch mode {
+	case GenreModeAny, GenreModeAll:
+		return true
+	}
+	return false
+}
+
+// Календарь релизов
+const (
+	// Наибольший диапазон GET /films/calendar в днях
+	MaxCalendarDays = 366
+	// Сколько дней вышедшие фильмы остаются в ленте .ics, чтобы календари не удаляли прошедшие события
+	CalendarFeedPastDays = 30
+	CalendarFeedName     = "Премьеры фильмов"
+)
@@ -35,18 +35,18 @@ func (m *MockFilmRepository) EXPECT() *MockFilmRepositoryMockRecorder {
 }
 
 // AddFilm mocks base method.
-func (m *MockFilmRepository) AddFilm(arg0 *models.FilmWithActors) (*models.Film, error) {
+func (m *MockFilmRepository) AddFilm(arg0 *models.FilmWithActors, arg1 string) (*models.AddedFilm, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddFilm", arg0)
-	ret0, _ := ret[0].(*models.Film)
+	ret := m.ctrl.Call(m, "AddFilm", arg0, arg1)
+	ret0, _ := ret[0].(*models.AddedFilm)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // AddFilm indicates an expected call of AddFilm.
-func (mr *MockFilmRepositoryMockRecorder) AddFilm(arg0 interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) AddFilm(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilm", reflect.TypeOf((*MockFilmRepository)(nil).AddFilm), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilm", reflect.TypeOf((*MockFilmRepository)(nil).AddFilm), arg0, arg1)
 }
 
 // DeleteFilm mocks base method.
@@ -63,59 +63,163 @@ func (mr *MockFilmRepositoryMockRecorder) DeleteFilm(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilm", reflect.TypeOf((*MockFilmRepository)(nil).DeleteFilm), arg0)
 }
 
+// DeleteFilmTranslation mocks base method.
+func (m *MockFilmRepository) DeleteFilmTranslation(filmID int, language string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmTranslation", filmID, language)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmTranslation indicates an expected call of DeleteFilmTranslation.
+func (mr *MockFilmRepositoryMockRecorder) DeleteFilmTranslation(filmID, language interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmTranslation", reflect.TypeOf((*MockFilmRepository)(nil).DeleteFilmTranslation), filmID, language)
+}
+
+// GetFilm mocks base method.
+func (m *MockFilmRepository) GetFilm(arg0 int) (*models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilm", arg0)
+	ret0, _ := ret[0].(*models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilm indicates an expected call of GetFilm.
+func (mr *MockFilmRepositoryMockRecorder) GetFilm(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilm", reflect.TypeOf((*MockFilmRepository)(nil).GetFilm), arg0)
+}
+
+// GetFilmListFlags mocks base method.
+func (m *MockFilmRepository) GetFilmListFlags(userID int, filmIDs []int) (map[int]models.FilmListFlags, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmListFlags", userID, filmIDs)
+	ret0, _ := ret[0].(map[int]models.FilmListFlags)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmListFlags indicates an expected call of GetFilmListFlags.
+func (mr *MockFilmRepositoryMockRecorder) GetFilmListFlags(userID, filmIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmListFlags", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmListFlags), userID, filmIDs)
+}
+
+// GetFilmTranslations mocks base method.
+func (m *MockFilmRepository) GetFilmTranslations(filmID int) ([]models.FilmTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmTranslations", filmID)
+	ret0, _ := ret[0].([]models.FilmTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmTranslations indicates an expected call of GetFilmTranslations.
+func (mr *MockFilmRepositoryMockRecorder) GetFilmTranslations(filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmTranslations", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmTranslations), filmID)
+}
+
 // GetFilmsByActor mocks base method.
-func (m *MockFilmRepository) GetFilmsByActor(actorName string) ([]models.Film, error) {
+func (m *MockFilmRepository) GetFilmsByActor(actorName string, filter *models.FilmFilter) ([]models.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsByActor", actorName)
+	ret := m.ctrl.Call(m, "GetFilmsByActor", actorName, filter)
 	ret0, _ := ret[0].([]models.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsByActor indicates an expected call of GetFilmsByActor.
-func (mr *MockFilmRepositoryMockRecorder) GetFilmsByActor(actorName interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) GetFilmsByActor(actorName, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByActor", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmsByActor), actorName)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByActor", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmsByActor), actorName, filter)
 }
 
 // GetFilmsByTitle mocks base method.
-func (m *MockFilmRepository) GetFilmsByTitle(title string) ([]models.Film, error) {
+func (m *MockFilmRepository) GetFilmsByTitle(title string, filter *models.FilmFilter) ([]models.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsByTitle", title)
+	ret := m.ctrl.Call(m, "GetFilmsByTitle", title, filter)
 	ret0, _ := ret[0].([]models.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsByTitle indicates an expected call of GetFilmsByTitle.
-func (mr *MockFilmRepositoryMockRecorder) GetFilmsByTitle(title interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) GetFilmsByTitle(title, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByTitle", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmsByTitle), title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsByTitle", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmsByTitle), title, filter)
 }
 
 // GetFilmsSorted mocks base method.
-func (m *MockFilmRepository) GetFilmsSorted(field string) ([]models.Film, error) {
+func (m *MockFilmRepository) GetFilmsSorted(field string, filter *models.FilmFilter) ([]models.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFilmsSorted", field)
+	ret := m.ctrl.Call(m, "GetFilmsSorted", field, filter)
 	ret0, _ := ret[0].([]models.Film)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFilmsSorted indicates an expected call of GetFilmsSorted.
-func (mr *MockFilmRepositoryMockRecorder) GetFilmsSorted(field interface{}) *gomock.Call {
+func (mr *MockFilmRepositoryMockRecorder) GetFilmsSorted(field, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsSorted", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmsSorted), field)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsSorted", reflect.TypeOf((*MockFilmRepository)(nil).GetFilmsSorted), field, filter)
 }
 
-// UpdateFilm mocks base method.
-func (m *MockFilmRepository) UpdateFilm(arg0 int, arg1 *models.Film) error {
+// PatchFilm mocks base method.
+func (m *MockFilmRepository) PatchFilm(filmID int, apply func(*models.FilmRequest) (map[string]any, error)) (*models.Film, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFilm", arg0, arg1)
+	ret := m.ctrl.Call(m, "PatchFilm", filmID, apply)
+	ret0, _ := ret[0].(*models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchFilm indicates an expected call of PatchFilm.
+func (mr *MockFilmRepositoryMockRecorder) PatchFilm(filmID, apply interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchFilm", reflect.TypeOf((*MockFilmRepository)(nil).PatchFilm), filmID, apply)
+}
+
+// SetFilmPoster mocks base method.
+func (m *MockFilmRepository) SetFilmPoster(filmID int, poster *models.Image) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFilmPoster", filmID, poster)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// SetFilmPoster indicates an expected call of SetFilmPoster.
+func (mr *MockFilmRepositoryMockRecorder) SetFilmPoster(filmID, poster interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFilmPoster", reflect.TypeOf((*MockFilmRepository)(nil).SetFilmPoster), filmID, poster)
+}
+
+// SetFilmTranslation mocks base method.
+func (m *MockFilmRepository) SetFilmTranslation(filmID int, translation *models.FilmTranslation) (*models.FilmTranslation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFilmTranslation", filmID, translation)
+	ret0, _ := ret[0].(*models.FilmTranslation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetFilmTranslation indicates an expected call of SetFilmTranslation.
+func (mr *MockFilmRepositoryMockRecorder) SetFilmTranslation(filmID, translation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFilmTranslation", reflect.TypeOf((*MockFilmRepository)(nil).SetFilmTranslation), filmID, translation)
+}
+
+// UpdateFilm mocks base method.
+func (m *MockFilmRepository) UpdateFilm(arg0 int, arg1 *models.Film) (*models.Film, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFilm", arg0, arg1)
+	ret0, _ := ret[0].(*models.Film)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // UpdateFilm indicates an expected call of UpdateFilm.
 func (mr *MockFilmRepositoryMockRecorder) UpdateFilm(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
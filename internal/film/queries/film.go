@@ -1,17 +1,103 @@
 package queries
 
+// Колонки фильма в ответах, порядок совпадает с repository.ScanFilm.
+// Жанры собираются в массив, отсортированный по названию.
+// Средняя оценка пользователей и число оценок считаются по опубликованным отзывам
+const FilmColumns = `f.id, f.title, f.description, f.release_date, f.rating,
+	array(select g.name from film_genre as fg join genre as g on g.id = fg.genre_id
+		where fg.film_id = f.id order by g.name) as genres,
+	f.runtime, f.countries, f.original_language, f.spoken_languages, f.age_rating,
+	f.budget, f.box_office, f.currency, f.poster,
+	(select round(avg(r.rating), 2)::float8 from review as r
+		where r.film_id = f.id and r.status = 'published') as community_rating,
+	(select count(*)::int from review as r where r.film_id = f.id and r.status = 'published') as vote_count`
+
+// Байесовское среднее оценок пользователей: (сумма оценок + 10 * средняя оценка по всем фильмам) / (число оценок + 10).
+// Пока у фильма меньше 10 оценок, его рейтинг ближе к среднему, чем к его собственным оценкам
+const communityScore = `(select (coalesce(sum(r.rating), 0) + 10 * (select avg(rating) from review where status = 'published'))
+		/ (count(*) + 10) from review as r where r.film_id = f.id and r.status = 'published')`
+
 const (
-	CreateFilm = `insert into film (title, description, release_date, rating)
-		values ($1, $2, $3, $4) returning id;`
+	CreateFilm = `insert into film (title, description, release_date, rating, runtime, countries,
+		original_language, spoken_languages, age_rating, budget, box_office, currency)
+		values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) returning id;`
 	MakeConnectionFilmWithActor = `insert into actor_film (actor_id, film_id) values ($1, $2);`
-	UpdateFilm                  = `update film set title = $1, description = $2, release_date = $3, rating = $4 where id = $5;`
-	DeleteFilm                  = `delete from film where id = $1`
-	GetFilmsSortedByRating      = `select * from film order by rating desc;`
-	GetFilmsSortedByDate        = `select * from film order by release_date;`
-	GetFilmsSortedByTitle       = `select * from film order by title;`
-	GetFilmsByTitle             = `select * from film where lower(title) like lower($1) || '%';`
-	GetFilmsByActor             = `select f.id, f.title, f.description, f.release_date, f.rating from film as f
-		join actor_film as af on f.id = af.id
-		join actor as a on a.id = af.id
-		where lower(a.name) like lower($1) || '%';`
+	MakeConnectionFilmWithGenre = `insert into film_genre (film_id, genre_id) values ($1, $2);`
+	DeleteFilmGenres            = `delete from film_genre where film_id = $1;`
+	UpdateFilm                  = `update film as f set title = $1, description = $2, release_date = $3, rating = $4,
+		runtime = $5, countries = $6, original_language = $7, spoken_languages = $8, age_rating = $9,
+		budget = $10, box_office = $11, currency = $12, updated_at = now()
+		where f.id = $13
+		returning ` + FilmColumns + `;`
+	DeleteFilm    = `delete from film where id = $1`
+	GetFilmByID   = `select ` + FilmColumns + ` from film as f where f.id = $1;`
+	// Фильм блокируется до конца транзакции, чтобы параллельные изменения не затёрли друг друга
+	LockFilmByID  = `select ` + FilmColumns + ` from film as f where f.id = $1 for update of f;`
+	PatchFilm     = `update film as f set %s, updated_at = now() where f.id = $%d returning ` + FilmColumns + `;`
+	GetFilms      = `select ` + FilmColumns + ` from film as f`
+	GetFilmIDByID = `select id from film where id = $1;`
+	SetFilmPoster = `update film set poster = $1, updated_at = now() where id = $2;`
+)
+
+const (
+	GetFilmTranslations = `select language, title, description from film_translation
+		where film_id = $1 order by language;`
+	GetTranslationsOfFilms = `select film_id, language, title, description from film_translation
+		where film_id = any($1) and language = any($2);`
+	SetFilmTranslation = `insert into film_translation (film_id, language, title, description) values ($1, $2, $3, $4)
+		on conflict (film_id, language) do update set title = excluded.title, description = excluded.description;`
+	DeleteFilmTranslation = `delete from film_translation where film_id = $1 and language = $2;`
+)
+
+// Отметки фильмов в личных списках пользователя
+const GetFilmListFlags = `select film_id, bool_or(list = 'watchlist'), bool_or(list = 'watched') from user_film_list
+	where user_id = $1 and film_id = any($2) group by film_id;`
+
+const (
+	actorNameMatches = `(lower(a.name) like lower($%d) || '%%'
+			or exists (select 1 from unnest(a.aliases) as alias where lower(alias) like lower($%d) || '%%'))`
+	// Полных лет актёру на дату выхода фильма, null, если дата рождения неизвестна
+	actorAgeAtRelease = `extract(year from age(f.release_date, a.date_of_birth))`
 )
+
+// Условия для списка фильмов. Номер параметра подставляется при построении запроса
+const (
+	FilmTitleCondition = `(lower(f.title) like lower($%d) || '%%' or exists (select 1 from film_translation as ft
+		where ft.film_id = f.id and lower(ft.title) like lower($%d) || '%%'))`
+	FilmActorCondition = `exists (select 1 from actor_film as af join person as a on a.id = af.actor_id
+		where af.film_id = f.id and ` + actorNameMatches + `)`
+	// Хотя бы одному актёру фильма на дату выхода было от $1 до $2 лет
+	FilmActorAgeCondition = `exists (select 1 from actor_film as af join person as a on a.id = af.actor_id
+		where af.film_id = f.id and ` + actorAgeAtRelease + ` between $%d and $%d)`
+	// Актёру с подходящим именем на дату выхода было от $2 до $3 лет
+	FilmActorAtAgeCondition = `exists (select 1 from actor_film as af join person as a on a.id = af.actor_id
+		where af.film_id = f.id and ` + actorNameMatches + ` and ` + actorAgeAtRelease + ` between $%d and $%d)`
+	FilmAnyGenreCondition = `exists (select 1 from film_genre as fg join genre as g on g.id = fg.genre_id
+		where fg.film_id = f.id and g.name = any($%d))`
+	FilmAllGenresCondition = `(select count(distinct g.name) from film_genre as fg join genre as g on g.id = fg.genre_id
+		where fg.film_id = f.id and g.name = any($%d)) = $%d`
+	FilmAgeRatingCondition  = `f.age_rating = any($%d)`
+	FilmCountryCondition    = `f.countries && $%d`
+	FilmLanguageCondition   = `(f.original_language = $%d or f.spoken_languages @> array[$%d])`
+	FilmMinRuntimeCondition = `f.runtime >= $%d`
+	FilmMaxRuntimeCondition = `f.runtime > 0 and f.runtime <= $%d`
+	FilmDirectorCondition   = `exists (select 1 from film_crew as fc join person as p on p.id = fc.person_id
+		where fc.film_id = f.id and fc.role = 'director' and lower(p.name) like lower($%d) || '%%')`
+	FilmWonAwardCondition     = `exists (select 1 from nomination as n where n.film_id = f.id and n.won)`
+	FilmReleasedFromCondition = `f.release_date >= $%d::date`
+	FilmReleasedToCondition   = `f.release_date <= $%d::date`
+	FilmActorIDCondition      = `exists (select 1 from actor_film as af where af.film_id = f.id and af.actor_id = $%d)`
+)
+
+// Сортировки списка фильмов по полю из sort_by. По умолчанию - по рейтингу
+var FilmsOrderBy = map[string]string{
+	"rating":       ` order by f.rating desc`,
+	"release_date": ` order by f.release_date`,
+	"title":        ` order by f.title`,
+	// Фильмы без оценок получают среднюю оценку по всем фильмам
+	"community_rating": ` order by ` + communityScore + ` desc nulls last, f.rating desc`,
+}
+
+// Поля фильма, которые можно изменить через PATCH. Имена в JSON совпадают с колонками
+var PatchableFilmFields = []string{"title", "description", "release_date", "rating", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency"}
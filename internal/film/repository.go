@@ -3,10 +3,17 @@ package film
 import "vk-intern_test-case/models"
 
 type FilmRepository interface {
-	AddFilm(*models.FilmWithActors) (*models.Film, error)
-	UpdateFilm(int, *models.Film) error
+	AddFilm(*models.FilmWithActors, string) (*models.AddedFilm, error)
+	UpdateFilm(int, *models.Film) (*models.Film, error)
+	GetFilm(int) (*models.Film, error)
+	PatchFilm(filmID int, apply func(*models.FilmRequest) (map[string]any, error)) (*models.Film, error)
 	DeleteFilm(int) error
-	GetFilmsSorted(field string) ([]models.Film, error)
-	GetFilmsByTitle(title string) ([]models.Film, error)
-	GetFilmsByActor(actorName string) ([]models.Film, error)
+	SetFilmPoster(filmID int, poster *models.Image) error
+	GetFilmsSorted(field string, filter *models.FilmFilter) ([]models.Film, error)
+	GetFilmsByTitle(title string, filter *models.FilmFilter) ([]models.Film, error)
+	GetFilmsByActor(actorName string, filter *models.FilmFilter) ([]models.Film, error)
+	GetFilmTranslations(filmID int) ([]models.FilmTranslation, error)
+	SetFilmTranslation(filmID int, translation *models.FilmTranslation) (*models.FilmTranslation, error)
+	DeleteFilmTranslation(filmID int, language string) error
+	GetFilmListFlags(userID int, filmIDs []int) (map[int]models.FilmListFlags, error)
 }
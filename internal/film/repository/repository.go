@@ -2,9 +2,15 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
 	"time"
 	actorQueries "vk-intern_test-case/internal/actor/queries"
+	filmPackage "vk-intern_test-case/internal/film"
 	filmQueries "vk-intern_test-case/internal/film/queries"
+	genreQueries "vk-intern_test-case/internal/genre/queries"
 	"vk-intern_test-case/models"
 	"vk-intern_test-case/utils/database"
 
@@ -25,7 +31,7 @@ func NewFilmRepository(pool database.PgxIface) *FilmRepository {
 	}
 }
 
-func (fR *FilmRepository) AddFilm(filmWithActors *models.FilmWithActors) (*models.Film, error) {
+func (fR *FilmRepository) AddFilm(filmWithActors *models.FilmWithActors, actorMode string) (*models.AddedFilm, error) {
 	message := logMessage + "AddFilm:"
 	log.Debug(message + "started")
 	transactionCtx := context.Background()
@@ -43,25 +49,37 @@ func (fR *FilmRepository) AddFilm(filmWithActors *models.FilmWithActors) (*model
 		}
 	}()
 
-	row := tx.QueryRow(transactionCtx, filmQueries.CreateFilm,
-		&filmWithActors.Title,
-		&filmWithActors.Description,
-		&filmWithActors.ReleaseDate,
-		&filmWithActors.Rating,
-	)
+	row := tx.QueryRow(transactionCtx, filmQueries.CreateFilm, filmArgs(&filmWithActors.FilmRequest)...)
 
 	err = row.Scan(&filmWithActors.ID)
 	if err != nil {
-		return nil, err
+		return nil, database.MapError(err)
 	}
 
-	for _, actor := range filmWithActors.Actors {
+	addedFilm := &models.AddedFilm{}
+	unknownActors := []string{}
+	unknownActorErrors := []models.FieldError{}
+	for index, actor := range filmWithActors.Actors {
 		var actorID int
-		row := tx.QueryRow(transactionCtx, actorQueries.GetActorIdByName, &actor)
+		row := tx.QueryRow(transactionCtx, actorQueries.ResolveActorIdByName, &actor)
 		err = row.Scan(&actorID)
-		if err != nil {
+		if err == pgx.ErrNoRows && actorMode == filmPackage.ActorModeCreate {
+			row = tx.QueryRow(transactionCtx, actorQueries.CreatePlaceholderActor, &actor)
+			err = row.Scan(&actorID)
+			if err != nil {
+				log.Error(message + err.Error())
+				return nil, database.MapError(err)
+			}
+			addedFilm.CreatedActors = append(addedFilm.CreatedActors, actor)
+		} else if err != nil {
 			if err == pgx.ErrNoRows {
 				err = nil
+				unknownActors = append(unknownActors, actor)
+				unknownActorErrors = append(unknownActorErrors, models.FieldError{
+					Field:   fmt.Sprintf("actors[%d]", index),
+					Code:    "unknown_actor",
+					Message: "actor " + actor + " not found",
+				})
 				continue
 			}
 			log.Error(message + err.Error())
@@ -70,14 +88,163 @@ func (fR *FilmRepository) AddFilm(filmWithActors *models.FilmWithActors) (*model
 
 		_, err = tx.Exec(transactionCtx, filmQueries.MakeConnectionFilmWithActor, &actorID, &filmWithActors.Film.ID)
 		if err != nil {
+			return nil, database.MapError(err)
+		}
+	}
+
+	if len(unknownActors) > 0 {
+		if actorMode == filmPackage.ActorModeStrict {
+			err = filmPackage.ErrUnknownActors.
+				WithMessage("Unknown actors: " + strings.Join(unknownActors, ", ")).
+				WithFields(unknownActorErrors)
+			return nil, database.MapError(err)
+		}
+		addedFilm.Warnings = unknownActors
+	}
+
+	if len(filmWithActors.Genres) > 0 {
+		filmWithActors.Genres, err = setFilmGenres(transactionCtx, tx, filmWithActors.ID, filmWithActors.Genres)
+		if err != nil {
+			return nil, database.MapError(err)
+		}
+	}
+
+	addedFilm.Film = filmWithActors.Film
+	return addedFilm, nil
+}
+
+func (fR *FilmRepository) UpdateFilm(filmID int, film *models.Film) (*models.Film, error) {
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, filmQueries.UpdateFilm, append(filmArgs(&film.FilmRequest), &filmID)...)
+	resultFilm, err := ScanFilm(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, filmPackage.ErrNotFound
+		}
+		return nil, database.MapError(err)
+	}
+
+	if film.Genres != nil {
+		resultFilm.Genres, err = setFilmGenres(transactionCtx, tx, filmID, film.Genres)
+		if err != nil {
+			return nil, database.MapError(err)
+		}
+	}
+
+	return resultFilm, nil
+}
+
+func (fR *FilmRepository) GetFilm(filmID int) (*models.Film, error) {
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, filmQueries.GetFilmByID, &filmID)
+	film, err := ScanFilm(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, filmPackage.ErrNotFound
+		}
+		return nil, err
+	}
+	return film, nil
+}
+
+// PatchFilm locks the film, passes its current state to apply and stores the fields apply returned
+// in the same transaction, so concurrent updates can not be lost between reading and writing
+func (fR *FilmRepository) PatchFilm(filmID int, apply func(*models.FilmRequest) (map[string]any, error)) (*models.Film, error) {
+	message := logMessage + "PatchFilm:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	film, err := ScanFilm(tx.QueryRow(transactionCtx, filmQueries.LockFilmByID, &filmID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = filmPackage.ErrNotFound
 			return nil, err
 		}
+		log.Error(message + err.Error())
+		return nil, err
+	}
+
+	fields, err := apply(&film.FilmRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	setClauses := []string{}
+	args := []any{}
+	for _, field := range filmQueries.PatchableFilmFields {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+		if values, isSlice := value.([]string); isSlice && values == nil {
+			value = []string{}
+		}
+		args = append(args, value)
+		setClauses = append(setClauses, fmt.Sprintf("%s = $%d", field, len(args)))
 	}
+	genres, patchGenres := fields["genres"].([]string)
 
-	return &filmWithActors.Film, nil
+	if len(setClauses) > 0 {
+		args = append(args, filmID)
+		query := fmt.Sprintf(filmQueries.PatchFilm, strings.Join(setClauses, ", "), len(args))
+		film, err = ScanFilm(tx.QueryRow(transactionCtx, query, args...))
+		if err != nil {
+			log.Error(message + err.Error())
+			return nil, database.MapError(err)
+		}
+	}
+
+	if patchGenres {
+		film.Genres, err = setFilmGenres(transactionCtx, tx, filmID, genres)
+		if err != nil {
+			return nil, database.MapError(err)
+		}
+	}
+	return film, nil
 }
 
-func (fR *FilmRepository) UpdateFilm(filmID int, film *models.Film) error {
+func (fR *FilmRepository) DeleteFilm(filmID int) error {
 	transactionCtx := context.Background()
 	tx, err := fR.pool.Begin(transactionCtx)
 	if err != nil {
@@ -93,15 +260,19 @@ func (fR *FilmRepository) UpdateFilm(filmID int, film *models.Film) error {
 		}
 	}()
 
-	_, err = tx.Exec(transactionCtx, filmQueries.UpdateFilm, &film.Title, &film.Description, &film.ReleaseDate, &film.Rating, &filmID)
+	commandTag, err := tx.Exec(transactionCtx, filmQueries.DeleteFilm, &filmID)
 	if err != nil {
 		return err
 	}
+	if commandTag.RowsAffected() == 0 {
+		err = filmPackage.ErrNotFound
+		return err
+	}
 
 	return nil
 }
 
-func (fR *FilmRepository) DeleteFilm(filmID int) error {
+func (fR *FilmRepository) SetFilmPoster(filmID int, poster *models.Image) error {
 	transactionCtx := context.Background()
 	tx, err := fR.pool.Begin(transactionCtx)
 	if err != nil {
@@ -117,15 +288,56 @@ func (fR *FilmRepository) DeleteFilm(filmID int) error {
 		}
 	}()
 
-	_, err = tx.Exec(transactionCtx, filmQueries.DeleteFilm, &filmID)
+	commandTag, err := tx.Exec(transactionCtx, filmQueries.SetFilmPoster, poster, &filmID)
 	if err != nil {
 		return err
 	}
-
+	if commandTag.RowsAffected() == 0 {
+		err = filmPackage.ErrNotFound
+		return err
+	}
 	return nil
 }
 
-func (fR *FilmRepository) GetFilmsSorted(field string) ([]models.Film, error) {
+func (fR *FilmRepository) GetFilmsSorted(field string, filter *models.FilmFilter) ([]models.Film, error) {
+	orderBy, ok := filmQueries.FilmsOrderBy[field]
+	if !ok {
+		orderBy = filmQueries.FilmsOrderBy["rating"]
+	}
+
+	query, args := filmsQuery([]string{}, []any{}, filter)
+	return fR.getFilms(query+orderBy, args, filter)
+}
+
+func (fR *FilmRepository) GetFilmsByTitle(title string, filter *models.FilmFilter) ([]models.Film, error) {
+	query, args := filmsQuery([]string{fmt.Sprintf(filmQueries.FilmTitleCondition, 1, 1)}, []any{&title}, filter)
+	return fR.getFilms(query, args, filter)
+}
+
+func (fR *FilmRepository) GetFilmsByActor(actorName string, filter *models.FilmFilter) ([]models.Film, error) {
+	if filter != nil && (filter.MinActorAge > 0 || filter.MaxActorAge > 0) {
+		// Границы возраста относятся к найденному актёру, а не к любому актёру фильма
+		minAge, maxAge := actorAgeBounds(filter)
+		actorFilter := *filter
+		actorFilter.MinActorAge, actorFilter.MaxActorAge = 0, 0
+		condition := fmt.Sprintf(filmQueries.FilmActorAtAgeCondition, 1, 1, 2, 3)
+		query, args := filmsQuery([]string{condition}, []any{&actorName, minAge, maxAge}, &actorFilter)
+		return fR.getFilms(query, args, filter)
+	}
+	query, args := filmsQuery([]string{fmt.Sprintf(filmQueries.FilmActorCondition, 1, 1)}, []any{&actorName}, filter)
+	return fR.getFilms(query, args, filter)
+}
+
+// actorAgeBounds returns age bounds of the filter, replacing 0 upper bound with no limit
+func actorAgeBounds(filter *models.FilmFilter) (int, int) {
+	maxAge := filter.MaxActorAge
+	if maxAge == 0 {
+		maxAge = math.MaxInt32
+	}
+	return filter.MinActorAge, maxAge
+}
+
+func (fR *FilmRepository) getFilms(query string, args []any, filter *models.FilmFilter) ([]models.Film, error) {
 	transactionCtx := context.Background()
 	tx, err := fR.pool.Begin(transactionCtx)
 	if err != nil {
@@ -141,43 +353,39 @@ func (fR *FilmRepository) GetFilmsSorted(field string) ([]models.Film, error) {
 		}
 	}()
 
-	query := filmQueries.GetFilmsSortedByRating
-	switch field {
-	case "release_date":
-		query = filmQueries.GetFilmsSortedByDate
-	case "title":
-		query = filmQueries.GetFilmsSortedByTitle
-	}
-
 	films := []models.Film{}
-	rows, err := tx.Query(transactionCtx, query)
+	rows, err := tx.Query(transactionCtx, query, args...)
 	if err != nil {
 		return []models.Film{}, err
 	}
 
 	for rows.Next() {
-		film := models.Film{}
-		var releaseDatePG pgtype.Date
-		err := rows.Scan(&film.ID, &film.Title, &film.Description, &releaseDatePG, &film.Rating)
+		film, err := ScanFilm(rows)
 		if err != nil {
 			return []models.Film{}, err
 		}
-		film.ReleaseDate = releaseDatePG.Time.Format(time.DateOnly)
-		films = append(films, film)
+		films = append(films, *film)
 	}
 
 	rows.Close()
 	if err := rows.Err(); err != nil {
 		return []models.Film{}, err
 	}
+
+	if filter != nil && len(filter.Languages) > 0 && len(films) > 0 {
+		err = localizeFilms(transactionCtx, tx, films, filter.Languages)
+		if err != nil {
+			return []models.Film{}, err
+		}
+	}
 	return films, nil
 }
 
-func (fR *FilmRepository) GetFilmsByTitle(title string) ([]models.Film, error) {
+func (fR *FilmRepository) GetFilmTranslations(filmID int) ([]models.FilmTranslation, error) {
 	transactionCtx := context.Background()
 	tx, err := fR.pool.Begin(transactionCtx)
 	if err != nil {
-		return []models.Film{}, err
+		return []models.FilmTranslation{}, err
 	}
 
 	defer func() {
@@ -189,35 +397,40 @@ func (fR *FilmRepository) GetFilmsByTitle(title string) ([]models.Film, error) {
 		}
 	}()
 
-	films := []models.Film{}
-	rows, err := tx.Query(transactionCtx, filmQueries.GetFilmsByTitle, &title)
+	err = checkFilmExists(transactionCtx, tx, filmID)
 	if err != nil {
-		return []models.Film{}, err
+		return []models.FilmTranslation{}, err
+	}
+
+	translations := []models.FilmTranslation{}
+	rows, err := tx.Query(transactionCtx, filmQueries.GetFilmTranslations, &filmID)
+	if err != nil {
+		return []models.FilmTranslation{}, err
 	}
 
 	for rows.Next() {
-		film := models.Film{}
-		var releaseDatePG pgtype.Date
-		err := rows.Scan(&film.ID, &film.Title, &film.Description, &releaseDatePG, &film.Rating)
+		translation := models.FilmTranslation{}
+		err := rows.Scan(&translation.Language, &translation.Title, &translation.Description)
 		if err != nil {
-			return []models.Film{}, err
+			return []models.FilmTranslation{}, err
 		}
-		film.ReleaseDate = releaseDatePG.Time.Format(time.DateOnly)
-		films = append(films, film)
+		translations = append(translations, translation)
 	}
 
 	rows.Close()
 	if err := rows.Err(); err != nil {
-		return []models.Film{}, err
+		return []models.FilmTranslation{}, err
 	}
-	return films, nil
+	return translations, nil
 }
 
-func (fR *FilmRepository) GetFilmsByActor(actorName string) ([]models.Film, error) {
+func (fR *FilmRepository) SetFilmTranslation(filmID int, translation *models.FilmTranslation) (*models.FilmTranslation, error) {
+	message := logMessage + "SetFilmTranslation:"
+	log.Debug(message + "started")
 	transactionCtx := context.Background()
 	tx, err := fR.pool.Begin(transactionCtx)
 	if err != nil {
-		return []models.Film{}, err
+		return nil, err
 	}
 
 	defer func() {
@@ -229,26 +442,299 @@ func (fR *FilmRepository) GetFilmsByActor(actorName string) ([]models.Film, erro
 		}
 	}()
 
-	films := []models.Film{}
-	rows, err := tx.Query(transactionCtx, filmQueries.GetFilmsByActor, &actorName)
+	err = checkFilmExists(transactionCtx, tx, filmID)
 	if err != nil {
-		return []models.Film{}, err
+		return nil, err
 	}
 
+	_, err = tx.Exec(transactionCtx, filmQueries.SetFilmTranslation,
+		&filmID, &translation.Language, &translation.Title, &translation.Description)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return translation, nil
+}
+
+func (fR *FilmRepository) DeleteFilmTranslation(filmID int, language string) error {
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, filmQueries.DeleteFilmTranslation, &filmID, &language)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = filmPackage.ErrTranslationNotFound
+		return err
+	}
+	return nil
+}
+
+func checkFilmExists(ctx context.Context, tx pgx.Tx, filmID int) error {
+	var id int
+	row := tx.QueryRow(ctx, filmQueries.GetFilmIDByID, &filmID)
+	err := row.Scan(&id)
+	if err == pgx.ErrNoRows {
+		return filmPackage.ErrNotFound
+	}
+	return err
+}
+
+// localizeFilms заменяет название и описание фильмов переводом на самый предпочтительный
+// из доступных языков. Фильмы без подходящего перевода или с языком оригинала,
+// который клиент предпочитает переводам, остаются на языке оригинала
+func localizeFilms(ctx context.Context, tx pgx.Tx, films []models.Film, languages []string) error {
+	filmIDs := make([]int, 0, len(films))
+	for _, film := range films {
+		filmIDs = append(filmIDs, film.ID)
+	}
+
+	rows, err := tx.Query(ctx, filmQueries.GetTranslationsOfFilms, filmIDs, languages)
+	if err != nil {
+		return err
+	}
+
+	translations := map[int][]models.FilmTranslation{}
 	for rows.Next() {
-		film := models.Film{}
-		var releaseDatePG pgtype.Date
-		err := rows.Scan(&film.ID, &film.Title, &film.Description, &releaseDatePG, &film.Rating)
+		var filmID int
+		translation := models.FilmTranslation{}
+		err := rows.Scan(&filmID, &translation.Language, &translation.Title, &translation.Description)
 		if err != nil {
-			return []models.Film{}, err
+			return err
+		}
+		translations[filmID] = append(translations[filmID], translation)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for index := range films {
+		film := &films[index]
+		for _, language := range languages {
+			if language == film.OriginalLanguage {
+				break
+			}
+			translationIndex := slices.IndexFunc(translations[film.ID], func(translation models.FilmTranslation) bool {
+				return translation.Language == language
+			})
+			if translationIndex == -1 {
+				continue
+			}
+			translation := translations[film.ID][translationIndex]
+			film.OriginalTitle = film.Title
+			film.Language = translation.Language
+			film.Title = translation.Title
+			film.Description = translation.Description
+			break
+		}
+	}
+	return nil
+}
+
+// filmsQuery добавляет к выборке фильмов условия поиска и фильтров
+func filmsQuery(conditions []string, args []any, filter *models.FilmFilter) (string, []any) {
+	// addCondition добавляет условие, во все места $%d которого подставляется номер нового параметра
+	addCondition := func(condition string, value any) {
+		args = append(args, value)
+		indexes := make([]any, strings.Count(condition, "$%d"))
+		for i := range indexes {
+			indexes[i] = len(args)
+		}
+		conditions = append(conditions, fmt.Sprintf(condition, indexes...))
+	}
+
+	if filter != nil {
+		if filter.Director != "" {
+			addCondition(filmQueries.FilmDirectorCondition, filter.Director)
+		}
+		if len(filter.AgeRatings) > 0 {
+			addCondition(filmQueries.FilmAgeRatingCondition, filter.AgeRatings)
+		}
+		if len(filter.Countries) > 0 {
+			addCondition(filmQueries.FilmCountryCondition, filter.Countries)
+		}
+		if filter.Language != "" {
+			addCondition(filmQueries.FilmLanguageCondition, filter.Language)
+		}
+		if filter.MinRuntime > 0 {
+			addCondition(filmQueries.FilmMinRuntimeCondition, filter.MinRuntime)
+		}
+		if filter.MaxRuntime > 0 {
+			addCondition(filmQueries.FilmMaxRuntimeCondition, filter.MaxRuntime)
+		}
+		if filter.WonAward {
+			conditions = append(conditions, filmQueries.FilmWonAwardCondition)
+		}
+		if filter.ReleasedFrom != "" {
+			addCondition(filmQueries.FilmReleasedFromCondition, filter.ReleasedFrom)
+		}
+		if filter.ReleasedTo != "" {
+			addCondition(filmQueries.FilmReleasedToCondition, filter.ReleasedTo)
+		}
+		if filter.ActorID > 0 {
+			addCondition(filmQueries.FilmActorIDCondition, filter.ActorID)
+		}
+		if filter.MinActorAge > 0 || filter.MaxActorAge > 0 {
+			minAge, maxAge := actorAgeBounds(filter)
+			args = append(args, minAge, maxAge)
+			conditions = append(conditions, fmt.Sprintf(filmQueries.FilmActorAgeCondition, len(args)-1, len(args)))
+		}
+		if len(filter.Genres) > 0 {
+			if filter.GenreMode == filmPackage.GenreModeAll {
+				args = append(args, filter.Genres, len(filter.Genres))
+				conditions = append(conditions, fmt.Sprintf(filmQueries.FilmAllGenresCondition, len(args)-1, len(args)))
+			} else {
+				addCondition(filmQueries.FilmAnyGenreCondition, filter.Genres)
+			}
+		}
+	}
+
+	query := filmQueries.GetFilms
+	if len(conditions) > 0 {
+		query += " where " + strings.Join(conditions, " and ")
+	}
+	return query, args
+}
+
+// setFilmGenres заменяет жанры фильма и возвращает их названия в порядке сортировки.
+// Если какого-то жанра нет в базе, возвращается ошибка со списком неизвестных жанров
+func setFilmGenres(ctx context.Context, tx pgx.Tx, filmID int, genres []string) ([]string, error) {
+	rows, err := tx.Query(ctx, genreQueries.GetGenresByNames, &genres)
+	if err != nil {
+		return nil, err
+	}
+
+	genreIDs := map[string]int{}
+	genreNames := []string{}
+	for rows.Next() {
+		var genreID int
+		var genreName string
+		err := rows.Scan(&genreID, &genreName)
+		if err != nil {
+			return nil, err
 		}
-		film.ReleaseDate = releaseDatePG.Time.Format(time.DateOnly)
-		films = append(films, film)
+		genreIDs[genreName] = genreID
+		genreNames = append(genreNames, genreName)
 	}
 
 	rows.Close()
 	if err := rows.Err(); err != nil {
-		return []models.Film{}, err
+		return nil, err
 	}
-	return films, nil
+
+	unknownGenres := []string{}
+	unknownGenreErrors := []models.FieldError{}
+	for index, genre := range genres {
+		if _, ok := genreIDs[genre]; !ok {
+			unknownGenres = append(unknownGenres, genre)
+			unknownGenreErrors = append(unknownGenreErrors, models.FieldError{
+				Field:   fmt.Sprintf("genres[%d]", index),
+				Code:    "unknown_genre",
+				Message: "genre " + genre + " not found",
+			})
+		}
+	}
+	if len(unknownGenres) > 0 {
+		return nil, filmPackage.ErrUnknownGenres.
+			WithMessage("Unknown genres: " + strings.Join(unknownGenres, ", ")).
+			WithFields(unknownGenreErrors)
+	}
+
+	_, err = tx.Exec(ctx, filmQueries.DeleteFilmGenres, &filmID)
+	if err != nil {
+		return nil, err
+	}
+	for _, genreName := range genreNames {
+		genreID := genreIDs[genreName]
+		_, err = tx.Exec(ctx, filmQueries.MakeConnectionFilmWithGenre, &filmID, &genreID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return genreNames, nil
+}
+
+// GetFilmListFlags returns flags of the user lists for the films that are in any of them
+func (fR *FilmRepository) GetFilmListFlags(userID int, filmIDs []int) (map[int]models.FilmListFlags, error) {
+	transactionCtx := context.Background()
+	tx, err := fR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	flags := map[int]models.FilmListFlags{}
+	rows, err := tx.Query(transactionCtx, filmQueries.GetFilmListFlags, &userID, filmIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var filmID int
+		var filmFlags models.FilmListFlags
+		err := rows.Scan(&filmID, &filmFlags.InWatchlist, &filmFlags.Watched)
+		if err != nil {
+			return nil, err
+		}
+		flags[filmID] = filmFlags
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// ScanFilm reads a film selected with queries.FilmColumns. Values of extra
+// columns selected after the film ones are scanned into extra
+func ScanFilm(row pgx.Row, extra ...any) (*models.Film, error) {
+	film := &models.Film{}
+	var releaseDatePG pgtype.Date
+	dest := []any{&film.ID, &film.Title, &film.Description, &releaseDatePG, &film.Rating, &film.Genres,
+		&film.Runtime, &film.Countries, &film.OriginalLanguage, &film.SpokenLanguages, &film.AgeRating,
+		&film.Budget, &film.BoxOffice, &film.Currency, &film.Poster, &film.CommunityRating, &film.VoteCount}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	film.ReleaseDate = releaseDatePG.Time.Format(time.DateOnly)
+	return film, nil
+}
+
+// filmArgs returns arguments of CreateFilm and UpdateFilm queries
+func filmArgs(film *models.FilmRequest) []any {
+	return []any{&film.Title, &film.Description, &film.ReleaseDate, &film.Rating, &film.Runtime,
+		nonNilStrings(film.Countries), &film.OriginalLanguage, nonNilStrings(film.SpokenLanguages),
+		&film.AgeRating, &film.Budget, &film.BoxOffice, &film.Currency}
+}
+
+// nonNilStrings keeps not null array columns from being set to null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
 }
@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"math"
 	"testing"
+	"vk-intern_test-case/internal/film"
 	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/patch"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/pashagolub/pgxmock/v3"
@@ -18,6 +22,27 @@ func prepareTestEnvironment(t *testing.T) (*FilmRepository, pgxmock.PgxPoolIface
 	return testFilmRepo, mock
 }
 
+var filmColumns = []string{"id", "title", "description", "release_date", "rating", "genres", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating", "vote_count"}
+
+// filmRow returns a film row with empty extended metadata
+func filmRow(id int, title, description, releaseDate string, rating int, genres []string) []any {
+	return []any{id, title, description, releaseDate, rating, genres, 0, []string{}, "", []string{}, "",
+		int64(0), int64(0), "", nil, nil, 0}
+}
+
+// changedFields returns patch function which reports fields as changed
+func changedFields(fields map[string]any) func(*models.FilmRequest) (map[string]any, error) {
+	return func(*models.FilmRequest) (map[string]any, error) {
+		return fields, nil
+	}
+}
+
+func expectedFilmArgs(film *models.FilmRequest) []any {
+	return []any{&film.Title, &film.Description, &film.ReleaseDate, &film.Rating, &film.Runtime, []string{},
+		&film.OriginalLanguage, []string{}, &film.AgeRating, &film.Budget, &film.BoxOffice, &film.Currency}
+}
+
 func TestShouldSuccessfullyAddNewFilm(t *testing.T) {
 	filmRepo, mock := prepareTestEnvironment(t)
 	defer mock.Close()
@@ -35,17 +60,17 @@ func TestShouldSuccessfullyAddNewFilm(t *testing.T) {
 	newFilmID := 1
 
 	mock.ExpectBegin()
-	mock.ExpectQuery("insert into film").WithArgs(&newFilm.Title, &newFilm.Description, &newFilm.ReleaseDate, &newFilm.Rating).
+	mock.ExpectQuery("insert into film").WithArgs(expectedFilmArgs(&newFilm.FilmRequest)...).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(newFilmID))
 	for index := range newFilm.Actors {
 		actorID := index + 1
-		mock.ExpectQuery("select id from actor").WithArgs(&newFilm.Actors[index]).
+		mock.ExpectQuery(`select id from person where name = \$1 or \$1 = any\(aliases\)`).WithArgs(&newFilm.Actors[index]).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(actorID))
 		mock.ExpectExec("insert into actor_film").WithArgs(&actorID, &newFilmID).WillReturnResult(pgxmock.NewResult("INSERT", 1))
 	}
 	mock.ExpectCommit()
 
-	resultFilm, err := filmRepo.AddFilm(newFilm)
+	resultFilm, err := filmRepo.AddFilm(newFilm, film.ActorModeSkip)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -73,24 +98,100 @@ func TestShouldSuccessfullyAddNewFilmWithUnknownActors(t *testing.T) {
 	newFilmID := 1
 
 	mock.ExpectBegin()
-	mock.ExpectQuery("insert into film").WithArgs(&newFilm.Title, &newFilm.Description, &newFilm.ReleaseDate, &newFilm.Rating).
+	mock.ExpectQuery("insert into film").WithArgs(expectedFilmArgs(&newFilm.FilmRequest)...).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(newFilmID))
 	for index := range newFilm.Actors {
-		mock.ExpectQuery("select id from actor").WithArgs(&newFilm.Actors[index]).WillReturnError(pgx.ErrNoRows)
+		mock.ExpectQuery(`select id from person where name = \$1 or \$1 = any\(aliases\)`).WithArgs(&newFilm.Actors[index]).WillReturnError(pgx.ErrNoRows)
 	}
 	mock.ExpectCommit()
 
-	resultFilm, err := filmRepo.AddFilm(newFilm)
+	resultFilm, err := filmRepo.AddFilm(newFilm, film.ActorModeSkip)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 
 	assert.Equal(t, 1, resultFilm.ID)
+	assert.Equal(t, newFilm.Actors, resultFilm.Warnings)
 	assert.Equal(t, newFilm.FilmRequest, resultFilm.FilmRequest)
 	assert.Nil(t, err)
 }
 
+func TestShouldFailToAddNewFilmWithUnknownActorsInStrictMode(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newFilm := &models.FilmWithActors{
+		Film: models.Film{
+			FilmRequest: models.FilmRequest{
+				Title:       "film_title",
+				Description: "film_description",
+				ReleaseDate: "06-08-2001",
+				Rating:      8,
+			},
+		},
+		Actors: []string{"Leo Di", "Keanu Rea"},
+	}
+	newFilmID := 1
+	actorID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("insert into film").WithArgs(expectedFilmArgs(&newFilm.FilmRequest)...).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(newFilmID))
+	mock.ExpectQuery(`select id from person where name = \$1 or \$1 = any\(aliases\)`).WithArgs(&newFilm.Actors[0]).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(actorID))
+	mock.ExpectExec("insert into actor_film").WithArgs(&actorID, &newFilmID).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery(`select id from person where name = \$1 or \$1 = any\(aliases\)`).WithArgs(&newFilm.Actors[1]).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilm, err := filmRepo.AddFilm(newFilm, film.ActorModeStrict)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.ErrorIs(t, err, film.ErrUnknownActors)
+	assert.Equal(t, "Unknown actors: Keanu Rea", err.Error())
+}
+
+func TestShouldSuccessfullyAddNewFilmCreatingUnknownActors(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newFilm := &models.FilmWithActors{
+		Film: models.Film{
+			FilmRequest: models.FilmRequest{
+				Title:       "film_title",
+				Description: "film_description",
+				ReleaseDate: "06-08-2001",
+				Rating:      8,
+			},
+		},
+		Actors: []string{"Leo Di"},
+	}
+	newFilmID := 1
+	actorID := 3
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("insert into film").WithArgs(expectedFilmArgs(&newFilm.FilmRequest)...).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(newFilmID))
+	mock.ExpectQuery(`select id from person where name = \$1 or \$1 = any\(aliases\)`).WithArgs(&newFilm.Actors[0]).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectQuery(`insert into person \(name, gender, date_of_birth\) values \(\$1, '', null\)`).WithArgs(&newFilm.Actors[0]).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(actorID))
+	mock.ExpectExec("insert into actor_film").WithArgs(&actorID, &newFilmID).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	resultFilm, err := filmRepo.AddFilm(newFilm, film.ActorModeCreate)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, 1, resultFilm.ID)
+	assert.Equal(t, []string{"Leo Di"}, resultFilm.CreatedActors)
+	assert.Empty(t, resultFilm.Warnings)
+	assert.Nil(t, err)
+}
+
 func TestShouldSuccessfullyUpdateFilm(t *testing.T) {
 	filmRepo, mock := prepareTestEnvironment(t)
 	defer mock.Close()
@@ -106,17 +207,203 @@ func TestShouldSuccessfullyUpdateFilm(t *testing.T) {
 	newFilmID := 1
 
 	mock.ExpectBegin()
-	mock.ExpectExec("update film").WithArgs(&newFilm.Title, &newFilm.Description, &newFilm.ReleaseDate, &newFilm.Rating, &newFilmID).
-		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mock.ExpectQuery("update film").WithArgs(append(expectedFilmArgs(&newFilm.FilmRequest), &newFilmID)...).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(newFilmID, newFilm.Title, newFilm.Description, "2001-08-06", newFilm.Rating, []string{})...))
+	mock.ExpectCommit()
+
+	resultFilm, err := filmRepo.UpdateFilm(newFilmID, newFilm)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, newFilmID, resultFilm.ID)
+	assert.Equal(t, "2001-08-06", resultFilm.ReleaseDate)
+}
+
+func TestShouldReturnNotFoundWhenUpdatingNonExistentFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newFilm := &models.Film{
+		FilmRequest: models.FilmRequest{
+			Title:       "film_title",
+			Description: "film_description",
+			ReleaseDate: "2001-08-06",
+			Rating:      8,
+		},
+	}
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("update film").WithArgs(append(expectedFilmArgs(&newFilm.FilmRequest), &filmID)...).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilm, err := filmRepo.UpdateFilm(filmID, newFilm)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyPatchFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+	newRating := 9
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from film as f where f.id = \\$1 for update of f").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(filmID, "Titanic", "cool", "2001-08-06", 8, []string{"Драма"})...))
+	mock.ExpectQuery(`update film as f set rating = \$1, updated_at = now\(\) where f.id = \$2`).WithArgs(newRating, filmID).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(filmID, "Titanic", "cool", "2001-08-06", newRating, []string{"Драма"})...))
 	mock.ExpectCommit()
 
-	err := filmRepo.UpdateFilm(newFilmID, newFilm)
+	resultFilm, err := filmRepo.PatchFilm(filmID, func(currentFilm *models.FilmRequest) (map[string]any, error) {
+		assert.Equal(t, 8, currentFilm.Rating)
+		return map[string]any{"rating": newRating, "unknown": "value"}, nil
+	})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
 	}
 
 	assert.Nil(t, err)
+	assert.Equal(t, newRating, resultFilm.Rating)
+	assert.Equal(t, "2001-08-06", resultFilm.ReleaseDate)
+}
+
+func TestShouldSuccessfullyPatchFilmGenres(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+	genres := []string{"Мелодрама", "Драма"}
+	genreIDs := []int{1, 3}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from film as f where f.id = \\$1 for update of f").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(filmID, "Titanic", "cool", "2001-08-06", 8, []string{"Комедия"})...))
+	mock.ExpectQuery("select id, name from genre").WithArgs(&genres).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name"}).AddRow(genreIDs[0], "Драма").AddRow(genreIDs[1], "Мелодрама"))
+	mock.ExpectExec("delete from film_genre").WithArgs(&filmID).WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	for index := range genreIDs {
+		mock.ExpectExec("insert into film_genre").WithArgs(&filmID, &genreIDs[index]).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	}
+	mock.ExpectCommit()
+
+	resultFilm, err := filmRepo.PatchFilm(filmID, changedFields(map[string]any{"genres": genres}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Драма", "Мелодрама"}, resultFilm.Genres)
+}
+
+func TestShouldNotUpdateFilmWhenPatchFails(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from film as f where f.id = \\$1 for update of f").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(filmID, "Titanic", "cool", "2001-08-06", 8, []string{})...))
+	mock.ExpectRollback()
+
+	resultFilm, err := filmRepo.PatchFilm(filmID, func(*models.FilmRequest) (map[string]any, error) {
+		return nil, patch.ErrTestFailed
+	})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.Equal(t, patch.ErrTestFailed, err)
+}
+
+func TestShouldFailToPatchNonExistentFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from film as f where f.id = \\$1 for update of f").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilm, err := filmRepo.PatchFilm(filmID, changedFields(map[string]any{"rating": 9}))
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldFailToUpdateFilmWithUnknownGenres(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newFilm := &models.Film{
+		FilmRequest: models.FilmRequest{
+			Title:       "film_title",
+			Description: "film_description",
+			ReleaseDate: "2001-08-06",
+			Rating:      8,
+			Genres:      []string{"Драма", "Аниме"},
+		},
+	}
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("update film").WithArgs(append(expectedFilmArgs(&newFilm.FilmRequest), &filmID)...).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(filmID, newFilm.Title, newFilm.Description, "2001-08-06", newFilm.Rating, []string{})...))
+	mock.ExpectQuery("select id, name from genre").WithArgs(&newFilm.Genres).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name"}).AddRow(1, "Драма"))
+	mock.ExpectRollback()
+
+	resultFilm, err := filmRepo.UpdateFilm(filmID, newFilm)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.ErrorIs(t, err, film.ErrUnknownGenres)
+	assert.Equal(t, []models.FieldError{
+		{Field: "genres[1]", Code: "unknown_genre", Message: "genre Аниме not found"},
+	}, err.(*apperror.Error).Fields)
+}
+
+func TestShouldReturnNotFoundWhenGettingNonExistentFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select f.id, f.title").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilm, err := filmRepo.GetFilm(filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.Equal(t, film.ErrNotFound, err)
 }
 
 func TestShouldSuccessfullyDeleteFilm(t *testing.T) {
@@ -138,6 +425,25 @@ func TestShouldSuccessfullyDeleteFilm(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestShouldReturnNotFoundWhenDeletingNonExistentFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from film").WithArgs(&filmID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := filmRepo.DeleteFilm(filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
 func TestShouldSuccessfullyReturnFilmsSortedByRating(t *testing.T) {
 	filmRepo, mock := prepareTestEnvironment(t)
 	defer mock.Close()
@@ -145,13 +451,13 @@ func TestShouldSuccessfullyReturnFilmsSortedByRating(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("select").
-		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
-			AddRow(1, "Titanic", "cool", "2001-08-06", 8).
-			AddRow(2, "Titanic 2", "not cool", "2001-08-06", 7)).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Titanic", "cool", "2001-08-06", 8, []string{"Драма", "Мелодрама"})...).
+			AddRow(filmRow(2, "Titanic 2", "not cool", "2001-08-06", 7, []string{})...)).
 		RowsWillBeClosed()
 	mock.ExpectCommit()
 
-	resultFilms, err := filmRepo.GetFilmsSorted(field)
+	resultFilms, err := filmRepo.GetFilmsSorted(field, &models.FilmFilter{GenreMode: film.GenreModeAny})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -160,6 +466,32 @@ func TestShouldSuccessfullyReturnFilmsSortedByRating(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestShouldSuccessfullyReturnFilmsSortedByCommunityRating(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	rated := filmRow(1, "Titanic", "cool", "1997-12-19", 8, []string{})
+	communityRating := 8.5
+	rated[15], rated[16] = &communityRating, 120
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`order by \(select \(coalesce\(sum\(r.rating\), 0\) \+ 10 \* \(select avg\(rating\) from review where status = 'published'\)\)\s+/ \(count\(\*\) \+ 10\)`).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(rated...).
+			AddRow(filmRow(2, "Titanic 2", "not cool", "2001-08-06", 7, []string{})...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsSorted("community_rating", nil)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, 8.5, *resultFilms[0].CommunityRating)
+	assert.Equal(t, 120, resultFilms[0].VoteCount)
+	assert.Nil(t, resultFilms[1].CommunityRating)
+}
+
 func TestShouldSuccessfullyReturnFilmsByTitle(t *testing.T) {
 	filmRepo, mock := prepareTestEnvironment(t)
 	defer mock.Close()
@@ -167,13 +499,13 @@ func TestShouldSuccessfullyReturnFilmsByTitle(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("select").WithArgs(&title).
-		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
-			AddRow(1, "Titanic", "cool", "2001-08-06", 8).
-			AddRow(2, "Titanic 2", "not cool", "2001-08-06", 7)).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Titanic", "cool", "2001-08-06", 8, []string{"Драма", "Мелодрама"})...).
+			AddRow(filmRow(2, "Titanic 2", "not cool", "2001-08-06", 7, []string{})...)).
 		RowsWillBeClosed()
 	mock.ExpectCommit()
 
-	resultFilms, err := filmRepo.GetFilmsByTitle(title)
+	resultFilms, err := filmRepo.GetFilmsByTitle(title, &models.FilmFilter{GenreMode: film.GenreModeAny})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -189,13 +521,13 @@ func TestShouldSuccessfullyReturnFilmsByActor(t *testing.T) {
 
 	mock.ExpectBegin()
 	mock.ExpectQuery("select").WithArgs(&actor).
-		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating"}).
-			AddRow(1, "Titanic", "cool", "2001-08-06", 8).
-			AddRow(2, "Titanic 2", "not cool", "2001-08-06", 7)).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Titanic", "cool", "2001-08-06", 8, []string{"Драма", "Мелодрама"})...).
+			AddRow(filmRow(2, "Titanic 2", "not cool", "2001-08-06", 7, []string{})...)).
 		RowsWillBeClosed()
 	mock.ExpectCommit()
 
-	resultFilms, err := filmRepo.GetFilmsByActor(actor)
+	resultFilms, err := filmRepo.GetFilmsByActor(actor, &models.FilmFilter{GenreMode: film.GenreModeAny})
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("there were unfulfilled expectations: %s", err)
@@ -203,3 +535,271 @@ func TestShouldSuccessfullyReturnFilmsByActor(t *testing.T) {
 	assert.Equal(t, 2, len(resultFilms))
 	assert.Nil(t, err)
 }
+
+func TestShouldApplyActorAgeToFoundActor(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	actor := "Leo"
+	filter := &models.FilmFilter{GenreMode: film.GenreModeAny, MaxActorAge: 24}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`like lower\(\$1\) (.+) age\(f.release_date, a.date_of_birth\)\) between \$2 and \$3\)$`).
+		WithArgs(&actor, 0, 24).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Titanic", "cool", "1997-12-19", 8, []string{"Драма"})...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsByActor(actor, filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Equal(t, 1, len(resultFilms))
+	assert.Nil(t, err)
+}
+
+func TestShouldSuccessfullyReturnFilmsByActorAge(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.FilmFilter{GenreMode: film.GenreModeAny, MinActorAge: 60}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`where af.film_id = f.id and extract\(year from age\(f.release_date, a.date_of_birth\)\) between \$1 and \$2\)`).
+		WithArgs(60, math.MaxInt32).
+		WillReturnRows(pgxmock.NewRows(filmColumns)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsSorted("rating", filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Empty(t, resultFilms)
+	assert.Nil(t, err)
+}
+
+func TestShouldSuccessfullyReturnFilmsWithAllOfGenres(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.FilmFilter{Genres: []string{"Драма", "Мелодрама"}, GenreMode: film.GenreModeAll}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`select count\(distinct g.name\) (.+) = any\(\$1\)\) = \$2 order by f.release_date`).
+		WithArgs(filter.Genres, len(filter.Genres)).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Titanic", "cool", "2001-08-06", 8, []string{"Драма", "Мелодрама"})...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsSorted("release_date", filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Драма", "Мелодрама"}, resultFilms[0].Genres)
+}
+
+func TestShouldSuccessfullyReturnFilmsByTitleAndDirector(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	title := "Tit"
+	filter := &models.FilmFilter{Director: "Кэмерон", GenreMode: film.GenreModeAny}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`like lower\(\$1\) (.+) fc.role = 'director' and lower\(p.name\) like lower\(\$2\)`).
+		WithArgs(&title, filter.Director).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Titanic", "cool", "2001-08-06", 8, []string{"Драма", "Мелодрама"})...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsByTitle(title, filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Equal(t, 1, len(resultFilms))
+	assert.Nil(t, err)
+}
+
+func TestShouldSuccessfullyReturnFilmsByMetadataFilter(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.FilmFilter{
+		GenreMode:  film.GenreModeAny,
+		AgeRatings: []string{"0+", "6+", "12+"},
+		Countries:  []string{"RU"},
+		Language:   "ru",
+		MaxRuntime: 120,
+	}
+	row := filmRow(1, "Брат", "cool", "1997-12-12", 8, []string{"Драма"})
+	row[6], row[7], row[8], row[10] = 100, []string{"RU"}, "ru", "12+"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`f.age_rating = any\(\$1\) and f.countries && \$2 and `+
+		`\(f.original_language = \$3 or f.spoken_languages @> array\[\$3\]\) and f.runtime > 0 and f.runtime <= \$4`).
+		WithArgs(filter.AgeRatings, filter.Countries, filter.Language, filter.MaxRuntime).
+		WillReturnRows(pgxmock.NewRows(filmColumns).AddRow(row...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsSorted("rating", filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, 100, resultFilms[0].Runtime)
+	assert.Equal(t, []string{"RU"}, resultFilms[0].Countries)
+	assert.Equal(t, "12+", resultFilms[0].AgeRating)
+}
+
+func TestShouldSuccessfullyReturnFilmsByReleaseFilter(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.FilmFilter{
+		GenreMode:    film.GenreModeAny,
+		ReleasedFrom: "2030-01-01",
+		ReleasedTo:   "2030-12-31",
+		ActorID:      5,
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`f.release_date >= \$1::date and f.release_date <= \$2::date and `+
+		`exists \(select 1 from actor_film as af where af.film_id = f.id and af.actor_id = \$3\) order by f.release_date`).
+		WithArgs(filter.ReleasedFrom, filter.ReleasedTo, filter.ActorID).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Аватар 4", "", "2030-12-19", 0, []string{"Фантастика"})...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsSorted("release_date", filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, "2030-12-19", resultFilms[0].ReleaseDate)
+}
+
+func TestShouldSuccessfullyReturnLocalizedFilms(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	title := "Тит"
+	filter := &models.FilmFilter{GenreMode: film.GenreModeAny, Languages: []string{"ru", "en"}}
+	titanic := filmRow(1, "Titanic", "cool", "1997-12-19", 8, []string{})
+	titanic[8] = "en"
+	brother := filmRow(2, "Брат", "круто", "1997-12-12", 8, []string{})
+	brother[8] = "ru"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`lower\(f.title\) like lower\(\$1\) (.+) lower\(ft.title\) like lower\(\$1\)`).WithArgs(&title).
+		WillReturnRows(pgxmock.NewRows(filmColumns).AddRow(titanic...).AddRow(brother...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("select film_id, language, title, description from film_translation").
+		WithArgs([]int{1, 2}, filter.Languages).
+		WillReturnRows(pgxmock.NewRows([]string{"film_id", "language", "title", "description"}).
+			AddRow(1, "ru", "Титаник", "классно").
+			AddRow(2, "en", "Brother", "cool")).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := filmRepo.GetFilmsByTitle(title, filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, "Титаник", resultFilms[0].Title)
+	assert.Equal(t, "Titanic", resultFilms[0].OriginalTitle)
+	assert.Equal(t, "ru", resultFilms[0].Language)
+	assert.Equal(t, "Брат", resultFilms[1].Title)
+	assert.Empty(t, resultFilms[1].OriginalTitle)
+}
+
+func TestShouldSuccessfullySetFilmTranslation(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+	translation := &models.FilmTranslation{
+		Language:               "ru",
+		FilmTranslationRequest: models.FilmTranslationRequest{Title: "Титаник", Description: "классно"},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectExec("insert into film_translation").
+		WithArgs(&filmID, &translation.Language, &translation.Title, &translation.Description).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	resultTranslation, err := filmRepo.SetFilmTranslation(filmID, translation)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, translation, resultTranslation)
+}
+
+func TestShouldReturnNotFoundWhenSettingTranslationOfNonExistentFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultTranslation, err := filmRepo.SetFilmTranslation(filmID, &models.FilmTranslation{Language: "ru"})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, resultTranslation)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldReturnNotFoundWhenSettingPosterOfNonExistentFilm(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+	poster := &models.Image{Original: "/media/films/1/poster/1/original.png"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec("update film set poster").WithArgs(poster, &filmID).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mock.ExpectRollback()
+
+	err := filmRepo.SetFilmPoster(filmID, poster)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyGetFilmListFlags(t *testing.T) {
+	filmRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID := 1
+	filmIDs := []int{1, 2}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from user_film_list where user_id = \\$1 and film_id = any\\(\\$2\\)").WithArgs(&userID, filmIDs).
+		WillReturnRows(pgxmock.NewRows([]string{"film_id", "in_watchlist", "watched"}).AddRow(2, true, false)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFlags, err := filmRepo.GetFilmListFlags(userID, filmIDs)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Nil(t, err)
+	assert.Equal(t, map[int]models.FilmListFlags{2: {InWatchlist: true}}, resultFlags)
+}
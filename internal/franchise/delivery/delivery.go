@@ -0,0 +1,409 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"vk-intern_test-case/internal/franchise"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "franchise:delivery:"
+
+type FranchiseDelivery struct {
+	franchiseRepo franchise.FranchiseRepository
+}
+
+func NewFranchiseDelivery(frR franchise.FranchiseRepository) *FranchiseDelivery {
+	return &FranchiseDelivery{
+		franchiseRepo: frR,
+	}
+}
+
+func (frD *FranchiseDelivery) HandleFranchises(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.TrimPrefix(r.URL.Path, "/franchises") == "" {
+			frD.GetFranchises(w, r)
+			return
+		}
+		frD.GetFranchise(w, r)
+	case http.MethodPost:
+		frD.AddFranchise(w, r)
+	case http.MethodPut:
+		frD.UpdateFranchise(w, r)
+	case http.MethodDelete:
+		frD.DeleteFranchise(w, r)
+	}
+}
+
+// HandleFranchiseFilms handles /franchises/{id}/films and /franchises/{id}/films/{film_id}
+func (frD *FranchiseDelivery) HandleFranchiseFilms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		frD.AddFranchiseFilm(w, r)
+	case http.MethodDelete:
+		frD.DeleteFranchiseFilm(w, r)
+	}
+}
+
+// HandleFilmRelations handles /films/{id}/relations and /films/{id}/relations/{film_id}
+func (frD *FranchiseDelivery) HandleFilmRelations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		frD.AddFilmRelation(w, r)
+	case http.MethodDelete:
+		frD.DeleteFilmRelation(w, r)
+	}
+}
+
+// HandleRelatedFilms handles /films/{id}/related
+func (frD *FranchiseDelivery) HandleRelatedFilms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		frD.GetRelatedFilms(w, r)
+	}
+}
+
+// swagger:route POST /franchises Franchises addFranchise
+// Добавляет франшизу в систему
+// security:
+// - key:
+// responses:
+//
+//	200: franchise
+//	400: problemResponse
+//  401: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) AddFranchise(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddFranchise:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	var newFranchise models.FranchiseRequest
+	err := json.NewDecoder(r.Body).Decode(&newFranchise)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateFranchise(&newFranchise).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFranchise, err := frD.franchiseRepo.AddFranchise(&newFranchise)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFranchise)
+}
+
+// swagger:route GET /franchises/{id} Franchises getFranchise
+// Возвращает франшизу с фильмами в хронологическом порядке
+// responses:
+//
+//	200: franchiseWithFilms
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) GetFranchise(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/franchises/")
+	franchiseID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultFranchise, err := frD.franchiseRepo.GetFranchise(franchiseID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFranchise)
+}
+
+// swagger:route GET /franchises Franchises getFranchises
+// Возвращает список франшиз, отсортированный по названию
+// responses:
+//
+//	200: []franchise
+//	500: problemResponse
+func (frD *FranchiseDelivery) GetFranchises(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+
+	resultFranchises, err := frD.franchiseRepo.GetFranchises()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFranchises)
+}
+
+// swagger:route PUT /franchises/{id} Franchises updateFranchise
+// Изменяет название и описание франшизы. Возвращает франшизу после изменения.
+// security:
+// - key:
+// responses:
+//
+//	200: franchise
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) UpdateFranchise(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/franchises/")
+	franchiseID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var franchiseRequest models.FranchiseRequest
+	err = json.NewDecoder(r.Body).Decode(&franchiseRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateFranchise(&franchiseRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFranchise, err := frD.franchiseRepo.UpdateFranchise(franchiseID, &franchiseRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFranchise)
+}
+
+// swagger:route DELETE /franchises/{id} Franchises deleteFranchise
+// Удаляет франшизу из системы. Фильмы франшизы остаются
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) DeleteFranchise(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/franchises/")
+	franchiseID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	err = frD.franchiseRepo.DeleteFranchise(franchiseID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route POST /franchises/{id}/films Franchises addFranchiseFilm
+// Добавляет фильм во франшизу. Повторное добавление ничего не меняет
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) AddFranchiseFilm(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddFranchiseFilm:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/franchises/")
+	franchiseID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var franchiseFilm models.FranchiseFilmRequest
+	err = json.NewDecoder(r.Body).Decode(&franchiseFilm)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateFranchiseFilm(&franchiseFilm).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = frD.franchiseRepo.AddFranchiseFilm(franchiseID, franchiseFilm.FilmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route DELETE /franchises/{id}/films/{film_id} Franchises deleteFranchiseFilm
+// Убирает фильм из франшизы
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) DeleteFranchiseFilm(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	franchiseID, filmID, err := parseNestedIDs(r.URL.Path, "/franchises/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = frD.franchiseRepo.DeleteFranchiseFilm(franchiseID, filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route GET /films/{id}/related Franchises getRelatedFilms
+// Возвращает фильмы, связанные с фильмом цепочкой сиквелов, приквелов, ремейков и спин-оффов
+// или общей франшизой, в хронологическом порядке. Для прямых связей заполняется relation
+// responses:
+//
+//	200: []relatedFilm
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) GetRelatedFilms(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultFilms, err := frD.franchiseRepo.GetRelatedFilms(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilms)
+}
+
+// swagger:route POST /films/{id}/relations Franchises addFilmRelation
+// Связывает фильмы: film_id из тела запроса становится сиквелом, приквелом, ремейком
+// или спин-оффом фильма из пути. Прежняя связь между этими фильмами заменяется
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) AddFilmRelation(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddFilmRelation:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var relation models.FilmRelationRequest
+	err = json.NewDecoder(r.Body).Decode(&relation)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateFilmRelation(filmID, &relation).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = frD.franchiseRepo.AddFilmRelation(filmID, &relation)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route DELETE /films/{id}/relations/{film_id} Franchises deleteFilmRelation
+// Удаляет связь между фильмами, в какую бы сторону она ни была записана
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (frD *FranchiseDelivery) DeleteFilmRelation(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	filmID, relatedFilmID, err := parseNestedIDs(r.URL.Path, "/films/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = frD.franchiseRepo.DeleteFilmRelation(filmID, relatedFilmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// parseNestedIDs returns ids from path like prefix{id}/{subresource}/{nested_id}
+func parseNestedIDs(path string, prefix string) (int, int, error) {
+	segments := router.PathSegments(path, prefix)
+	if len(segments) != 3 {
+		return 0, 0, apperror.ErrInvalidID
+	}
+	id, err := strconv.Atoi(segments[0])
+	if err != nil {
+		return 0, 0, apperror.ErrInvalidID
+	}
+	nestedID, err := strconv.Atoi(segments[2])
+	if err != nil {
+		return 0, 0, apperror.ErrInvalidID
+	}
+	return id, nestedID, nil
+}
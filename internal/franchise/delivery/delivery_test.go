@@ -0,0 +1,406 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/franchise"
+	"vk-intern_test-case/internal/franchise/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type franchiseTest struct {
+	name               string
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockFranchiseRepository *mock.MockFranchiseRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var matrixFranchise = models.Franchise{ID: 1, FranchiseRequest: models.FranchiseRequest{Name: "Матрица"}}
+
+var matrix = models.Film{
+	ID: 1,
+	FilmRequest: models.FilmRequest{
+		Title:       "Матрица",
+		Description: "Cool film",
+		ReleaseDate: "1999-03-31",
+		Rating:      9,
+	},
+}
+
+var matrixReloaded = models.Film{
+	ID: 2,
+	FilmRequest: models.FilmRequest{
+		Title:       "Матрица: Перезагрузка",
+		Description: "Cool sequel",
+		ReleaseDate: "2003-05-15",
+		Rating:      8,
+	},
+}
+
+var franchiseTests = []franchiseTest{
+	{
+		"Successfully add new Franchise",
+		http.MethodPost,
+		"/franchises",
+		`{"name": "Матрица"}`,
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				AddFranchise(&models.FranchiseRequest{Name: "Матрица"}).
+				Return(&matrixFranchise, nil)
+		},
+		`{"id": 1, "name": "Матрица", "description": ""}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to add Franchise without name",
+		http.MethodPost,
+		"/franchises",
+		`{"description": "Трилогия"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/franchises",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "name", "code": "required", "message": "name is required"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to add an existing Franchise",
+		http.MethodPost,
+		"/franchises",
+		`{"name": "Матрица"}`,
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				AddFranchise(&models.FranchiseRequest{Name: "Матрица"}).
+				Return(nil, database.ErrAlreadyExists)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Conflict",
+			"status": 409,
+			"detail": "Resource already exists",
+			"instance": "/franchises",
+			"code": "already_exists"
+		}`,
+		http.StatusConflict,
+	},
+	{
+		"Successfully get Franchises",
+		http.MethodGet,
+		"/franchises",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				GetFranchises().
+				Return([]models.Franchise{matrixFranchise}, nil)
+		},
+		`[{"id": 1, "name": "Матрица", "description": ""}]`,
+		http.StatusOK,
+	},
+	{
+		"Successfully get a Franchise with films",
+		http.MethodGet,
+		"/franchises/1",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				GetFranchise(1).
+				Return(&models.FranchiseWithFilms{Franchise: matrixFranchise, Films: []models.Film{matrix}}, nil)
+		},
+		`{
+			"id": 1,
+			"name": "Матрица",
+			"description": "",
+			"films": [
+				{"id": 1, "title": "Матрица", "description": "Cool film", "release_date": "1999-03-31", "rating": 9}
+			]
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a non-existent Franchise",
+		http.MethodGet,
+		"/franchises/5",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				GetFranchise(5).
+				Return(nil, franchise.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Franchise not found",
+			"instance": "/franchises/5",
+			"code": "franchise_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully delete a Franchise",
+		http.MethodDelete,
+		"/franchises/1",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				DeleteFranchise(1).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+}
+
+func TestHandleFranchises(t *testing.T) {
+	runFranchiseTests(t, franchiseTests, func(franchiseDelivery *FranchiseDelivery) http.HandlerFunc {
+		return franchiseDelivery.HandleFranchises
+	})
+}
+
+var franchiseFilmTests = []franchiseTest{
+	{
+		"Successfully add a Film into Franchise",
+		http.MethodPost,
+		"/franchises/1/films",
+		`{"film_id": 2}`,
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				AddFranchiseFilm(1, 2).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to add an unknown Film into Franchise",
+		http.MethodPost,
+		"/franchises/1/films",
+		`{"film_id": 42}`,
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				AddFranchiseFilm(1, 42).
+				Return(franchise.ErrUnknownFilm.WithFields([]models.FieldError{{
+					Field:   "film_id",
+					Code:    "unknown_film",
+					Message: "film not found",
+				}}))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Unknown film",
+			"instance": "/franchises/1/films",
+			"code": "unknown_film",
+			"errors": [
+				{"field": "film_id", "code": "unknown_film", "message": "film not found"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to remove a Film that is not in Franchise",
+		http.MethodDelete,
+		"/franchises/1/films/3",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				DeleteFranchiseFilm(1, 3).
+				Return(franchise.ErrFranchiseFilmNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film is not in the franchise",
+			"instance": "/franchises/1/films/3",
+			"code": "franchise_film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleFranchiseFilms(t *testing.T) {
+	runFranchiseTests(t, franchiseFilmTests, func(franchiseDelivery *FranchiseDelivery) http.HandlerFunc {
+		return franchiseDelivery.HandleFranchiseFilms
+	})
+}
+
+var filmRelationTests = []franchiseTest{
+	{
+		"Successfully add a Film relation",
+		http.MethodPost,
+		"/films/1/relations",
+		`{"film_id": 2, "type": "sequel"}`,
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				AddFilmRelation(1, &models.FilmRelationRequest{FilmID: 2, Type: "sequel"}).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to relate a Film to itself with unknown type",
+		http.MethodPost,
+		"/films/1/relations",
+		`{"film_id": 1, "type": "reboot"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/films/1/relations",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "film_id", "code": "not_allowed", "message": "film can not be related to itself"},
+				{"field": "type", "code": "not_allowed", "message": "type must be one of: sequel, prequel, remake, spin_off"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Successfully delete a Film relation",
+		http.MethodDelete,
+		"/films/2/relations/1",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				DeleteFilmRelation(2, 1).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to delete a Film relation without film id",
+		http.MethodDelete,
+		"/films/2/relations",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid id in request path",
+			"instance": "/films/2/relations",
+			"code": "invalid_id"
+		}`,
+		http.StatusBadRequest,
+	},
+}
+
+func TestHandleFilmRelations(t *testing.T) {
+	runFranchiseTests(t, filmRelationTests, func(franchiseDelivery *FranchiseDelivery) http.HandlerFunc {
+		return franchiseDelivery.HandleFilmRelations
+	})
+}
+
+var relatedFilmsTests = []franchiseTest{
+	{
+		"Successfully get related Films",
+		http.MethodGet,
+		"/films/1/related",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				GetRelatedFilms(1).
+				Return([]models.RelatedFilm{{Film: matrixReloaded, Relation: "sequel"}}, nil)
+		},
+		`[{
+			"id": 2,
+			"title": "Матрица: Перезагрузка",
+			"description": "Cool sequel",
+			"release_date": "2003-05-15",
+			"rating": 8,
+			"relation": "sequel"
+		}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get related Films of non-existent Film",
+		http.MethodGet,
+		"/films/7/related",
+		"",
+		func(mockFranchiseRepository *mock.MockFranchiseRepository) {
+			mockFranchiseRepository.EXPECT().
+				GetRelatedFilms(7).
+				Return([]models.RelatedFilm{}, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/7/related",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleRelatedFilms(t *testing.T) {
+	runFranchiseTests(t, relatedFilmsTests, func(franchiseDelivery *FranchiseDelivery) http.HandlerFunc {
+		return franchiseDelivery.HandleRelatedFilms
+	})
+}
+
+func runFranchiseTests(t *testing.T, tests []franchiseTest, handler func(*FranchiseDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockFranchiseRepository := mock.NewMockFranchiseRepository(ctrl)
+			franchiseDeliveryTest := NewFranchiseDelivery(mockFranchiseRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockFranchiseRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+
+			handler(franchiseDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
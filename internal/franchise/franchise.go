@@ -0,0 +1,10 @@
+package franchise
+
+import "vk-intern_test-case/utils/apperror"
+
+var (
+	ErrNotFound              = apperror.NotFound("franchise_not_found", "Franchise not found")
+	ErrFranchiseFilmNotFound = apperror.NotFound("franchise_film_not_found", "Film is not in the franchise")
+	ErrRelationNotFound      = apperror.NotFound("film_relation_not_found", "Film relation not found")
+	ErrUnknownFilm           = apperror.Validation("unknown_film", "Unknown film", nil)
+)
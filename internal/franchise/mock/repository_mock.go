@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/franchise/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockFranchiseRepository is a mock of FranchiseRepository interface.
+type MockFranchiseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFranchiseRepositoryMockRecorder
+}
+
+// MockFranchiseRepositoryMockRecorder is the mock recorder for MockFranchiseRepository.
+type MockFranchiseRepositoryMockRecorder struct {
+	mock *MockFranchiseRepository
+}
+
+// NewMockFranchiseRepository creates a new mock instance.
+func NewMockFranchiseRepository(ctrl *gomock.Controller) *MockFranchiseRepository {
+	mock := &MockFranchiseRepository{ctrl: ctrl}
+	mock.recorder = &MockFranchiseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFranchiseRepository) EXPECT() *MockFranchiseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddFilmRelation mocks base method.
+func (m *MockFranchiseRepository) AddFilmRelation(arg0 int, arg1 *models.FilmRelationRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFilmRelation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFilmRelation indicates an expected call of AddFilmRelation.
+func (mr *MockFranchiseRepositoryMockRecorder) AddFilmRelation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFilmRelation", reflect.TypeOf((*MockFranchiseRepository)(nil).AddFilmRelation), arg0, arg1)
+}
+
+// AddFranchise mocks base method.
+func (m *MockFranchiseRepository) AddFranchise(arg0 *models.FranchiseRequest) (*models.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFranchise", arg0)
+	ret0, _ := ret[0].(*models.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddFranchise indicates an expected call of AddFranchise.
+func (mr *MockFranchiseRepositoryMockRecorder) AddFranchise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFranchise", reflect.TypeOf((*MockFranchiseRepository)(nil).AddFranchise), arg0)
+}
+
+// AddFranchiseFilm mocks base method.
+func (m *MockFranchiseRepository) AddFranchiseFilm(franchiseID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFranchiseFilm", franchiseID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddFranchiseFilm indicates an expected call of AddFranchiseFilm.
+func (mr *MockFranchiseRepositoryMockRecorder) AddFranchiseFilm(franchiseID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFranchiseFilm", reflect.TypeOf((*MockFranchiseRepository)(nil).AddFranchiseFilm), franchiseID, filmID)
+}
+
+// DeleteFilmRelation mocks base method.
+func (m *MockFranchiseRepository) DeleteFilmRelation(filmID, relatedFilmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilmRelation", filmID, relatedFilmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilmRelation indicates an expected call of DeleteFilmRelation.
+func (mr *MockFranchiseRepositoryMockRecorder) DeleteFilmRelation(filmID, relatedFilmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilmRelation", reflect.TypeOf((*MockFranchiseRepository)(nil).DeleteFilmRelation), filmID, relatedFilmID)
+}
+
+// DeleteFranchise mocks base method.
+func (m *MockFranchiseRepository) DeleteFranchise(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFranchise", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFranchise indicates an expected call of DeleteFranchise.
+func (mr *MockFranchiseRepositoryMockRecorder) DeleteFranchise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFranchise", reflect.TypeOf((*MockFranchiseRepository)(nil).DeleteFranchise), arg0)
+}
+
+// DeleteFranchiseFilm mocks base method.
+func (m *MockFranchiseRepository) DeleteFranchiseFilm(franchiseID, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFranchiseFilm", franchiseID, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFranchiseFilm indicates an expected call of DeleteFranchiseFilm.
+func (mr *MockFranchiseRepositoryMockRecorder) DeleteFranchiseFilm(franchiseID, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFranchiseFilm", reflect.TypeOf((*MockFranchiseRepository)(nil).DeleteFranchiseFilm), franchiseID, filmID)
+}
+
+// GetFranchise mocks base method.
+func (m *MockFranchiseRepository) GetFranchise(arg0 int) (*models.FranchiseWithFilms, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFranchise", arg0)
+	ret0, _ := ret[0].(*models.FranchiseWithFilms)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFranchise indicates an expected call of GetFranchise.
+func (mr *MockFranchiseRepositoryMockRecorder) GetFranchise(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFranchise", reflect.TypeOf((*MockFranchiseRepository)(nil).GetFranchise), arg0)
+}
+
+// GetFranchises mocks base method.
+func (m *MockFranchiseRepository) GetFranchises() ([]models.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFranchises")
+	ret0, _ := ret[0].([]models.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFranchises indicates an expected call of GetFranchises.
+func (mr *MockFranchiseRepositoryMockRecorder) GetFranchises() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFranchises", reflect.TypeOf((*MockFranchiseRepository)(nil).GetFranchises))
+}
+
+// GetRelatedFilms mocks base method.
+func (m *MockFranchiseRepository) GetRelatedFilms(arg0 int) ([]models.RelatedFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelatedFilms", arg0)
+	ret0, _ := ret[0].([]models.RelatedFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelatedFilms indicates an expected call of GetRelatedFilms.
+func (mr *MockFranchiseRepositoryMockRecorder) GetRelatedFilms(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelatedFilms", reflect.TypeOf((*MockFranchiseRepository)(nil).GetRelatedFilms), arg0)
+}
+
+// UpdateFranchise mocks base method.
+func (m *MockFranchiseRepository) UpdateFranchise(arg0 int, arg1 *models.FranchiseRequest) (*models.Franchise, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFranchise", arg0, arg1)
+	ret0, _ := ret[0].(*models.Franchise)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFranchise indicates an expected call of UpdateFranchise.
+func (mr *MockFranchiseRepositoryMockRecorder) UpdateFranchise(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFranchise", reflect.TypeOf((*MockFranchiseRepository)(nil).UpdateFranchise), arg0, arg1)
+}
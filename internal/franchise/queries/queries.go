@@ -0,0 +1,58 @@
+package queries
+
+import filmQueries "vk-intern_test-case/internal/film/queries"
+
+const (
+	CreateFranchise = `insert into franchise (name, description) values ($1, $2)
+		returning id, name, description;`
+	GetFranchiseByID   = `select id, name, description from franchise where id = $1;`
+	GetFranchiseIDByID = `select id from franchise where id = $1;`
+	GetFranchises      = `select id, name, description from franchise order by name;`
+	UpdateFranchise    = `update franchise set name = $1, description = $2 where id = $3
+		returning id, name, description;`
+	DeleteFranchise   = `delete from franchise where id = $1;`
+	GetFranchiseFilms = `select ` + filmQueries.FilmColumns + `
+		from film as f
+		join franchise_film as ff on f.id = ff.film_id
+		where ff.franchise_id = $1
+		order by f.release_date, f.id;`
+	AddFranchiseFilm = `insert into franchise_film (franchise_id, film_id) values ($1, $2)
+		on conflict do nothing;`
+	DeleteFranchiseFilm = `delete from franchise_film where franchise_id = $1 and film_id = $2;`
+
+	GetFilmIDByID = `select id from film where id = $1;`
+	// Связь хранится в одну сторону: related_film_id приходится фильму film_id тем, что указано в type
+	AddFilmRelation    = `insert into film_relation (film_id, related_film_id, type) values ($1, $2, $3);`
+	DeleteFilmRelation = `delete from film_relation
+		where (film_id = $1 and related_film_id = $2) or (film_id = $2 and related_film_id = $1);`
+	// GetRelatedFilms обходит связи фильмов в обе стороны и добавляет фильмы из тех же франшиз.
+	// relation заполняется только для прямых связей и описывает фильм с точки зрения запрошенного
+	GetRelatedFilms = `with recursive chain (film_id) as (
+			select $1::int
+			union
+			select case when fr.film_id = c.film_id then fr.related_film_id else fr.film_id end
+			from film_relation as fr
+			join chain as c on c.film_id in (fr.film_id, fr.related_film_id)
+		), related (film_id) as (
+			select film_id from chain
+			union
+			select ff.film_id from franchise_film as ff
+			where ff.franchise_id in (select franchise_id from franchise_film where film_id = $1)
+		), direct (film_id, relation) as (
+			select related_film_id, type from film_relation where film_id = $1
+			union all
+			select film_id, case type
+				when 'sequel' then 'prequel'
+				when 'prequel' then 'sequel'
+				when 'remake' then 'original'
+				when 'spin_off' then 'parent'
+			end
+			from film_relation where related_film_id = $1
+		)
+		select ` + filmQueries.FilmColumns + `, coalesce(d.relation, '')
+		from related as r
+		join film as f on f.id = r.film_id
+		left join direct as d on d.film_id = f.id
+		where f.id <> $1
+		order by f.release_date, f.id;`
+)
@@ -0,0 +1,16 @@
+package franchise
+
+import "vk-intern_test-case/models"
+
+type FranchiseRepository interface {
+	AddFranchise(*models.FranchiseRequest) (*models.Franchise, error)
+	GetFranchise(int) (*models.FranchiseWithFilms, error)
+	GetFranchises() ([]models.Franchise, error)
+	UpdateFranchise(int, *models.FranchiseRequest) (*models.Franchise, error)
+	DeleteFranchise(int) error
+	AddFranchiseFilm(franchiseID int, filmID int) error
+	DeleteFranchiseFilm(franchiseID int, filmID int) error
+	GetRelatedFilms(int) ([]models.RelatedFilm, error)
+	AddFilmRelation(int, *models.FilmRelationRequest) error
+	DeleteFilmRelation(filmID int, relatedFilmID int) error
+}
@@ -0,0 +1,410 @@
+package repository
+
+import (
+	"context"
+	filmPackage "vk-intern_test-case/internal/film"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	franchisePackage "vk-intern_test-case/internal/franchise"
+	franchiseQueries "vk-intern_test-case/internal/franchise/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "franchise:repository:"
+
+type FranchiseRepository struct {
+	pool database.PgxIface
+}
+
+func NewFranchiseRepository(pool database.PgxIface) *FranchiseRepository {
+	return &FranchiseRepository{
+		pool: pool,
+	}
+}
+
+func (frR *FranchiseRepository) AddFranchise(franchise *models.FranchiseRequest) (*models.Franchise, error) {
+	message := logMessage + "AddFranchise:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, franchiseQueries.CreateFranchise, &franchise.Name, &franchise.Description)
+	resultFranchise, err := scanFranchise(row)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return resultFranchise, nil
+}
+
+func (frR *FranchiseRepository) GetFranchise(franchiseID int) (*models.FranchiseWithFilms, error) {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, franchiseQueries.GetFranchiseByID, &franchiseID)
+	resultFranchise, err := scanFranchise(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = franchisePackage.ErrNotFound
+			return nil, err
+		}
+		return nil, err
+	}
+
+	franchise := &models.FranchiseWithFilms{
+		Franchise: *resultFranchise,
+		Films:     []models.Film{},
+	}
+	rows, err := tx.Query(transactionCtx, franchiseQueries.GetFranchiseFilms, &franchiseID)
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		film, err := filmRepository.ScanFilm(rows)
+		if err != nil {
+			return nil, err
+		}
+		franchise.Films = append(franchise.Films, *film)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return franchise, nil
+}
+
+func (frR *FranchiseRepository) GetFranchises() ([]models.Franchise, error) {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Franchise{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	franchises := []models.Franchise{}
+	rows, err := tx.Query(transactionCtx, franchiseQueries.GetFranchises)
+	if err != nil {
+		return []models.Franchise{}, err
+	}
+
+	for rows.Next() {
+		franchise, err := scanFranchise(rows)
+		if err != nil {
+			return []models.Franchise{}, err
+		}
+		franchises = append(franchises, *franchise)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Franchise{}, err
+	}
+	return franchises, nil
+}
+
+func (frR *FranchiseRepository) UpdateFranchise(franchiseID int, franchise *models.FranchiseRequest) (*models.Franchise, error) {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, franchiseQueries.UpdateFranchise, &franchise.Name, &franchise.Description, &franchiseID)
+	resultFranchise, err := scanFranchise(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = franchisePackage.ErrNotFound
+			return nil, err
+		}
+		return nil, database.MapError(err)
+	}
+	return resultFranchise, nil
+}
+
+func (frR *FranchiseRepository) DeleteFranchise(franchiseID int) error {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, franchiseQueries.DeleteFranchise, &franchiseID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = franchisePackage.ErrNotFound
+		return err
+	}
+	return nil
+}
+
+func (frR *FranchiseRepository) AddFranchiseFilm(franchiseID int, filmID int) error {
+	message := logMessage + "AddFranchiseFilm:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFranchiseExists(transactionCtx, tx, franchiseID)
+	if err != nil {
+		return err
+	}
+	err = checkRelatedFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(transactionCtx, franchiseQueries.AddFranchiseFilm, &franchiseID, &filmID)
+	if err != nil {
+		log.Error(message + err.Error())
+		return database.MapError(err)
+	}
+	return nil
+}
+
+func (frR *FranchiseRepository) DeleteFranchiseFilm(franchiseID int, filmID int) error {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, franchiseQueries.DeleteFranchiseFilm, &franchiseID, &filmID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = franchisePackage.ErrFranchiseFilmNotFound
+		return err
+	}
+	return nil
+}
+
+func (frR *FranchiseRepository) GetRelatedFilms(filmID int) ([]models.RelatedFilm, error) {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.RelatedFilm{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return []models.RelatedFilm{}, err
+	}
+
+	relatedFilms := []models.RelatedFilm{}
+	rows, err := tx.Query(transactionCtx, franchiseQueries.GetRelatedFilms, &filmID)
+	if err != nil {
+		return []models.RelatedFilm{}, err
+	}
+
+	for rows.Next() {
+		var relation string
+		film, err := filmRepository.ScanFilm(rows, &relation)
+		if err != nil {
+			return []models.RelatedFilm{}, err
+		}
+		relatedFilms = append(relatedFilms, models.RelatedFilm{Film: *film, Relation: relation})
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.RelatedFilm{}, err
+	}
+	return relatedFilms, nil
+}
+
+// AddFilmRelation записывает, кем фильм relation.FilmID приходится фильму filmID.
+// Прежняя связь между этими фильмами, в какую бы сторону она ни была записана, заменяется
+func (frR *FranchiseRepository) AddFilmRelation(filmID int, relation *models.FilmRelationRequest) error {
+	message := logMessage + "AddFilmRelation:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return err
+	}
+	err = checkRelatedFilmExists(transactionCtx, tx, relation.FilmID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(transactionCtx, franchiseQueries.DeleteFilmRelation, &filmID, &relation.FilmID)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(transactionCtx, franchiseQueries.AddFilmRelation, &filmID, &relation.FilmID, &relation.Type)
+	if err != nil {
+		log.Error(message + err.Error())
+		return database.MapError(err)
+	}
+	return nil
+}
+
+func (frR *FranchiseRepository) DeleteFilmRelation(filmID int, relatedFilmID int) error {
+	transactionCtx := context.Background()
+	tx, err := frR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, franchiseQueries.DeleteFilmRelation, &filmID, &relatedFilmID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = franchisePackage.ErrRelationNotFound
+		return err
+	}
+	return nil
+}
+
+func checkFranchiseExists(ctx context.Context, tx pgx.Tx, franchiseID int) error {
+	var id int
+	row := tx.QueryRow(ctx, franchiseQueries.GetFranchiseIDByID, &franchiseID)
+	err := row.Scan(&id)
+	if err == pgx.ErrNoRows {
+		return franchisePackage.ErrNotFound
+	}
+	return err
+}
+
+func checkFilmExists(ctx context.Context, tx pgx.Tx, filmID int) error {
+	var id int
+	row := tx.QueryRow(ctx, franchiseQueries.GetFilmIDByID, &filmID)
+	err := row.Scan(&id)
+	if err == pgx.ErrNoRows {
+		return filmPackage.ErrNotFound
+	}
+	return err
+}
+
+// checkRelatedFilmExists проверяет фильм из тела запроса: его отсутствие - ошибка валидации, а не 404
+func checkRelatedFilmExists(ctx context.Context, tx pgx.Tx, filmID int) error {
+	err := checkFilmExists(ctx, tx, filmID)
+	if err == filmPackage.ErrNotFound {
+		return franchisePackage.ErrUnknownFilm.WithFields([]models.FieldError{{
+			Field:   "film_id",
+			Code:    "unknown_film",
+			Message: "film not found",
+		}})
+	}
+	return err
+}
+
+func scanFranchise(row pgx.Row) (*models.Franchise, error) {
+	franchise := &models.Franchise{}
+	err := row.Scan(&franchise.ID, &franchise.Name, &franchise.Description)
+	if err != nil {
+		return nil, err
+	}
+	return franchise, nil
+}
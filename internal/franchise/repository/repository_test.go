@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"testing"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/franchise"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*FranchiseRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testFranchiseRepo := NewFranchiseRepository(mock)
+	return testFranchiseRepo, mock
+}
+
+var relatedFilmColumns = []string{"id", "title", "description", "release_date", "rating", "genres", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating", "vote_count", "relation"}
+
+func relatedFilmRow(id int, title, releaseDate, relation string) []any {
+	return []any{id, title, "", releaseDate, 8, []string{}, 0, []string{}, "", []string{}, "",
+		int64(0), int64(0), "", nil, nil, 0, relation}
+}
+
+func TestShouldSuccessfullyAddFranchise(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newFranchise := &models.FranchiseRequest{Name: "Матрица", Description: "Трилогия"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("insert into franchise").WithArgs(&newFranchise.Name, &newFranchise.Description).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description"}).AddRow(1, "Матрица", "Трилогия"))
+	mock.ExpectCommit()
+
+	resultFranchise, err := franchiseRepo.AddFranchise(newFranchise)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.Franchise{ID: 1, FranchiseRequest: *newFranchise}, resultFranchise)
+}
+
+func TestShouldReturnNotFoundWhenGettingNonExistentFranchise(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	franchiseID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id, name, description from franchise").WithArgs(&franchiseID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFranchise, err := franchiseRepo.GetFranchise(franchiseID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFranchise)
+	assert.Equal(t, franchise.ErrNotFound, err)
+}
+
+func TestShouldFailToAddUnknownFilmToFranchise(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	franchiseID := 1
+	filmID := 42
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from franchise").WithArgs(&franchiseID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(franchiseID))
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	err := franchiseRepo.AddFranchiseFilm(franchiseID, filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.ErrorIs(t, err, franchise.ErrUnknownFilm)
+}
+
+func TestShouldSuccessfullyGetRelatedFilms(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("with recursive chain").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows(relatedFilmColumns).
+			AddRow(relatedFilmRow(1, "Матрица", "1999-03-31", "prequel")...).
+			AddRow(relatedFilmRow(3, "Матрица: Революция", "2003-11-05", "")...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := franchiseRepo.GetRelatedFilms(filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Len(t, resultFilms, 2)
+	assert.Equal(t, "prequel", resultFilms[0].Relation)
+	assert.Equal(t, "1999-03-31", resultFilms[0].ReleaseDate)
+	assert.Equal(t, "", resultFilms[1].Relation)
+}
+
+func TestShouldReturnNotFoundWhenGettingRelatedFilmsOfNonExistentFilm(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilms, err := franchiseRepo.GetRelatedFilms(filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Empty(t, resultFilms)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldReplaceExistingFilmRelation(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+	relation := &models.FilmRelationRequest{FilmID: 2, Type: "sequel"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("select id from film").WithArgs(&relation.FilmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(relation.FilmID))
+	mock.ExpectExec("delete from film_relation").WithArgs(&filmID, &relation.FilmID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectExec("insert into film_relation").WithArgs(&filmID, &relation.FilmID, &relation.Type).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	err := franchiseRepo.AddFilmRelation(filmID, relation)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+}
+
+func TestShouldReturnNotFoundWhenDeletingNonExistentFilmRelation(t *testing.T) {
+	franchiseRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 1
+	relatedFilmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from film_relation").WithArgs(&filmID, &relatedFilmID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := franchiseRepo.DeleteFilmRelation(filmID, relatedFilmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, franchise.ErrRelationNotFound, err)
+}
@@ -0,0 +1,194 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"vk-intern_test-case/internal/genre"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "genre:delivery:"
+
+type GenreDelivery struct {
+	genreRepo genre.GenreRepository
+}
+
+func NewGenreDelivery(gR genre.GenreRepository) *GenreDelivery {
+	return &GenreDelivery{
+		genreRepo: gR,
+	}
+}
+
+func (gD *GenreDelivery) HandleGenres(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		if strings.TrimPrefix(r.URL.Path, "/genres") == "" {
+			gD.GetGenres(w, r)
+			return
+		}
+		gD.GetGenre(w, r)
+	case http.MethodPost:
+		gD.AddGenre(w, r)
+	case http.MethodPut:
+		gD.UpdateGenre(w, r)
+	case http.MethodDelete:
+		gD.DeleteGenre(w, r)
+	}
+}
+
+// swagger:route POST /genres Genres addGenre
+// Добавляет жанр в систему
+// security:
+// - key:
+// responses:
+//
+//	200: genre
+//	400: problemResponse
+//  401: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (gD *GenreDelivery) AddGenre(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddGenre:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	var newGenre models.GenreRequest
+	err := json.NewDecoder(r.Body).Decode(&newGenre)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateGenre(&newGenre).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultGenre, err := gD.genreRepo.AddGenre(&newGenre)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultGenre)
+}
+
+// swagger:route GET /genres/{id} Genres getGenre
+// Возвращает жанр по ID
+// responses:
+//
+//	200: genre
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (gD *GenreDelivery) GetGenre(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/genres/")
+	genreID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultGenre, err := gD.genreRepo.GetGenre(genreID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultGenre)
+}
+
+// swagger:route GET /genres Genres getGenres
+// Возвращает список жанров, отсортированный по названию
+// responses:
+//
+//	200: []genre
+//	500: problemResponse
+func (gD *GenreDelivery) GetGenres(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+
+	resultGenres, err := gD.genreRepo.GetGenres()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultGenres)
+}
+
+// swagger:route PUT /genres/{id} Genres updateGenre
+// Переименовывает жанр. Возвращает жанр после изменения.
+// security:
+// - key:
+// responses:
+//
+//	200: genre
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	409: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (gD *GenreDelivery) UpdateGenre(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/genres/")
+	genreID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var genreRequest models.GenreRequest
+	err = json.NewDecoder(r.Body).Decode(&genreRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateGenre(&genreRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultGenre, err := gD.genreRepo.UpdateGenre(genreID, &genreRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultGenre)
+}
+
+// swagger:route DELETE /genres/{id} Genres deleteGenre
+// Удаляет жанр из системы. Связи с фильмами удаляются вместе с ним
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (gD *GenreDelivery) DeleteGenre(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/genres/")
+	genreID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	err = gD.genreRepo.DeleteGenre(genreID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
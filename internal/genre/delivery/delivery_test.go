@@ -0,0 +1,199 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vk-intern_test-case/internal/genre"
+	"vk-intern_test-case/internal/genre/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type genreTest struct {
+	name               string
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockGenreRepository *mock.MockGenreRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var drama = &models.Genre{ID: 1, GenreRequest: models.GenreRequest{Name: "Драма"}}
+
+var genreTests = []genreTest{
+	{
+		"Successfully add new Genre",
+		http.MethodPost,
+		"/genres",
+		`{"name": "Драма"}`,
+		func(mockGenreRepository *mock.MockGenreRepository) {
+			mockGenreRepository.EXPECT().
+				AddGenre(&models.GenreRequest{Name: "Драма"}).
+				Return(drama, nil)
+		},
+		`{"id": 1, "name": "Драма"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to add Genre with blank name",
+		http.MethodPost,
+		"/genres",
+		`{"name": " "}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/genres",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "name", "code": "required", "message": "name is required"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to add an existing Genre",
+		http.MethodPost,
+		"/genres",
+		`{"name": "Драма"}`,
+		func(mockGenreRepository *mock.MockGenreRepository) {
+			mockGenreRepository.EXPECT().
+				AddGenre(&models.GenreRequest{Name: "Драма"}).
+				Return(nil, database.ErrAlreadyExists)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Conflict",
+			"status": 409,
+			"detail": "Resource already exists",
+			"instance": "/genres",
+			"code": "already_exists"
+		}`,
+		http.StatusConflict,
+	},
+	{
+		"Successfully get a list of Genres",
+		http.MethodGet,
+		"/genres",
+		"",
+		func(mockGenreRepository *mock.MockGenreRepository) {
+			mockGenreRepository.EXPECT().
+				GetGenres().
+				Return([]models.Genre{*drama, {ID: 2, GenreRequest: models.GenreRequest{Name: "Комедия"}}}, nil)
+		},
+		`[{"id": 1, "name": "Драма"}, {"id": 2, "name": "Комедия"}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a non-existent Genre",
+		http.MethodGet,
+		"/genres/5",
+		"",
+		func(mockGenreRepository *mock.MockGenreRepository) {
+			mockGenreRepository.EXPECT().
+				GetGenre(5).
+				Return(nil, genre.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Genre not found",
+			"instance": "/genres/5",
+			"code": "genre_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully rename a Genre",
+		http.MethodPut,
+		"/genres/1",
+		`{"name": "Драма"}`,
+		func(mockGenreRepository *mock.MockGenreRepository) {
+			mockGenreRepository.EXPECT().
+				UpdateGenre(1, &models.GenreRequest{Name: "Драма"}).
+				Return(drama, nil)
+		},
+		`{"id": 1, "name": "Драма"}`,
+		http.StatusOK,
+	},
+	{
+		"Successfully delete a Genre",
+		http.MethodDelete,
+		"/genres/1",
+		"",
+		func(mockGenreRepository *mock.MockGenreRepository) {
+			mockGenreRepository.EXPECT().
+				DeleteGenre(1).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to delete a Genre with invalid id",
+		http.MethodDelete,
+		"/genres/drama",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid id in request path",
+			"instance": "/genres/drama",
+			"code": "invalid_id"
+		}`,
+		http.StatusBadRequest,
+	},
+}
+
+func TestHandleGenres(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range genreTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockGenreRepository := mock.NewMockGenreRepository(ctrl)
+			genreDeliveryTest := NewGenreDelivery(mockGenreRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockGenreRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+
+			genreDeliveryTest.HandleGenres(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
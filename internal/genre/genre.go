@@ -0,0 +1,5 @@
+package genre
+
+import "vk-intern_test-case/utils/apperror"
+
+var ErrNotFound = apperror.NotFound("genre_not_found", "Genre not found")
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/genre/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGenreRepository is a mock of GenreRepository interface.
+type MockGenreRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockGenreRepositoryMockRecorder
+}
+
+// MockGenreRepositoryMockRecorder is the mock recorder for MockGenreRepository.
+type MockGenreRepositoryMockRecorder struct {
+	mock *MockGenreRepository
+}
+
+// NewMockGenreRepository creates a new mock instance.
+func NewMockGenreRepository(ctrl *gomock.Controller) *MockGenreRepository {
+	mock := &MockGenreRepository{ctrl: ctrl}
+	mock.recorder = &MockGenreRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGenreRepository) EXPECT() *MockGenreRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddGenre mocks base method.
+func (m *MockGenreRepository) AddGenre(arg0 *models.GenreRequest) (*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddGenre", arg0)
+	ret0, _ := ret[0].(*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddGenre indicates an expected call of AddGenre.
+func (mr *MockGenreRepositoryMockRecorder) AddGenre(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGenre", reflect.TypeOf((*MockGenreRepository)(nil).AddGenre), arg0)
+}
+
+// DeleteGenre mocks base method.
+func (m *MockGenreRepository) DeleteGenre(arg0 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGenre", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGenre indicates an expected call of DeleteGenre.
+func (mr *MockGenreRepositoryMockRecorder) DeleteGenre(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGenre", reflect.TypeOf((*MockGenreRepository)(nil).DeleteGenre), arg0)
+}
+
+// GetGenre mocks base method.
+func (m *MockGenreRepository) GetGenre(arg0 int) (*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenre", arg0)
+	ret0, _ := ret[0].(*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenre indicates an expected call of GetGenre.
+func (mr *MockGenreRepositoryMockRecorder) GetGenre(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenre", reflect.TypeOf((*MockGenreRepository)(nil).GetGenre), arg0)
+}
+
+// GetGenres mocks base method.
+func (m *MockGenreRepository) GetGenres() ([]models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenres")
+	ret0, _ := ret[0].([]models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenres indicates an expected call of GetGenres.
+func (mr *MockGenreRepositoryMockRecorder) GetGenres() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenres", reflect.TypeOf((*MockGenreRepository)(nil).GetGenres))
+}
+
+// UpdateGenre mocks base method.
+func (m *MockGenreRepository) UpdateGenre(arg0 int, arg1 *models.GenreRequest) (*models.Genre, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGenre", arg0, arg1)
+	ret0, _ := ret[0].(*models.Genre)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGenre indicates an expected call of UpdateGenre.
+func (mr *MockGenreRepositoryMockRecorder) UpdateGenre(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGenre", reflect.TypeOf((*MockGenreRepository)(nil).UpdateGenre), arg0, arg1)
+}
@@ -0,0 +1,10 @@
+package queries
+
+const (
+	CreateGenre      = `insert into genre (name) values ($1) returning id, name;`
+	GetGenreByID     = `select id, name from genre where id = $1;`
+	GetGenres        = `select id, name from genre order by name;`
+	UpdateGenre      = `update genre set name = $1 where id = $2 returning id, name;`
+	DeleteGenre      = `delete from genre where id = $1;`
+	GetGenresByNames = `select id, name from genre where name = any($1) order by name;`
+)
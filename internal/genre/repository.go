@@ -0,0 +1,11 @@
+package genre
+
+import "vk-intern_test-case/models"
+
+type GenreRepository interface {
+	AddGenre(*models.GenreRequest) (*models.Genre, error)
+	GetGenre(int) (*models.Genre, error)
+	GetGenres() ([]models.Genre, error)
+	UpdateGenre(int, *models.GenreRequest) (*models.Genre, error)
+	DeleteGenre(int) error
+}
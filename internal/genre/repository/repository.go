@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	genrePackage "vk-intern_test-case/internal/genre"
+	genreQueries "vk-intern_test-case/internal/genre/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "genre:repository:"
+
+type GenreRepository struct {
+	pool database.PgxIface
+}
+
+func NewGenreRepository(pool database.PgxIface) *GenreRepository {
+	return &GenreRepository{
+		pool: pool,
+	}
+}
+
+func (gR *GenreRepository) AddGenre(genre *models.GenreRequest) (*models.Genre, error) {
+	message := logMessage + "AddGenre:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := gR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, genreQueries.CreateGenre, &genre.Name)
+	resultGenre, err := scanGenre(row)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return resultGenre, nil
+}
+
+func (gR *GenreRepository) GetGenre(genreID int) (*models.Genre, error) {
+	transactionCtx := context.Background()
+	tx, err := gR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, genreQueries.GetGenreByID, &genreID)
+	resultGenre, err := scanGenre(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, genrePackage.ErrNotFound
+		}
+		return nil, err
+	}
+	return resultGenre, nil
+}
+
+func (gR *GenreRepository) GetGenres() ([]models.Genre, error) {
+	transactionCtx := context.Background()
+	tx, err := gR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Genre{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	genres := []models.Genre{}
+	rows, err := tx.Query(transactionCtx, genreQueries.GetGenres)
+	if err != nil {
+		return []models.Genre{}, err
+	}
+
+	for rows.Next() {
+		genre := models.Genre{}
+		err := rows.Scan(&genre.ID, &genre.Name)
+		if err != nil {
+			return []models.Genre{}, err
+		}
+		genres = append(genres, genre)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Genre{}, err
+	}
+	return genres, nil
+}
+
+func (gR *GenreRepository) UpdateGenre(genreID int, genre *models.GenreRequest) (*models.Genre, error) {
+	transactionCtx := context.Background()
+	tx, err := gR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	row := tx.QueryRow(transactionCtx, genreQueries.UpdateGenre, &genre.Name, &genreID)
+	resultGenre, err := scanGenre(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, genrePackage.ErrNotFound
+		}
+		return nil, database.MapError(err)
+	}
+	return resultGenre, nil
+}
+
+func (gR *GenreRepository) DeleteGenre(genreID int) error {
+	transactionCtx := context.Background()
+	tx, err := gR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, genreQueries.DeleteGenre, &genreID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = genrePackage.ErrNotFound
+		return err
+	}
+
+	return nil
+}
+
+func scanGenre(row pgx.Row) (*models.Genre, error) {
+	genre := &models.Genre{}
+	err := row.Scan(&genre.ID, &genre.Name)
+	if err != nil {
+		return nil, err
+	}
+	return genre, nil
+}
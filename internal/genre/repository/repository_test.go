@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"vk-intern_test-case/internal/genre"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*GenreRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testGenreRepo := NewGenreRepository(mock)
+	return testGenreRepo, mock
+}
+
+func TestShouldSuccessfullyAddNewGenre(t *testing.T) {
+	genreRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newGenre := &models.GenreRequest{Name: "Драма"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("insert into genre").WithArgs(&newGenre.Name).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name"}).AddRow(1, newGenre.Name))
+	mock.ExpectCommit()
+
+	resultGenre, err := genreRepo.AddGenre(newGenre)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, resultGenre.ID)
+	assert.Equal(t, newGenre.Name, resultGenre.Name)
+}
+
+func TestShouldReturnConflictWhenAddingExistingGenre(t *testing.T) {
+	genreRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	newGenre := &models.GenreRequest{Name: "Драма"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("insert into genre").WithArgs(&newGenre.Name).
+		WillReturnError(&pgconn.PgError{Code: "23505"})
+	mock.ExpectRollback()
+
+	resultGenre, err := genreRepo.AddGenre(newGenre)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultGenre)
+	assert.True(t, errors.Is(err, database.ErrAlreadyExists))
+}
+
+func TestShouldSuccessfullyGetGenres(t *testing.T) {
+	genreRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id, name from genre").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name"}).AddRow(1, "Драма").AddRow(2, "Комедия")).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultGenres, err := genreRepo.GetGenres()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resultGenres))
+}
+
+func TestShouldReturnNotFoundWhenUpdatingNonExistentGenre(t *testing.T) {
+	genreRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	genreID := 5
+	newGenre := &models.GenreRequest{Name: "Драма"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("update genre").WithArgs(&newGenre.Name, &genreID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultGenre, err := genreRepo.UpdateGenre(genreID, newGenre)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultGenre)
+	assert.Equal(t, genre.ErrNotFound, err)
+}
+
+func TestShouldReturnNotFoundWhenDeletingNonExistentGenre(t *testing.T) {
+	genreRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	genreID := 5
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from genre").WithArgs(&genreID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := genreRepo.DeleteGenre(genreID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, genre.ErrNotFound, err)
+}
@@ -4,16 +4,30 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"vk-intern_test-case/utils/apperror"
 	"vk-intern_test-case/utils/database"
 	"vk-intern_test-case/utils/response"
 
+	"github.com/jackc/pgx/v5"
 	log "github.com/sirupsen/logrus"
 )
 
 const (
-	adminCheck = `select role from service_user where id = $1`
+	adminCheck    = `select role from service_user where id = $1`
+	adminRole     = "Администратор"
+	moderatorRole = "Модератор"
 )
 
+var (
+	ErrUnauthorized = apperror.Unauthorized("unauthorized", "Authorization header with a valid user id is required")
+	ErrForbidden    = apperror.Forbidden("forbidden", "Only administrators are allowed to modify data")
+	ErrNotModerator = apperror.Forbidden("forbidden", "Only moderators are allowed to moderate reviews")
+)
+
+type contextKey string
+
+const userIDKey contextKey = "user_id"
+
 type AuthMiddleware struct {
 	pool database.PgxIface
 }
@@ -28,35 +42,129 @@ func (aM *AuthMiddleware) MiddlewareCheckAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		log.Debug("Middleware started")
 		if r.Method != http.MethodGet {
-			jsonEnc := response.MakeJsonEncoder(w)
-			headerValue, exist := r.Header[http.CanonicalHeaderKey("Authorization")]
-			log.Debug(headerValue)
-			if !exist {
-				response.WriteBasicResponse(w, jsonEnc, http.StatusUnauthorized, "Unauthorized")
+			_, role, err := aM.getUser(r)
+			if err != nil {
+				response.WriteError(w, r, err)
 				return
 			}
 
-			userID, err := strconv.Atoi(headerValue[0])
-			if err != nil {
-				response.WriteBasicResponse(w, jsonEnc, http.StatusUnauthorized, "Unauthorized")
+			if role != adminRole {
+				response.WriteError(w, r, ErrForbidden)
+				return
 			}
+		}
 
-			tx, err := aM.pool.Begin(context.Background())
-			if err != nil {
-				response.WriteBasicResponse(w, jsonEnc, http.StatusUnauthorized, "Unauthorized")
-			}
-			var role string
-			row := tx.QueryRow(context.Background(), adminCheck, &userID)
-			err = row.Scan(&role)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MiddlewareCheckUser пропускает GET запросы, остальные - только от существующих пользователей.
+// ID пользователя сохраняется в контексте запроса, его возвращает UserID
+func (aM *AuthMiddleware) MiddlewareCheckUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Debug("Middleware started")
+		if r.Method != http.MethodGet {
+			userID, _, err := aM.getUser(r)
 			if err != nil {
-				response.WriteBasicResponse(w, jsonEnc, http.StatusUnauthorized, "Unauthorized")
+				response.WriteError(w, r, err)
+				return
 			}
+			r = r.WithContext(WithUserID(r.Context(), userID))
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// MiddlewareRequireUser пропускает запросы любым методом только от существующих пользователей.
+// ID пользователя сохраняется в контексте запроса, его возвращает UserID
+func (aM *AuthMiddleware) MiddlewareRequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Debug("Middleware started")
+		userID, _, err := aM.getUser(r)
+		if err != nil {
+			response.WriteError(w, r, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithUserID(r.Context(), userID)))
+	})
+}
 
-			if role != "Администратор" {
-				response.WriteBasicResponse(w, jsonEnc, http.StatusUnauthorized, "Unauthorized")
+// MiddlewareIdentifyUser сохраняет в контексте GET запроса пользователя из заголовка Authorization,
+// чтобы ответ можно было дополнить его данными. Запросы без заголовка проходят анонимно
+func (aM *AuthMiddleware) MiddlewareIdentifyUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Debug("Middleware started")
+		if r.Method == http.MethodGet && r.Header.Get("Authorization") != "" {
+			userID, _, err := aM.getUser(r)
+			if err != nil {
+				response.WriteError(w, r, err)
+				return
 			}
+			r = r.WithContext(WithUserID(r.Context(), userID))
 		}
 
 		next.ServeHTTP(w, r)
 	})
-}
\ No newline at end of file
+}
+
+// MiddlewareCheckModerator пропускает запросы любым методом только от модераторов и администраторов.
+// ID пользователя сохраняется в контексте запроса, его возвращает UserID
+func (aM *AuthMiddleware) MiddlewareCheckModerator(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.Debug("Middleware started")
+		userID, role, err := aM.getUser(r)
+		if err != nil {
+			response.WriteError(w, r, err)
+			return
+		}
+
+		if role != moderatorRole && role != adminRole {
+			response.WriteError(w, r, ErrNotModerator)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithUserID(r.Context(), userID)))
+	})
+}
+
+// WithUserID returns a copy of ctx with id of the authenticated user
+func WithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserID returns id of the authenticated user saved by middleware
+func UserID(ctx context.Context) (int, bool) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	return userID, ok
+}
+
+func (aM *AuthMiddleware) getUser(r *http.Request) (int, string, error) {
+	headerValue := r.Header.Get("Authorization")
+	log.Debug(headerValue)
+	userID, err := strconv.Atoi(headerValue)
+	if err != nil {
+		return 0, "", ErrUnauthorized
+	}
+
+	transactionCtx := context.Background()
+	tx, err := aM.pool.Begin(transactionCtx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() {
+		_ = tx.Rollback(transactionCtx)
+	}()
+
+	var role string
+	row := tx.QueryRow(transactionCtx, adminCheck, &userID)
+	err = row.Scan(&role)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, "", ErrUnauthorized
+		}
+		return 0, "", err
+	}
+	return userID, role, nil
+}
@@ -0,0 +1,256 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+type checkAdminTest struct {
+	name               string
+	method             string
+	authorization      string
+	beforeTest         func(mock pgxmock.PgxPoolIface)
+	expectedStatusCode int
+	expectedNextCalled bool
+}
+
+var checkAdminTests = []checkAdminTest{
+	{
+		"GET requests are allowed without authorization",
+		http.MethodGet,
+		"",
+		nil,
+		http.StatusOK,
+		true,
+	},
+	{
+		"Modifying request without authorization",
+		http.MethodPost,
+		"",
+		nil,
+		http.StatusUnauthorized,
+		false,
+	},
+	{
+		"Modifying request by unknown user",
+		http.MethodDelete,
+		"5",
+		func(mock pgxmock.PgxPoolIface) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("select role from service_user").WithArgs(intPtr(5)).WillReturnError(pgx.ErrNoRows)
+			mock.ExpectRollback()
+		},
+		http.StatusUnauthorized,
+		false,
+	},
+	{
+		"Modifying request by ordinary user",
+		http.MethodPut,
+		"1",
+		func(mock pgxmock.PgxPoolIface) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("select role from service_user").WithArgs(intPtr(1)).
+				WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow("Пользователь"))
+			mock.ExpectRollback()
+		},
+		http.StatusForbidden,
+		false,
+	},
+	{
+		"Modifying request by administrator",
+		http.MethodPost,
+		"2",
+		func(mock pgxmock.PgxPoolIface) {
+			mock.ExpectBegin()
+			mock.ExpectQuery("select role from service_user").WithArgs(intPtr(2)).
+				WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow("Администратор"))
+			mock.ExpectRollback()
+		},
+		http.StatusOK,
+		true,
+	},
+}
+
+func TestMiddlewareCheckAdmin(t *testing.T) {
+	for _, test := range checkAdminTests {
+		t.Run(test.name, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			if test.beforeTest != nil {
+				test.beforeTest(mock)
+			}
+
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+			request := httptest.NewRequest(test.method, "/films", nil)
+			if test.authorization != "" {
+				request.Header.Set("Authorization", test.authorization)
+			}
+			responseRecorder := httptest.NewRecorder()
+
+			NewAuthMiddleware(mock).MiddlewareCheckAdmin(next).ServeHTTP(responseRecorder, request)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, test.expectedNextCalled, nextCalled)
+		})
+	}
+}
+
+func TestMiddlewareCheckUser(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("select role from service_user").WithArgs(intPtr(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow("Пользователь"))
+	mock.ExpectRollback()
+
+	var userID int
+	var userFound bool
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, userFound = UserID(r.Context())
+	})
+	request := httptest.NewRequest(http.MethodPut, "/films/1/reviews", nil)
+	request.Header.Set("Authorization", "1")
+	responseRecorder := httptest.NewRecorder()
+
+	NewAuthMiddleware(mock).MiddlewareCheckUser(next).ServeHTTP(responseRecorder, request)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Equal(t, http.StatusOK, responseRecorder.Code)
+	assert.True(t, userFound)
+	assert.Equal(t, 1, userID)
+}
+
+func TestMiddlewareCheckUserWithoutAuthorization(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	nextCalled := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+	})
+	request := httptest.NewRequest(http.MethodPut, "/films/1/reviews", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	NewAuthMiddleware(mock).MiddlewareCheckUser(next).ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+	assert.False(t, nextCalled)
+}
+
+func TestMiddlewareRequireUserForGet(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	nextCalled := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		nextCalled = true
+	})
+	request := httptest.NewRequest(http.MethodGet, "/me/watchlist", nil)
+	responseRecorder := httptest.NewRecorder()
+
+	NewAuthMiddleware(mock).MiddlewareRequireUser(next).ServeHTTP(responseRecorder, request)
+
+	assert.Equal(t, http.StatusUnauthorized, responseRecorder.Code)
+	assert.False(t, nextCalled)
+}
+
+func TestMiddlewareIdentifyUser(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	mock.ExpectBegin()
+	mock.ExpectQuery("select role from service_user").WithArgs(intPtr(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow("Пользователь"))
+	mock.ExpectRollback()
+
+	var userIDs []int
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID, _ := UserID(r.Context())
+		userIDs = append(userIDs, userID)
+	})
+	authMiddleware := NewAuthMiddleware(mock)
+
+	anonymousRequest := httptest.NewRequest(http.MethodGet, "/films", nil)
+	authMiddleware.MiddlewareIdentifyUser(next).ServeHTTP(httptest.NewRecorder(), anonymousRequest)
+	request := httptest.NewRequest(http.MethodGet, "/films", nil)
+	request.Header.Set("Authorization", "1")
+	authMiddleware.MiddlewareIdentifyUser(next).ServeHTTP(httptest.NewRecorder(), request)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+	assert.Equal(t, []int{0, 1}, userIDs)
+}
+
+func TestMiddlewareCheckModerator(t *testing.T) {
+	roles := []struct {
+		role               string
+		expectedStatusCode int
+		expectedNextCalled bool
+	}{
+		{"Пользователь", http.StatusForbidden, false},
+		{"Модератор", http.StatusOK, true},
+		{"Администратор", http.StatusOK, true},
+	}
+	for _, test := range roles {
+		t.Run(test.role, func(t *testing.T) {
+			mock, err := pgxmock.NewPool()
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer mock.Close()
+			mock.ExpectBegin()
+			mock.ExpectQuery("select role from service_user").WithArgs(intPtr(3)).
+				WillReturnRows(pgxmock.NewRows([]string{"role"}).AddRow(test.role))
+			mock.ExpectRollback()
+
+			nextCalled := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+			})
+			// Очередь модерации закрыта и для GET запросов
+			request := httptest.NewRequest(http.MethodGet, "/moderation/reviews", nil)
+			request.Header.Set("Authorization", "3")
+			responseRecorder := httptest.NewRecorder()
+
+			NewAuthMiddleware(mock).MiddlewareCheckModerator(next).ServeHTTP(responseRecorder, request)
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, test.expectedNextCalled, nextCalled)
+		})
+	}
+}
+
+func intPtr(value int) *int {
+	return &value
+}
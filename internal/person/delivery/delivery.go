@@ -0,0 +1,222 @@
+package delivery
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"vk-intern_test-case/internal/person"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "person:delivery:"
+
+type PersonDelivery struct {
+	personRepo person.PersonRepository
+}
+
+func NewPersonDelivery(pR person.PersonRepository) *PersonDelivery {
+	return &PersonDelivery{
+		personRepo: pR,
+	}
+}
+
+func (pD *PersonDelivery) HandlePersons(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pD.GetPerson(w, r)
+	}
+}
+
+// HandleFilmCrew handles /films/{id}/crew and /films/{id}/crew/{person_id}
+func (pD *PersonDelivery) HandleFilmCrew(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pD.GetFilmCrew(w, r)
+	case http.MethodPost:
+		pD.AddCrewMember(w, r)
+	case http.MethodDelete:
+		pD.DeleteCrewMember(w, r)
+	}
+}
+
+// HandleFilmCast handles /films/{id}/cast
+func (pD *PersonDelivery) HandleFilmCast(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		pD.GetFilmCast(w, r)
+	}
+}
+
+// swagger:route GET /persons/{id} Persons getPerson
+// Возвращает персону вместе с актёрскими работами (acting) и работами в съёмочной группе (crew).
+// В актёрских работах age_at_release - возраст персоны на дату выхода фильма
+// responses:
+//
+//	200: personWithCredits
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (pD *PersonDelivery) GetPerson(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetPerson:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	id := strings.TrimPrefix(r.URL.Path, "/persons/")
+	personID, err := strconv.Atoi(id)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultPerson, err := pD.personRepo.GetPerson(personID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultPerson)
+}
+
+// swagger:route GET /films/{id}/cast Crew getFilmCast
+// Возвращает актёров фильма. Поле age_at_release - возраст актёра на дату выхода фильма
+// responses:
+//
+//	200: []castMember
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (pD *PersonDelivery) GetFilmCast(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultCast, err := pD.personRepo.GetFilmCast(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCast)
+}
+
+// swagger:route GET /films/{id}/crew Crew getFilmCrew
+// Возвращает съёмочную группу фильма
+// responses:
+//
+//	200: []crewMember
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (pD *PersonDelivery) GetFilmCrew(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	resultCrew, err := pD.personRepo.GetFilmCrew(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultCrew)
+}
+
+// swagger:route POST /films/{id}/crew Crew addCrewMember
+// Добавляет персону в съёмочную группу фильма с указанной ролью.
+// Повторное добавление той же роли ничего не меняет
+// security:
+// - key:
+// responses:
+//
+//	200: crewMember
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (pD *PersonDelivery) AddCrewMember(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddCrewMember:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	var crewRequest models.CrewRequest
+	err = json.NewDecoder(r.Body).Decode(&crewRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateCrewRequest(&crewRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultMember, err := pD.personRepo.AddCrewMember(filmID, &crewRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultMember)
+}
+
+// swagger:route DELETE /films/{id}/crew/{person_id} Crew deleteCrewMember
+// Удаляет персону из съёмочной группы фильма. Если указан role - удаляется только эта роль
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (pD *PersonDelivery) DeleteCrewMember(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	segments := router.PathSegments(r.URL.Path, "/films/")
+	if len(segments) != 3 {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+	filmID, err := strconv.Atoi(segments[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+	personID, err := strconv.Atoi(segments[2])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+
+	role := r.URL.Query().Get("role")
+	if role != "" && !slices.Contains(validation.AllowedCrewRoles, role) {
+		response.WriteError(w, r, apperror.ErrInvalidQuery.WithMessage("Unknown role"))
+		return
+	}
+
+	err = pD.personRepo.DeleteCrewMember(filmID, personID, role)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
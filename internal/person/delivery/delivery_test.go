@@ -0,0 +1,294 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/person"
+	"vk-intern_test-case/internal/person/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type personTest struct {
+	name               string
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockPersonRepository *mock.MockPersonRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var titanic = models.Film{
+	ID: 2,
+	FilmRequest: models.FilmRequest{
+		Title:       "Титаник",
+		Description: "Cool film",
+		ReleaseDate: "1997-12-19",
+		Rating:      8,
+	},
+}
+
+var getPersonTests = []personTest{
+	{
+		"Successfully get a Person with credits",
+		http.MethodGet,
+		"/persons/3",
+		"",
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				GetPerson(3).
+				Return(&models.PersonWithCredits{
+					Actor: models.Actor{
+						ID: 3,
+						ActorRequest: models.ActorRequest{
+							Name:        "Джеймс Кэмерон",
+							Gender:      "Мужской",
+							DateOfBirth: "1954-08-16",
+						},
+					},
+					Acting: []models.ActingCredit{},
+					Crew:   []models.CrewCredit{{Film: titanic, Role: "director"}},
+				}, nil)
+		},
+		`{
+			"id": 3,
+			"name": "Джеймс Кэмерон",
+			"gender": "Мужской",
+			"date_of_birth": "1954-08-16",
+			"acting": [],
+			"crew": [
+				{
+					"id": 2,
+					"title": "Титаник",
+					"description": "Cool film",
+					"release_date": "1997-12-19",
+					"rating": 8,
+					"role": "director"
+				}
+			]
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a non-existent Person",
+		http.MethodGet,
+		"/persons/3",
+		"",
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				GetPerson(3).
+				Return(nil, person.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Person not found",
+			"instance": "/persons/3",
+			"code": "person_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandlePersons(t *testing.T) {
+	runPersonTests(t, getPersonTests, func(personDelivery *PersonDelivery) http.HandlerFunc {
+		return personDelivery.HandlePersons
+	})
+}
+
+var filmCrewTests = []personTest{
+	{
+		"Successfully get a Film crew",
+		http.MethodGet,
+		"/films/2/crew",
+		"",
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				GetFilmCrew(2).
+				Return([]models.CrewMember{{PersonID: 3, Name: "Джеймс Кэмерон", Role: "director"}}, nil)
+		},
+		`[{"person_id": 3, "name": "Джеймс Кэмерон", "role": "director"}]`,
+		http.StatusOK,
+	},
+	{
+		"Successfully add a Crew member",
+		http.MethodPost,
+		"/films/2/crew",
+		`{"person_id": 3, "role": "director"}`,
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				AddCrewMember(2, &models.CrewRequest{PersonID: 3, Role: "director"}).
+				Return(&models.CrewMember{PersonID: 3, Name: "Джеймс Кэмерон", Role: "director"}, nil)
+		},
+		`{"person_id": 3, "name": "Джеймс Кэмерон", "role": "director"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to add a Crew member with unknown role",
+		http.MethodPost,
+		"/films/2/crew",
+		`{"person_id": 3, "role": "actor"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/films/2/crew",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "role", "code": "not_allowed", "message": "role must be one of: director, writer, composer, producer"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to add a Crew member to non-existent Film",
+		http.MethodPost,
+		"/films/2/crew",
+		`{"person_id": 3, "role": "director"}`,
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				AddCrewMember(2, &models.CrewRequest{PersonID: 3, Role: "director"}).
+				Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/2/crew",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully delete a Crew member role",
+		http.MethodDelete,
+		"/films/2/crew/3?role=writer",
+		"",
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				DeleteCrewMember(2, 3, "writer").
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to delete a Crew member without person id",
+		http.MethodDelete,
+		"/films/2/crew",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid id in request path",
+			"instance": "/films/2/crew",
+			"code": "invalid_id"
+		}`,
+		http.StatusBadRequest,
+	},
+}
+
+func TestHandleFilmCrew(t *testing.T) {
+	runPersonTests(t, filmCrewTests, func(personDelivery *PersonDelivery) http.HandlerFunc {
+		return personDelivery.HandleFilmCrew
+	})
+}
+
+var filmCastTests = []personTest{
+	{
+		"Successfully get a Film cast",
+		http.MethodGet,
+		"/films/2/cast",
+		"",
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			age := 23
+			mockPersonRepository.EXPECT().
+				GetFilmCast(2).
+				Return([]models.CastMember{
+					{PersonID: 1, Name: "Леонардо Ди Каприо", DateOfBirth: "1974-11-11", AgeAtRelease: &age},
+				}, nil)
+		},
+		`[{"person_id": 1, "name": "Леонардо Ди Каприо", "date_of_birth": "1974-11-11", "age_at_release": 23}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get a cast of non-existent Film",
+		http.MethodGet,
+		"/films/2/cast",
+		"",
+		func(mockPersonRepository *mock.MockPersonRepository) {
+			mockPersonRepository.EXPECT().
+				GetFilmCast(2).
+				Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/2/cast",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleFilmCast(t *testing.T) {
+	runPersonTests(t, filmCastTests, func(personDelivery *PersonDelivery) http.HandlerFunc {
+		return personDelivery.HandleFilmCast
+	})
+}
+
+func runPersonTests(t *testing.T, tests []personTest, handler func(*PersonDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockPersonRepository := mock.NewMockPersonRepository(ctrl)
+			personDeliveryTest := NewPersonDelivery(mockPersonRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockPersonRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+
+			handler(personDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/person/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPersonRepository is a mock of PersonRepository interface.
+type MockPersonRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonRepositoryMockRecorder
+}
+
+// MockPersonRepositoryMockRecorder is the mock recorder for MockPersonRepository.
+type MockPersonRepositoryMockRecorder struct {
+	mock *MockPersonRepository
+}
+
+// NewMockPersonRepository creates a new mock instance.
+func NewMockPersonRepository(ctrl *gomock.Controller) *MockPersonRepository {
+	mock := &MockPersonRepository{ctrl: ctrl}
+	mock.recorder = &MockPersonRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonRepository) EXPECT() *MockPersonRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCrewMember mocks base method.
+func (m *MockPersonRepository) AddCrewMember(arg0 int, arg1 *models.CrewRequest) (*models.CrewMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCrewMember", arg0, arg1)
+	ret0, _ := ret[0].(*models.CrewMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCrewMember indicates an expected call of AddCrewMember.
+func (mr *MockPersonRepositoryMockRecorder) AddCrewMember(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCrewMember", reflect.TypeOf((*MockPersonRepository)(nil).AddCrewMember), arg0, arg1)
+}
+
+// DeleteCrewMember mocks base method.
+func (m *MockPersonRepository) DeleteCrewMember(filmID, personID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCrewMember", filmID, personID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCrewMember indicates an expected call of DeleteCrewMember.
+func (mr *MockPersonRepositoryMockRecorder) DeleteCrewMember(filmID, personID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCrewMember", reflect.TypeOf((*MockPersonRepository)(nil).DeleteCrewMember), filmID, personID, role)
+}
+
+// GetFilmCast mocks base method.
+func (m *MockPersonRepository) GetFilmCast(arg0 int) ([]models.CastMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmCast", arg0)
+	ret0, _ := ret[0].([]models.CastMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmCast indicates an expected call of GetFilmCast.
+func (mr *MockPersonRepositoryMockRecorder) GetFilmCast(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmCast", reflect.TypeOf((*MockPersonRepository)(nil).GetFilmCast), arg0)
+}
+
+// GetFilmCrew mocks base method.
+func (m *MockPersonRepository) GetFilmCrew(arg0 int) ([]models.CrewMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmCrew", arg0)
+	ret0, _ := ret[0].([]models.CrewMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmCrew indicates an expected call of GetFilmCrew.
+func (mr *MockPersonRepositoryMockRecorder) GetFilmCrew(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmCrew", reflect.TypeOf((*MockPersonRepository)(nil).GetFilmCrew), arg0)
+}
+
+// GetPerson mocks base method.
+func (m *MockPersonRepository) GetPerson(arg0 int) (*models.PersonWithCredits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPerson", arg0)
+	ret0, _ := ret[0].(*models.PersonWithCredits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPerson indicates an expected call of GetPerson.
+func (mr *MockPersonRepositoryMockRecorder) GetPerson(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPerson", reflect.TypeOf((*MockPersonRepository)(nil).GetPerson), arg0)
+}
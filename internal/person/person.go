@@ -0,0 +1,9 @@
+package person
+
+import "vk-intern_test-case/utils/apperror"
+
+var (
+	ErrNotFound           = apperror.NotFound("person_not_found", "Person not found")
+	ErrCrewMemberNotFound = apperror.NotFound("crew_member_not_found", "Crew member not found")
+	ErrUnknownPerson      = apperror.Validation("unknown_person", "Unknown person", nil)
+)
@@ -0,0 +1,35 @@
+package queries
+
+import (
+	actorQueries "vk-intern_test-case/internal/actor/queries"
+	filmQueries "vk-intern_test-case/internal/film/queries"
+)
+
+const (
+	GetPersonByID    = `select ` + actorQueries.ActorColumns + ` from person as p where p.id = $1;`
+	GetPersonName    = `select name from person where id = $1;`
+	GetFilmIDByID    = `select id from film where id = $1;`
+	GetActingCredits = `select ` + filmQueries.FilmColumns + `
+		from film as f
+		join actor_film as af on f.id = af.film_id
+		where af.actor_id = $1
+		order by f.release_date;`
+	GetCrewCredits = `select ` + filmQueries.FilmColumns + `, fc.role
+		from film as f
+		join film_crew as fc on f.id = fc.film_id
+		where fc.person_id = $1
+		order by f.release_date, fc.role;`
+	GetFilmCrew = `select p.id, p.name, fc.role from film_crew as fc
+		join person as p on p.id = fc.person_id
+		where fc.film_id = $1
+		order by fc.role, p.name;`
+	GetFilmCast = `select p.id, p.name, p.date_of_birth, f.release_date from actor_film as af
+		join person as p on p.id = af.actor_id
+		join film as f on f.id = af.film_id
+		where af.film_id = $1
+		order by p.name;`
+	AddCrewMember = `insert into film_crew (film_id, person_id, role) values ($1, $2, $3)
+		on conflict do nothing;`
+	DeleteCrewMember       = `delete from film_crew where film_id = $1 and person_id = $2;`
+	DeleteCrewMemberByRole = `delete from film_crew where film_id = $1 and person_id = $2 and role = $3;`
+)
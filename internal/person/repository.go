@@ -0,0 +1,11 @@
+package person
+
+import "vk-intern_test-case/models"
+
+type PersonRepository interface {
+	GetPerson(int) (*models.PersonWithCredits, error)
+	GetFilmCrew(int) ([]models.CrewMember, error)
+	GetFilmCast(int) ([]models.CastMember, error)
+	AddCrewMember(int, *models.CrewRequest) (*models.CrewMember, error)
+	DeleteCrewMember(filmID int, personID int, role string) error
+}
@@ -0,0 +1,290 @@
+package repository
+
+import (
+	"context"
+	"time"
+	actorRepository "vk-intern_test-case/internal/actor/repository"
+	filmPackage "vk-intern_test-case/internal/film"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	personPackage "vk-intern_test-case/internal/person"
+	personQueries "vk-intern_test-case/internal/person/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "person:repository:"
+
+type PersonRepository struct {
+	pool database.PgxIface
+}
+
+func NewPersonRepository(pool database.PgxIface) *PersonRepository {
+	return &PersonRepository{
+		pool: pool,
+	}
+}
+
+func (pR *PersonRepository) GetPerson(personID int) (*models.PersonWithCredits, error) {
+	message := logMessage + "GetPerson:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := pR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	person := &models.PersonWithCredits{
+		Acting: []models.ActingCredit{},
+		Crew:   []models.CrewCredit{},
+	}
+	row := tx.QueryRow(transactionCtx, personQueries.GetPersonByID, &personID)
+	actor, err := actorRepository.ScanActor(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, personPackage.ErrNotFound
+		}
+		return nil, err
+	}
+	person.Actor = *actor
+
+	rows, err := tx.Query(transactionCtx, personQueries.GetActingCredits, &personID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var film *models.Film
+		film, err = filmRepository.ScanFilm(rows)
+		if err != nil {
+			return nil, err
+		}
+		person.Acting = append(person.Acting, models.ActingCredit{
+			Film:         *film,
+			AgeAtRelease: actorRepository.AgeAtRelease(person.DateOfBirth, film.ReleaseDate),
+		})
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = tx.Query(transactionCtx, personQueries.GetCrewCredits, &personID)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var film *models.Film
+		var role string
+		film, err = filmRepository.ScanFilm(rows, &role)
+		if err != nil {
+			return nil, err
+		}
+		person.Crew = append(person.Crew, models.CrewCredit{Film: *film, Role: role})
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return person, nil
+}
+
+func (pR *PersonRepository) GetFilmCrew(filmID int) ([]models.CrewMember, error) {
+	transactionCtx := context.Background()
+	tx, err := pR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.CrewMember{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return []models.CrewMember{}, err
+	}
+
+	crew := []models.CrewMember{}
+	rows, err := tx.Query(transactionCtx, personQueries.GetFilmCrew, &filmID)
+	if err != nil {
+		return []models.CrewMember{}, err
+	}
+
+	for rows.Next() {
+		member := models.CrewMember{}
+		err := rows.Scan(&member.PersonID, &member.Name, &member.Role)
+		if err != nil {
+			return []models.CrewMember{}, err
+		}
+		crew = append(crew, member)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.CrewMember{}, err
+	}
+	return crew, nil
+}
+
+// GetFilmCast возвращает актёров фильма с их возрастом на дату выхода фильма
+func (pR *PersonRepository) GetFilmCast(filmID int) ([]models.CastMember, error) {
+	transactionCtx := context.Background()
+	tx, err := pR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.CastMember{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return []models.CastMember{}, err
+	}
+
+	cast := []models.CastMember{}
+	rows, err := tx.Query(transactionCtx, personQueries.GetFilmCast, &filmID)
+	if err != nil {
+		return []models.CastMember{}, err
+	}
+
+	for rows.Next() {
+		member := models.CastMember{}
+		var dateOfBirthPG, releaseDatePG pgtype.Date
+		err := rows.Scan(&member.PersonID, &member.Name, &dateOfBirthPG, &releaseDatePG)
+		if err != nil {
+			return []models.CastMember{}, err
+		}
+		if dateOfBirthPG.Valid {
+			member.DateOfBirth = dateOfBirthPG.Time.Format(time.DateOnly)
+			if releaseDatePG.Valid {
+				age := actorRepository.Age(dateOfBirthPG.Time, releaseDatePG.Time)
+				member.AgeAtRelease = &age
+			}
+		}
+		cast = append(cast, member)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.CastMember{}, err
+	}
+	return cast, nil
+}
+
+func (pR *PersonRepository) AddCrewMember(filmID int, crewRequest *models.CrewRequest) (*models.CrewMember, error) {
+	message := logMessage + "AddCrewMember:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := pR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return nil, err
+	}
+
+	member := &models.CrewMember{
+		PersonID: crewRequest.PersonID,
+		Role:     crewRequest.Role,
+	}
+	row := tx.QueryRow(transactionCtx, personQueries.GetPersonName, &crewRequest.PersonID)
+	err = row.Scan(&member.Name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = personPackage.ErrUnknownPerson.WithFields([]models.FieldError{{
+				Field:   "person_id",
+				Code:    "unknown_person",
+				Message: "person not found",
+			}})
+			return nil, err
+		}
+		return nil, err
+	}
+
+	_, err = tx.Exec(transactionCtx, personQueries.AddCrewMember, &filmID, &crewRequest.PersonID, &crewRequest.Role)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return member, nil
+}
+
+func (pR *PersonRepository) DeleteCrewMember(filmID int, personID int, role string) error {
+	transactionCtx := context.Background()
+	tx, err := pR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	query := personQueries.DeleteCrewMember
+	args := []any{&filmID, &personID}
+	if role != "" {
+		query = personQueries.DeleteCrewMemberByRole
+		args = append(args, &role)
+	}
+
+	commandTag, err := tx.Exec(transactionCtx, query, args...)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = personPackage.ErrCrewMemberNotFound
+		return err
+	}
+	return nil
+}
+
+func checkFilmExists(ctx context.Context, tx pgx.Tx, filmID int) error {
+	var id int
+	row := tx.QueryRow(ctx, personQueries.GetFilmIDByID, &filmID)
+	err := row.Scan(&id)
+	if err == pgx.ErrNoRows {
+		return filmPackage.ErrNotFound
+	}
+	return err
+}
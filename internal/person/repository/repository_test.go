@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"testing"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/person"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*PersonRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testPersonRepo := NewPersonRepository(mock)
+	return testPersonRepo, mock
+}
+
+func TestShouldSuccessfullyGetPersonWithCredits(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	personID := 3
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select p.id, p.name, p.gender, p.date_of_birth, p.photo, p.date_of_death").WithArgs(&personID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "gender", "date_of_birth", "photo", "date_of_death",
+			"birthplace", "biography", "aliases", "imdb_id", "kinopoisk_id"}).
+			AddRow(personID, "Джеймс Кэмерон", "Мужской", "1954-08-16", nil, nil, "", "", []string{}, "", 0))
+	mock.ExpectQuery("join actor_film").WithArgs(&personID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"runtime", "countries", "original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating", "vote_count"}).
+			AddRow(2, "Титаник", "cool film", "1997-12-19", 8, []string{"Драма"}, 0, []string{}, "", []string{}, "", int64(0), int64(0), "", nil, nil, 0)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("join film_crew").WithArgs(&personID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "release_date", "rating", "genres",
+			"runtime", "countries", "original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating", "vote_count", "role"}).
+			AddRow(2, "Титаник", "cool film", "1997-12-19", 8, []string{"Драма"}, 0, []string{}, "", []string{}, "", int64(0), int64(0), "", nil, nil, 0, "director").
+			AddRow(2, "Титаник", "cool film", "1997-12-19", 8, []string{"Драма"}, 0, []string{}, "", []string{}, "", int64(0), int64(0), "", nil, nil, 0, "writer")).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultPerson, err := personRepo.GetPerson(personID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "1954-08-16", resultPerson.DateOfBirth)
+	assert.Equal(t, 1, len(resultPerson.Acting))
+	assert.Equal(t, 43, *resultPerson.Acting[0].AgeAtRelease)
+	assert.Equal(t, 2, len(resultPerson.Crew))
+	assert.Equal(t, "writer", resultPerson.Crew[1].Role)
+}
+
+func TestShouldReturnNotFoundWhenGettingNonExistentPerson(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	personID := 3
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select p.id, p.name, p.gender, p.date_of_birth, p.photo, p.date_of_death").WithArgs(&personID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultPerson, err := personRepo.GetPerson(personID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultPerson)
+	assert.Equal(t, person.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyGetFilmCastWithAgeAtRelease(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("from actor_film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "date_of_birth", "release_date"}).
+			AddRow(1, "Леонардо Ди Каприо", "1974-11-11", "1997-12-19").
+			AddRow(4, "Массовка", nil, "1997-12-19")).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultCast, err := personRepo.GetFilmCast(filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resultCast))
+	assert.Equal(t, "1974-11-11", resultCast[0].DateOfBirth)
+	assert.Equal(t, 23, *resultCast[0].AgeAtRelease)
+	assert.Nil(t, resultCast[1].AgeAtRelease)
+}
+
+func TestShouldSuccessfullyAddCrewMember(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+	crewRequest := &models.CrewRequest{PersonID: 3, Role: "director"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("select name from person").WithArgs(&crewRequest.PersonID).
+		WillReturnRows(pgxmock.NewRows([]string{"name"}).AddRow("Джеймс Кэмерон"))
+	mock.ExpectExec("insert into film_crew").WithArgs(&filmID, &crewRequest.PersonID, &crewRequest.Role).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectCommit()
+
+	resultMember, err := personRepo.AddCrewMember(filmID, crewRequest)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.CrewMember{PersonID: 3, Name: "Джеймс Кэмерон", Role: "director"}, resultMember)
+}
+
+func TestShouldFailToAddCrewMemberToNonExistentFilm(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+	crewRequest := &models.CrewRequest{PersonID: 3, Role: "director"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultMember, err := personRepo.AddCrewMember(filmID, crewRequest)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultMember)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldFailToAddUnknownPersonToCrew(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+	crewRequest := &models.CrewRequest{PersonID: 3, Role: "director"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("select name from person").WithArgs(&crewRequest.PersonID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultMember, err := personRepo.AddCrewMember(filmID, crewRequest)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultMember)
+	assert.ErrorIs(t, err, person.ErrUnknownPerson)
+}
+
+func TestShouldDeleteOnlyGivenCrewRole(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+	personID := 3
+	role := "writer"
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from film_crew (.+) and role = ").WithArgs(&filmID, &personID, &role).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectCommit()
+
+	err := personRepo.DeleteCrewMember(filmID, personID, role)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+}
+
+func TestShouldReturnNotFoundWhenDeletingMissingCrewMember(t *testing.T) {
+	personRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+	personID := 3
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from film_crew").WithArgs(&filmID, &personID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := personRepo.DeleteCrewMember(filmID, personID, "")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, person.ErrCrewMemberNotFound, err)
+}
@@ -0,0 +1,137 @@
+package recommendation
+
+import (
+	"math"
+	"sort"
+)
+
+// Interaction - оценка фильма пользователем или отметка о просмотре без оценки
+type Interaction struct {
+	UserID int
+	FilmID int
+	// Оценка от 1 до 10, nil - фильм просмотрен, но не оценён
+	Rating *int
+}
+
+// ScoredFilm - фильм, предсказанный коллаборативной фильтрацией
+type ScoredFilm struct {
+	FilmID int
+	Score  float64
+	// Фильмы пользователя, сильнее всего повлиявшие на рекомендацию
+	BecauseOf []int
+}
+
+// Preference переводит оценку в предпочтение от -1 до 1: 1 - отвращение, 10 - восторг.
+// Просмотр без оценки считается умеренно положительным сигналом
+func Preference(rating *int) float64 {
+	if rating == nil {
+		return WatchedPreference
+	}
+	return (float64(*rating) - NeutralRating) / (10 - NeutralRating)
+}
+
+// SeenFilms returns films the user has rated or watched
+func SeenFilms(interactions []Interaction, userID int) []int {
+	seen := []int{}
+	for _, interaction := range interactions {
+		if interaction.UserID == userID {
+			seen = append(seen, interaction.FilmID)
+		}
+	}
+	sort.Ints(seen)
+	return seen
+}
+
+// CollaborativeFilter ранжирует не просмотренные пользователем фильмы item-item фильтрацией:
+// похожесть фильмов - косинус между векторами предпочтений всех пользователей,
+// оценка кандидата - сумма похожестей на фильмы пользователя, взвешенных его предпочтениями
+func CollaborativeFilter(interactions []Interaction, userID int) []ScoredFilm {
+	filmUsers := map[int]map[int]float64{}
+	userFilms := map[int]map[int]float64{}
+	norms := map[int]float64{}
+	for _, interaction := range interactions {
+		preference := Preference(interaction.Rating)
+		if filmUsers[interaction.FilmID] == nil {
+			filmUsers[interaction.FilmID] = map[int]float64{}
+		}
+		if userFilms[interaction.UserID] == nil {
+			userFilms[interaction.UserID] = map[int]float64{}
+		}
+		filmUsers[interaction.FilmID][interaction.UserID] = preference
+		userFilms[interaction.UserID][interaction.FilmID] = preference
+		norms[interaction.FilmID] += preference * preference
+	}
+
+	seen := userFilms[userID]
+	scores := map[int]float64{}
+	contributions := map[int]map[int]float64{}
+	for seenFilm, userPreference := range seen {
+		if userPreference == 0 {
+			continue
+		}
+		// Скалярные произведения фильма пользователя с фильмами его "соседей"
+		dots := map[int]float64{}
+		for otherUser, otherPreference := range filmUsers[seenFilm] {
+			if otherUser == userID {
+				continue
+			}
+			for candidate, candidatePreference := range userFilms[otherUser] {
+				if _, ok := seen[candidate]; ok {
+					continue
+				}
+				dots[candidate] += otherPreference * candidatePreference
+			}
+		}
+		for candidate, dot := range dots {
+			if dot == 0 {
+				continue
+			}
+			similarity := dot / math.Sqrt(norms[seenFilm]*norms[candidate])
+			contribution := similarity * userPreference
+			scores[candidate] += contribution
+			if contributions[candidate] == nil {
+				contributions[candidate] = map[int]float64{}
+			}
+			contributions[candidate][seenFilm] = contribution
+		}
+	}
+
+	films := []ScoredFilm{}
+	for candidate, score := range scores {
+		if score <= 0 {
+			continue
+		}
+		films = append(films, ScoredFilm{
+			FilmID:    candidate,
+			Score:     math.Round(score*100) / 100,
+			BecauseOf: strongestContributions(contributions[candidate]),
+		})
+	}
+	sort.Slice(films, func(i, j int) bool {
+		if films[i].Score != films[j].Score {
+			return films[i].Score > films[j].Score
+		}
+		return films[i].FilmID < films[j].FilmID
+	})
+	return films
+}
+
+// strongestContributions returns up to BecauseOfLimit films with the largest positive contribution
+func strongestContributions(contributions map[int]float64) []int {
+	filmIDs := []int{}
+	for filmID, contribution := range contributions {
+		if contribution > 0 {
+			filmIDs = append(filmIDs, filmID)
+		}
+	}
+	sort.Slice(filmIDs, func(i, j int) bool {
+		if contributions[filmIDs[i]] != contributions[filmIDs[j]] {
+			return contributions[filmIDs[i]] > contributions[filmIDs[j]]
+		}
+		return filmIDs[i] < filmIDs[j]
+	})
+	if len(filmIDs) > BecauseOfLimit {
+		filmIDs = filmIDs[:BecauseOfLimit]
+	}
+	return filmIDs
+}
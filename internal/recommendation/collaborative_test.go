@@ -0,0 +1,52 @@
+package recommendation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rating(value int) *int {
+	return &value
+}
+
+func TestCollaborativeFilter(t *testing.T) {
+	interactions := []Interaction{
+		// Пользователь 1 любит фильмы 1 и 2
+		{UserID: 1, FilmID: 1, Rating: rating(10)},
+		{UserID: 1, FilmID: 2, Rating: rating(9)},
+		// Пользователю 2 нравятся 1, 2 и 3, фильм 4 - нет
+		{UserID: 2, FilmID: 1, Rating: rating(10)},
+		{UserID: 2, FilmID: 2, Rating: rating(8)},
+		{UserID: 2, FilmID: 3, Rating: rating(9)},
+		{UserID: 2, FilmID: 4, Rating: rating(2)},
+		// Пользователь 3 посмотрел 1 и 5 без оценок
+		{UserID: 3, FilmID: 1},
+		{UserID: 3, FilmID: 5},
+	}
+
+	films := CollaborativeFilter(interactions, 1)
+
+	assert.Equal(t, 2, len(films))
+	assert.Equal(t, 3, films[0].FilmID)
+	assert.Equal(t, []int{1, 2}, films[0].BecauseOf)
+	assert.Equal(t, 5, films[1].FilmID)
+	assert.Equal(t, []int{1}, films[1].BecauseOf)
+	assert.Greater(t, films[0].Score, films[1].Score)
+}
+
+func TestCollaborativeFilterColdStart(t *testing.T) {
+	interactions := []Interaction{
+		{UserID: 2, FilmID: 1, Rating: rating(10)},
+		{UserID: 2, FilmID: 2, Rating: rating(8)},
+	}
+
+	assert.Empty(t, CollaborativeFilter(interactions, 1))
+	assert.Empty(t, SeenFilms(interactions, 1))
+}
+
+func TestPreference(t *testing.T) {
+	assert.Equal(t, 1.0, Preference(rating(10)))
+	assert.Equal(t, -1.0, Preference(rating(1)))
+	assert.Equal(t, WatchedPreference, Preference(nil))
+}
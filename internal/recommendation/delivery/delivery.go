@@ -0,0 +1,120 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/internal/recommendation"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "recommendation:delivery:"
+
+type RecommendationDelivery struct {
+	recommendationRepo recommendation.RecommendationRepository
+}
+
+func NewRecommendationDelivery(rR recommendation.RecommendationRepository) *RecommendationDelivery {
+	return &RecommendationDelivery{
+		recommendationRepo: rR,
+	}
+}
+
+// HandleSimilarFilms handles /films/{id}/similar
+func (rD *RecommendationDelivery) HandleSimilarFilms(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		rD.GetSimilarFilms(w, r)
+	}
+}
+
+// swagger:route GET /films/{id}/similar Recommendations getSimilarFilms
+// Возвращает фильмы, похожие на этот: с общими актёрами и жанрами, близким годом выхода и оценкой.
+// Самые похожие - первыми, reasons и остальные поля объясняют, почему фильм рекомендован.
+// limit - число фильмов, по умолчанию 10, не больше 50
+// responses:
+//
+//	200: []similarFilm
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (rD *RecommendationDelivery) GetSimilarFilms(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetSimilarFilms:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	filmID, err := strconv.Atoi(router.PathSegments(r.URL.Path, "/films/")[0])
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidID)
+		return
+	}
+	limit, err := parseLimit(r, recommendation.DefaultSimilarLimit, recommendation.MaxSimilarLimit)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFilms, err := rD.recommendationRepo.GetSimilarFilms(filmID, limit)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilms)
+}
+
+// HandleRecommendations handles /me/recommendations
+func (rD *RecommendationDelivery) HandleRecommendations(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		rD.GetRecommendations(w, r)
+	}
+}
+
+// swagger:route GET /me/recommendations Recommendations getRecommendations
+// Рекомендует пользователю из заголовка Authorization фильмы, которые он не оценил и не смотрел.
+// Сначала - фильмы, которые нравятся пользователям с похожими оценками и просмотрами (source collaborative,
+// because_of - фильмы пользователя, из-за которых фильм рекомендован), остальное - популярные фильмы (source popular).
+// limit - число фильмов, по умолчанию 20, не больше 50
+// responses:
+//
+//	200: []recommendation
+//	400: problemResponse
+//	401: problemResponse
+//	500: problemResponse
+func (rD *RecommendationDelivery) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetRecommendations:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	limit, err := parseLimit(r, recommendation.DefaultRecommendationLimit, recommendation.MaxRecommendationLimit)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	recommendations, err := rD.recommendationRepo.GetRecommendations(userID, limit)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, recommendations)
+}
+
+func parseLimit(r *http.Request, defaultLimit, maxLimit int) (int, error) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return defaultLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > maxLimit {
+		return 0, apperror.ErrInvalidQuery.WithMessage("Invalid limit")
+	}
+	return limit, nil
+}
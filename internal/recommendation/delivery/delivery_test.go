@@ -0,0 +1,238 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/internal/recommendation/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type recommendationTest struct {
+	name               string
+	userID             int
+	method             string
+	path               string
+	beforeTest         func(mockRecommendationRepository *mock.MockRecommendationRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var similarFilmsTests = []recommendationTest{
+	{
+		"Successfully get similar films",
+		0,
+		http.MethodGet,
+		"/films/2/similar?limit=1",
+		func(mockRecommendationRepository *mock.MockRecommendationRepository) {
+			mockRecommendationRepository.EXPECT().
+				GetSimilarFilms(2, 1).
+				Return([]models.SimilarFilm{{
+					Film: models.Film{ID: 5, FilmRequest: models.FilmRequest{
+						Title: "Отступники", ReleaseDate: "2006-09-26", Rating: 9}},
+					Score:            4.2,
+					Reasons:          []string{"shared_cast", "similar_rating"},
+					SharedActors:     []string{"Леонардо Ди Каприо"},
+					SharedGenres:     []string{},
+					YearDifference:   9,
+					RatingDifference: 1,
+				}}, nil)
+		},
+		`[{
+			"id": 5,
+			"title": "Отступники",
+			"description": "",
+			"release_date": "2006-09-26",
+			"rating": 9,
+			"score": 4.2,
+			"reasons": ["shared_cast", "similar_rating"],
+			"shared_actors": ["Леонардо Ди Каприо"],
+			"shared_genres": [],
+			"year_difference": 9,
+			"rating_difference": 1
+		}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get similar films with too big limit",
+		0,
+		http.MethodGet,
+		"/films/2/similar?limit=500",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid limit",
+			"instance": "/films/2/similar",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get films similar to non-existent Film",
+		0,
+		http.MethodGet,
+		"/films/2/similar",
+		func(mockRecommendationRepository *mock.MockRecommendationRepository) {
+			mockRecommendationRepository.EXPECT().
+				GetSimilarFilms(2, 10).
+				Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/2/similar",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+var recommendationsTests = []recommendationTest{
+	{
+		"Successfully get recommendations",
+		1,
+		http.MethodGet,
+		"/me/recommendations?limit=2",
+		func(mockRecommendationRepository *mock.MockRecommendationRepository) {
+			mockRecommendationRepository.EXPECT().
+				GetRecommendations(1, 2).
+				Return([]models.Recommendation{
+					{
+						Film: models.Film{ID: 5, FilmRequest: models.FilmRequest{
+							Title: "Отступники", ReleaseDate: "2006-09-26", Rating: 9}},
+						Score:     1.37,
+						Source:    "collaborative",
+						BecauseOf: []string{"Титаник"},
+					},
+					{
+						Film: models.Film{ID: 7, FilmRequest: models.FilmRequest{
+							Title: "Аватар", ReleaseDate: "2009-12-10", Rating: 8}},
+						Source:    "popular",
+						BecauseOf: []string{},
+					},
+				}, nil)
+		},
+		`[
+			{
+				"id": 5,
+				"title": "Отступники",
+				"description": "",
+				"release_date": "2006-09-26",
+				"rating": 9,
+				"score": 1.37,
+				"source": "collaborative",
+				"because_of": ["Титаник"]
+			},
+			{
+				"id": 7,
+				"title": "Аватар",
+				"description": "",
+				"release_date": "2009-12-10",
+				"rating": 8,
+				"score": 0,
+				"source": "popular",
+				"because_of": []
+			}
+		]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get recommendations with invalid limit",
+		1,
+		http.MethodGet,
+		"/me/recommendations?limit=0",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid limit",
+			"instance": "/me/recommendations",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get recommendations without user",
+		0,
+		http.MethodGet,
+		"/me/recommendations",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unauthorized",
+			"status": 401,
+			"detail": "Authorization header with a valid user id is required",
+			"instance": "/me/recommendations",
+			"code": "unauthorized"
+		}`,
+		http.StatusUnauthorized,
+	},
+}
+
+func TestHandleRecommendations(t *testing.T) {
+	runRecommendationTests(t, recommendationsTests, func(recommendationDelivery *RecommendationDelivery) http.HandlerFunc {
+		return recommendationDelivery.HandleRecommendations
+	})
+}
+
+func TestHandleSimilarFilms(t *testing.T) {
+	runRecommendationTests(t, similarFilmsTests, func(recommendationDelivery *RecommendationDelivery) http.HandlerFunc {
+		return recommendationDelivery.HandleSimilarFilms
+	})
+}
+
+func runRecommendationTests(t *testing.T, tests []recommendationTest, handler func(*RecommendationDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockRecommendationRepository := mock.NewMockRecommendationRepository(ctrl)
+			recommendationDeliveryTest := NewRecommendationDelivery(mockRecommendationRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockRecommendationRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(""))
+			assert.Nil(t, err)
+			if test.userID != 0 {
+				request = request.WithContext(middleware.WithUserID(request.Context(), test.userID))
+			}
+
+			handler(recommendationDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
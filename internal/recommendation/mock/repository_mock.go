@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/recommendation/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockRecommendationRepository is a mock of RecommendationRepository interface.
+type MockRecommendationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRecommendationRepositoryMockRecorder
+}
+
+// MockRecommendationRepositoryMockRecorder is the mock recorder for MockRecommendationRepository.
+type MockRecommendationRepositoryMockRecorder struct {
+	mock *MockRecommendationRepository
+}
+
+// NewMockRecommendationRepository creates a new mock instance.
+func NewMockRecommendationRepository(ctrl *gomock.Controller) *MockRecommendationRepository {
+	mock := &MockRecommendationRepository{ctrl: ctrl}
+	mock.recorder = &MockRecommendationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRecommendationRepository) EXPECT() *MockRecommendationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetRecommendations mocks base method.
+func (m *MockRecommendationRepository) GetRecommendations(userID, limit int) ([]models.Recommendation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecommendations", userID, limit)
+	ret0, _ := ret[0].([]models.Recommendation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecommendations indicates an expected call of GetRecommendations.
+func (mr *MockRecommendationRepositoryMockRecorder) GetRecommendations(userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecommendations", reflect.TypeOf((*MockRecommendationRepository)(nil).GetRecommendations), userID, limit)
+}
+
+// GetSimilarFilms mocks base method.
+func (m *MockRecommendationRepository) GetSimilarFilms(filmID, limit int) ([]models.SimilarFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSimilarFilms", filmID, limit)
+	ret0, _ := ret[0].([]models.SimilarFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilarFilms indicates an expected call of GetSimilarFilms.
+func (mr *MockRecommendationRepositoryMockRecorder) GetSimilarFilms(filmID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilarFilms", reflect.TypeOf((*MockRecommendationRepository)(nil).GetSimilarFilms), filmID, limit)
+}
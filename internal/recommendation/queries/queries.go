@@ -0,0 +1,66 @@
+package queries
+
+import filmQueries "vk-intern_test-case/internal/film/queries"
+
+const GetFilmIDByID = `select id from film where id = $1;`
+
+// Похожие фильмы: хотя бы один общий актёр или жанр с фильмом $1. Оценка складывается из
+// $3 за общего актёра, $4 за общий жанр, до $5 за близкий год выхода (убывает за 2*$7 лет)
+// и до $6 за близкую редакционную оценку
+const GetSimilarFilms = `with target as (select release_date, rating from film where id = $1),
+	shared_cast as (
+		select af2.film_id, array_agg(distinct p.name order by p.name) as actors
+		from actor_film as af1
+		join actor_film as af2 on af2.actor_id = af1.actor_id and af2.film_id <> af1.film_id
+		join person as p on p.id = af1.actor_id
+		where af1.film_id = $1
+		group by af2.film_id),
+	shared_genres as (
+		select fg2.film_id, array_agg(distinct g.name order by g.name) as genres
+		from film_genre as fg1
+		join film_genre as fg2 on fg2.genre_id = fg1.genre_id and fg2.film_id <> fg1.film_id
+		join genre as g on g.id = fg1.genre_id
+		where fg1.film_id = $1
+		group by fg2.film_id),
+	candidates as (
+		select f.id,
+			coalesce(sc.actors, '{}') as actors,
+			coalesce(sg.genres, '{}') as genres,
+			abs(extract(year from f.release_date) - extract(year from t.release_date))::int as year_difference,
+			abs(f.rating - t.rating)::int as rating_difference
+		from film as f
+		cross join target as t
+		left join shared_cast as sc on sc.film_id = f.id
+		left join shared_genres as sg on sg.film_id = f.id
+		where f.id <> $1 and (sc.film_id is not null or sg.film_id is not null)),
+	scored as (
+		select c.*, cardinality(c.actors) * $3::float8 + cardinality(c.genres) * $4::float8
+			+ greatest(0, 1 - c.year_difference / (2.0 * $7)) * $5::float8
+			+ greatest(0, 1 - c.rating_difference / 10.0) * $6::float8 as score
+		from candidates as c)
+	select ` + filmQueries.FilmColumns + `, s.score, s.actors, s.genres, s.year_difference, s.rating_difference
+	from scored as s join film as f on f.id = s.id
+	order by s.score desc, f.rating desc, f.id
+	limit $2;`
+
+// Оценки и просмотры всех пользователей для коллаборативной фильтрации. Чужие оценки -
+// только из опубликованных отзывов, свои ($1) - все. Просмотр без оценки - rating null
+const GetInteractions = `select r.user_id, r.film_id, r.rating from review as r
+	where r.status = 'published' or r.user_id = $1
+	union all
+	select l.user_id, l.film_id, null from user_film_list as l
+	where l.list = 'watched' and not exists (
+		select 1 from review as r where r.user_id = l.user_id and r.film_id = l.film_id
+			and (r.status = 'published' or r.user_id = $1));`
+
+const GetFilmsByIDs = `select ` + filmQueries.FilmColumns + ` from film as f where f.id = any($1);`
+
+const GetFilmTitlesByIDs = `select id, title from film where id = any($1);`
+
+// Популярные фильмы, кроме $1: сначала с большим числом опубликованных оценок и просмотров
+const GetPopularFilms = `select ` + filmQueries.FilmColumns + ` from film as f
+	where f.id <> all($1)
+	order by (select count(*) from review as r where r.film_id = f.id and r.status = 'published')
+		+ (select count(*) from user_film_list as l where l.film_id = f.id and l.list = 'watched') desc,
+		f.rating desc, f.id
+	limit $2;`
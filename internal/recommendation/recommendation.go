@@ -0,0 +1,53 @@
+package recommendation
+
+// Веса признаков похожести фильмов
+const (
+	// За каждого общего актёра
+	SharedActorWeight = 3.0
+	// За каждый общий жанр
+	SharedGenreWeight = 2.0
+	// Максимум за близкий год выхода, убывает до нуля за CloseReleaseYears*2 лет
+	ReleaseYearWeight = 2.0
+	// Максимум за близкую редакционную оценку, убывает до нуля при разнице в 10 баллов
+	RatingWeight = 1.0
+)
+
+// Причины, по которым фильм попал в рекомендации
+const (
+	ReasonSharedCast    = "shared_cast"
+	ReasonSharedGenres  = "shared_genres"
+	ReasonCloseRelease  = "close_release"
+	ReasonSimilarRating = "similar_rating"
+)
+
+const (
+	// Фильмы, вышедшие с разницей не больше стольких лет, считаются близкими по времени
+	CloseReleaseYears = 5
+	// Оценки, отличающиеся не больше чем на столько баллов, считаются похожими
+	SimilarRatingDifference = 1
+)
+
+// Число похожих фильмов в ответе
+const (
+	DefaultSimilarLimit = 10
+	MaxSimilarLimit     = 50
+)
+
+// Персональные рекомендации
+const (
+	// Оценка, которая не говорит ни за, ни против фильма
+	NeutralRating = 5.5
+	// Предпочтение для просмотренного, но не оценённого фильма
+	WatchedPreference = 0.5
+	// Сколько фильмов пользователя объясняют рекомендацию
+	BecauseOfLimit = 3
+
+	DefaultRecommendationLimit = 20
+	MaxRecommendationLimit     = 50
+)
+
+// Откуда взялась рекомендация
+const (
+	SourceCollaborative = "collaborative"
+	SourcePopular       = "popular"
+)
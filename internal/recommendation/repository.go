@@ -0,0 +1,8 @@
+package recommendation
+
+import "vk-intern_test-case/models"
+
+type RecommendationRepository interface {
+	GetSimilarFilms(filmID int, limit int) ([]models.SimilarFilm, error)
+	GetRecommendations(userID int, limit int) ([]models.Recommendation, error)
+}
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	filmPackage "vk-intern_test-case/internal/film"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	recommendationPackage "vk-intern_test-case/internal/recommendation"
+	recommendationQueries "vk-intern_test-case/internal/recommendation/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "recommendation:repository:"
+
+type RecommendationRepository struct {
+	pool database.PgxIface
+}
+
+func NewRecommendationRepository(pool database.PgxIface) *RecommendationRepository {
+	return &RecommendationRepository{
+		pool: pool,
+	}
+}
+
+// GetSimilarFilms возвращает фильмы с общими актёрами или жанрами, самые похожие - первыми
+func (rR *RecommendationRepository) GetSimilarFilms(filmID int, limit int) ([]models.SimilarFilm, error) {
+	message := logMessage + "GetSimilarFilms:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.SimilarFilm{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var id int
+	row := tx.QueryRow(transactionCtx, recommendationQueries.GetFilmIDByID, &filmID)
+	err = row.Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = filmPackage.ErrNotFound
+			return []models.SimilarFilm{}, err
+		}
+		return []models.SimilarFilm{}, err
+	}
+
+	rows, err := tx.Query(transactionCtx, recommendationQueries.GetSimilarFilms, &filmID, limit,
+		recommendationPackage.SharedActorWeight, recommendationPackage.SharedGenreWeight,
+		recommendationPackage.ReleaseYearWeight, recommendationPackage.RatingWeight,
+		recommendationPackage.CloseReleaseYears)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.SimilarFilm{}, err
+	}
+
+	films := []models.SimilarFilm{}
+	for rows.Next() {
+		similarFilm := models.SimilarFilm{}
+		film, err := filmRepository.ScanFilm(rows, &similarFilm.Score, &similarFilm.SharedActors,
+			&similarFilm.SharedGenres, &similarFilm.YearDifference, &similarFilm.RatingDifference)
+		if err != nil {
+			return []models.SimilarFilm{}, err
+		}
+		similarFilm.Film = *film
+		similarFilm.Reasons = similarityReasons(&similarFilm)
+		films = append(films, similarFilm)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.SimilarFilm{}, err
+	}
+	return films, nil
+}
+
+// similarityReasons explains why the film was recommended
+func similarityReasons(film *models.SimilarFilm) []string {
+	reasons := []string{}
+	if len(film.SharedActors) > 0 {
+		reasons = append(reasons, recommendationPackage.ReasonSharedCast)
+	}
+	if len(film.SharedGenres) > 0 {
+		reasons = append(reasons, recommendationPackage.ReasonSharedGenres)
+	}
+	if film.YearDifference <= recommendationPackage.CloseReleaseYears {
+		reasons = append(reasons, recommendationPackage.ReasonCloseRelease)
+	}
+	if film.RatingDifference <= recommendationPackage.SimilarRatingDifference {
+		reasons = append(reasons, recommendationPackage.ReasonSimilarRating)
+	}
+	return reasons
+}
+
+// GetRecommendations рекомендует пользователю фильмы, которые он не оценил и не смотрел:
+// сначала по item-item коллаборативной фильтрации, остальное - популярными фильмами
+func (rR *RecommendationRepository) GetRecommendations(userID int, limit int) ([]models.Recommendation, error) {
+	message := logMessage + "GetRecommendations:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Recommendation{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	rows, err := tx.Query(transactionCtx, recommendationQueries.GetInteractions, userID)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.Recommendation{}, err
+	}
+	interactions := []recommendationPackage.Interaction{}
+	for rows.Next() {
+		interaction := recommendationPackage.Interaction{}
+		err = rows.Scan(&interaction.UserID, &interaction.FilmID, &interaction.Rating)
+		if err != nil {
+			return []models.Recommendation{}, err
+		}
+		interactions = append(interactions, interaction)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return []models.Recommendation{}, err
+	}
+
+	scoredFilms := recommendationPackage.CollaborativeFilter(interactions, userID)
+	if len(scoredFilms) > limit {
+		scoredFilms = scoredFilms[:limit]
+	}
+	recommendations, err := getCollaborativeRecommendations(transactionCtx, tx, scoredFilms)
+	if err != nil {
+		return []models.Recommendation{}, err
+	}
+
+	if len(recommendations) < limit {
+		excluded := recommendationPackage.SeenFilms(interactions, userID)
+		for _, recommendation := range recommendations {
+			excluded = append(excluded, recommendation.ID)
+		}
+		var popular []models.Recommendation
+		popular, err = getPopularRecommendations(transactionCtx, tx, excluded, limit-len(recommendations))
+		if err != nil {
+			return []models.Recommendation{}, err
+		}
+		recommendations = append(recommendations, popular...)
+	}
+	return recommendations, nil
+}
+
+func getCollaborativeRecommendations(ctx context.Context, tx pgx.Tx,
+	scoredFilms []recommendationPackage.ScoredFilm) ([]models.Recommendation, error) {
+	if len(scoredFilms) == 0 {
+		return []models.Recommendation{}, nil
+	}
+	filmIDs := []int{}
+	becauseOfIDs := []int{}
+	added := map[int]bool{}
+	for _, scoredFilm := range scoredFilms {
+		filmIDs = append(filmIDs, scoredFilm.FilmID)
+		for _, filmID := range scoredFilm.BecauseOf {
+			if !added[filmID] {
+				added[filmID] = true
+				becauseOfIDs = append(becauseOfIDs, filmID)
+			}
+		}
+	}
+
+	rows, err := tx.Query(ctx, recommendationQueries.GetFilmsByIDs, filmIDs)
+	if err != nil {
+		return []models.Recommendation{}, err
+	}
+	films := map[int]models.Film{}
+	for rows.Next() {
+		film, err := filmRepository.ScanFilm(rows)
+		if err != nil {
+			return []models.Recommendation{}, err
+		}
+		films[film.ID] = *film
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Recommendation{}, err
+	}
+
+	rows, err = tx.Query(ctx, recommendationQueries.GetFilmTitlesByIDs, becauseOfIDs)
+	if err != nil {
+		return []models.Recommendation{}, err
+	}
+	titles := map[int]string{}
+	for rows.Next() {
+		var id int
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return []models.Recommendation{}, err
+		}
+		titles[id] = title
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Recommendation{}, err
+	}
+
+	recommendations := []models.Recommendation{}
+	for _, scoredFilm := range scoredFilms {
+		film, ok := films[scoredFilm.FilmID]
+		if !ok {
+			continue
+		}
+		becauseOf := []string{}
+		for _, filmID := range scoredFilm.BecauseOf {
+			if title, ok := titles[filmID]; ok {
+				becauseOf = append(becauseOf, title)
+			}
+		}
+		recommendations = append(recommendations, models.Recommendation{
+			Film:      film,
+			Score:     scoredFilm.Score,
+			Source:    recommendationPackage.SourceCollaborative,
+			BecauseOf: becauseOf,
+		})
+	}
+	return recommendations, nil
+}
+
+func getPopularRecommendations(ctx context.Context, tx pgx.Tx, excluded []int,
+	limit int) ([]models.Recommendation, error) {
+	rows, err := tx.Query(ctx, recommendationQueries.GetPopularFilms, excluded, limit)
+	if err != nil {
+		return []models.Recommendation{}, err
+	}
+	recommendations := []models.Recommendation{}
+	for rows.Next() {
+		film, err := filmRepository.ScanFilm(rows)
+		if err != nil {
+			return []models.Recommendation{}, err
+		}
+		recommendations = append(recommendations, models.Recommendation{
+			Film:      *film,
+			Source:    recommendationPackage.SourcePopular,
+			BecauseOf: []string{},
+		})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Recommendation{}, err
+	}
+	return recommendations, nil
+}
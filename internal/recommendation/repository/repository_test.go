@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"testing"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/recommendation"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*RecommendationRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testRecommendationRepo := NewRecommendationRepository(mock)
+	return testRecommendationRepo, mock
+}
+
+var filmColumns = []string{"id", "title", "description", "release_date", "rating", "genres", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster",
+	"community_rating", "vote_count"}
+
+func filmRow(id int, title, releaseDate string, rating int, extra ...any) []any {
+	row := []any{id, title, "", releaseDate, rating, []string{}, 0, []string{}, "", []string{}, "",
+		int64(0), int64(0), "", nil, nil, 0}
+	return append(row, extra...)
+}
+
+func TestShouldSuccessfullyGetSimilarFilms(t *testing.T) {
+	recommendationRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("with target as (.+) order by s.score desc").
+		WithArgs(&filmID, 10, recommendation.SharedActorWeight, recommendation.SharedGenreWeight,
+			recommendation.ReleaseYearWeight, recommendation.RatingWeight, recommendation.CloseReleaseYears).
+		WillReturnRows(pgxmock.NewRows(append(filmColumns, "score", "actors", "genres", "year_difference",
+			"rating_difference")).
+			AddRow(filmRow(5, "Отступники", "2006-09-26", 9, 4.2, []string{"Леонардо Ди Каприо"}, []string{}, 9, 1)...).
+			AddRow(filmRow(7, "Перл-Харбор", "2001-05-21", 6, 3.1, []string{}, []string{"Драма", "Мелодрама"}, 4, 2)...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, err := recommendationRepo.GetSimilarFilms(filmID, 10)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resultFilms))
+	assert.Equal(t, []string{recommendation.ReasonSharedCast, recommendation.ReasonSimilarRating}, resultFilms[0].Reasons)
+	assert.Equal(t, []string{recommendation.ReasonSharedGenres, recommendation.ReasonCloseRelease}, resultFilms[1].Reasons)
+}
+
+func TestShouldFailToGetFilmsSimilarToNonExistentFilm(t *testing.T) {
+	recommendationRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilms, err := recommendationRepo.GetSimilarFilms(filmID, 10)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Empty(t, resultFilms)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyGetRecommendations(t *testing.T) {
+	recommendationRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID := 1
+	ten, eight, nine := 10, 8, 9
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select r.user_id, r.film_id, r.rating from review").WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "film_id", "rating"}).
+			AddRow(1, 1, &ten).
+			AddRow(2, 1, &ten).
+			AddRow(2, 2, &eight).
+			AddRow(2, 3, &nine).
+			AddRow(3, 1, nil).
+			AddRow(3, 4, nil)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("where f.id = any").WithArgs([]int{2, 3, 4}).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(4, "Остров проклятых", "2010-02-13", 8)...).
+			AddRow(filmRow(3, "Отступники", "2006-09-26", 9)...).
+			AddRow(filmRow(2, "Начало", "2010-07-08", 9)...)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("select id, title from film").WithArgs([]int{1}).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title"}).AddRow(1, "Титаник")).
+		RowsWillBeClosed()
+	mock.ExpectQuery("where f.id <> all").WithArgs([]int{1, 2, 3, 4}, 1).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(5, "Аватар", "2009-12-10", 8)...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	recommendations, err := recommendationRepo.GetRecommendations(userID, 4)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(recommendations))
+	assert.Equal(t, 2, recommendations[0].ID)
+	assert.Equal(t, recommendation.SourceCollaborative, recommendations[0].Source)
+	assert.Equal(t, []string{"Титаник"}, recommendations[0].BecauseOf)
+	assert.Equal(t, 3, recommendations[1].ID)
+	assert.Equal(t, 4, recommendations[2].ID)
+	assert.Equal(t, 5, recommendations[3].ID)
+	assert.Equal(t, recommendation.SourcePopular, recommendations[3].Source)
+}
+
+func TestShouldRecommendPopularFilmsForColdStartUser(t *testing.T) {
+	recommendationRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID := 1
+	ten := 10
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select r.user_id, r.film_id, r.rating from review").WithArgs(userID).
+		WillReturnRows(pgxmock.NewRows([]string{"user_id", "film_id", "rating"}).
+			AddRow(2, 1, &ten)).
+		RowsWillBeClosed()
+	mock.ExpectQuery("where f.id <> all").WithArgs([]int{}, 2).
+		WillReturnRows(pgxmock.NewRows(filmColumns).
+			AddRow(filmRow(1, "Титаник", "1997-11-01", 8)...).
+			AddRow(filmRow(5, "Аватар", "2009-12-10", 8)...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	recommendations, err := recommendationRepo.GetRecommendations(userID, 2)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(recommendations))
+	assert.Equal(t, recommendation.SourcePopular, recommendations[0].Source)
+	assert.Equal(t, []string{}, recommendations[0].BecauseOf)
+}
@@ -0,0 +1,349 @@
+package delivery
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/internal/review"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "review:delivery:"
+
+type ReviewDelivery struct {
+	reviewRepo review.ReviewRepository
+}
+
+func NewReviewDelivery(rR review.ReviewRepository) *ReviewDelivery {
+	return &ReviewDelivery{
+		reviewRepo: rR,
+	}
+}
+
+// HandleFilmReviews handles /films/{id}/reviews
+func (rD *ReviewDelivery) HandleFilmReviews(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		rD.GetFilmReviews(w, r)
+	case http.MethodPut:
+		rD.SetReview(w, r)
+	case http.MethodDelete:
+		rD.DeleteReview(w, r)
+	}
+}
+
+// swagger:route GET /films/{id}/reviews Reviews getFilmReviews
+// Возвращает опубликованные оценки и отзывы пользователей о фильме, сначала новые
+// responses:
+//
+//	200: []review
+//	400: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (rD *ReviewDelivery) GetFilmReviews(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	filmID, err := parseID(r, "/films/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultReviews, err := rD.reviewRepo.GetFilmReviews(filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultReviews)
+}
+
+// swagger:route PUT /films/{id}/reviews Reviews setReview
+// Ставит фильму оценку от имени пользователя из заголовка Authorization, текст отзыва необязателен.
+// У пользователя одна оценка на фильм, повторный запрос заменяет прежнюю.
+// Оценки без текста и отзывы доверенных пользователей публикуются сразу, остальные ждут модератора (status=pending)
+// security:
+// - key:
+// responses:
+//
+//	200: review
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (rD *ReviewDelivery) SetReview(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "SetReview:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	filmID, err := parseID(r, "/films/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	var reviewRequest models.ReviewRequest
+	err = json.NewDecoder(r.Body).Decode(&reviewRequest)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateReview(&reviewRequest).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultReview, err := rD.reviewRepo.SetReview(filmID, userID, &reviewRequest)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultReview)
+}
+
+// swagger:route DELETE /films/{id}/reviews Reviews deleteReview
+// Удаляет оценку и отзыв пользователя из заголовка Authorization
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (rD *ReviewDelivery) DeleteReview(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	filmID, err := parseID(r, "/films/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = rD.reviewRepo.DeleteReview(filmID, userID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// HandleReviewFlags handles /reviews/{id}/flags
+func (rD *ReviewDelivery) HandleReviewFlags(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		rD.FlagReview(w, r)
+	}
+}
+
+// HandleReviewModeration handles /reviews/{id}/moderation
+func (rD *ReviewDelivery) HandleReviewModeration(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		rD.ModerateReview(w, r)
+	}
+}
+
+// HandleModerationQueue handles /moderation/reviews
+func (rD *ReviewDelivery) HandleModerationQueue(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		rD.GetModerationQueue(w, r)
+	}
+}
+
+// swagger:route POST /reviews/{id}/flags Reviews flagReview
+// Жалоба пользователя из заголовка Authorization на опубликованный отзыв.
+// Повторная жалоба того же пользователя заменяет прежнюю
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (rD *ReviewDelivery) FlagReview(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "FlagReview:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	reviewID, err := parseID(r, "/reviews/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	// Причина жалобы необязательна, тело запроса может быть пустым
+	var flag models.ReviewFlagRequest
+	err = json.NewDecoder(r.Body).Decode(&flag)
+	if err != nil && err != io.EOF {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateReviewFlag(&flag).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = rD.reviewRepo.FlagReview(reviewID, userID, &flag)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// swagger:route POST /reviews/{id}/moderation Reviews moderateReview
+// Решение модератора: approve публикует отзыв, reject отклоняет с обязательной причиной, hide скрывает.
+// Жалобы на отзыв после решения закрываются. Доступно модераторам и администраторам
+// security:
+// - key:
+// responses:
+//
+//	200: review
+//	400: problemResponse
+//  401: problemResponse
+//	403: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (rD *ReviewDelivery) ModerateReview(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "ModerateReview:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	moderatorID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	reviewID, err := parseID(r, "/reviews/")
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	var moderation models.ReviewModerationRequest
+	err = json.NewDecoder(r.Body).Decode(&moderation)
+	if err != nil {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateReviewModeration(&moderation).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultReview, err := rD.reviewRepo.ModerateReview(reviewID, moderatorID, &moderation)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultReview)
+}
+
+// swagger:route GET /moderation/reviews Reviews getModerationQueue
+// Очередь модерации: отзывы на проверке (queue=pending) и опубликованные отзывы с жалобами (queue=flagged),
+// без queue - обе очереди. Сначала отзывы с большим числом жалоб, затем - дольше ожидающие проверки.
+// film_id и user_id - фильтры по фильму и автору, limit (по умолчанию 20, не больше 100) и offset - страница.
+// Общее число отзывов в очереди - в заголовке X-Total-Count. Доступно модераторам и администраторам
+// security:
+// - key:
+// responses:
+//
+//	200: []moderationQueueItem
+//	400: problemResponse
+//  401: problemResponse
+//	403: problemResponse
+//	500: problemResponse
+func (rD *ReviewDelivery) GetModerationQueue(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetModerationQueue:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	filter, err := parseModerationFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultItems, total, err := rD.reviewRepo.GetModerationQueue(filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultItems)
+}
+
+func parseModerationFilter(r *http.Request) (*models.ModerationFilter, error) {
+	query := r.URL.Query()
+	filter := &models.ModerationFilter{
+		Queue: query.Get("queue"),
+		Limit: review.DefaultPageSize,
+	}
+	if filter.Queue != "" && !review.IsValidQueue(filter.Queue) {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Unknown queue")
+	}
+
+	intParams := []struct {
+		name     string
+		target   *int
+		min, max int
+	}{
+		{"film_id", &filter.FilmID, 1, math.MaxInt32},
+		{"user_id", &filter.UserID, 1, math.MaxInt32},
+		{"limit", &filter.Limit, 1, review.MaxPageSize},
+		{"offset", &filter.Offset, 0, math.MaxInt32},
+	}
+	for _, param := range intParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < param.min || number > param.max {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Invalid " + param.name)
+		}
+		*param.target = number
+	}
+	return filter, nil
+}
+
+// parseID returns id from path like prefix{id}/{subresource}
+func parseID(r *http.Request, prefix string) (int, error) {
+	segments := router.PathSegments(r.URL.Path, prefix)
+	id, err := strconv.Atoi(segments[0])
+	if err != nil {
+		return 0, apperror.ErrInvalidID
+	}
+	return id, nil
+}
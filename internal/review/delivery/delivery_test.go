@@ -0,0 +1,381 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/internal/review"
+	"vk-intern_test-case/internal/review/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type reviewTest struct {
+	name string
+	// ID пользователя, которого определил middleware, 0 - без авторизации
+	userID             int
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockReviewRepository *mock.MockReviewRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var reviewTime = time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC)
+
+var titanicReview = models.Review{
+	ID:            1,
+	FilmID:        2,
+	UserID:        1,
+	ReviewRequest: models.ReviewRequest{Rating: 9, Text: "Лучший фильм Кэмерона"},
+	Status:        review.StatusPublished,
+	CreatedAt:     reviewTime,
+	UpdatedAt:     reviewTime,
+}
+
+const titanicReviewJSON = `{
+	"id": 1,
+	"film_id": 2,
+	"user_id": 1,
+	"rating": 9,
+	"text": "Лучший фильм Кэмерона",
+	"status": "published",
+	"created_at": "2024-03-18T12:00:00Z",
+	"updated_at": "2024-03-18T12:00:00Z"
+}`
+
+var filmReviewsTests = []reviewTest{
+	{
+		"Successfully get Film reviews",
+		0,
+		http.MethodGet,
+		"/films/2/reviews",
+		"",
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				GetFilmReviews(2).
+				Return([]models.Review{titanicReview}, nil)
+		},
+		`[` + titanicReviewJSON + `]`,
+		http.StatusOK,
+	},
+	{
+		"Successfully rate a Film",
+		1,
+		http.MethodPut,
+		"/films/2/reviews",
+		`{"rating": 9, "text": "Лучший фильм Кэмерона"}`,
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				SetReview(2, 1, &models.ReviewRequest{Rating: 9, Text: "Лучший фильм Кэмерона"}).
+				Return(&titanicReview, nil)
+		},
+		titanicReviewJSON,
+		http.StatusOK,
+	},
+	{
+		"Fail to rate a Film with rating out of range",
+		1,
+		http.MethodPut,
+		"/films/2/reviews",
+		`{"rating": 11}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/films/2/reviews",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "rating", "code": "out_of_range", "message": "rating must be between 1 and 10"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to rate a Film without authorization",
+		0,
+		http.MethodPut,
+		"/films/2/reviews",
+		`{"rating": 9}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unauthorized",
+			"status": 401,
+			"detail": "Authorization header with a valid user id is required",
+			"instance": "/films/2/reviews",
+			"code": "unauthorized"
+		}`,
+		http.StatusUnauthorized,
+	},
+	{
+		"Fail to rate a non-existent Film",
+		1,
+		http.MethodPut,
+		"/films/2/reviews",
+		`{"rating": 9}`,
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				SetReview(2, 1, &models.ReviewRequest{Rating: 9}).
+				Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/films/2/reviews",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully delete a review",
+		1,
+		http.MethodDelete,
+		"/films/2/reviews",
+		"",
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				DeleteReview(2, 1).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to delete a non-existent review",
+		1,
+		http.MethodDelete,
+		"/films/2/reviews",
+		"",
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				DeleteReview(2, 1).
+				Return(review.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Review not found",
+			"instance": "/films/2/reviews",
+			"code": "review_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+}
+
+func TestHandleFilmReviews(t *testing.T) {
+	runReviewTests(t, filmReviewsTests, func(reviewDelivery *ReviewDelivery) http.HandlerFunc {
+		return reviewDelivery.HandleFilmReviews
+	})
+}
+
+var reviewModerationTests = []reviewTest{
+	{
+		"Successfully flag a review without reason",
+		3,
+		http.MethodPost,
+		"/reviews/1/flags",
+		"",
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				FlagReview(1, 3, &models.ReviewFlagRequest{}).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to flag a non-published review",
+		3,
+		http.MethodPost,
+		"/reviews/1/flags",
+		`{"reason": "Оскорбления"}`,
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				FlagReview(1, 3, &models.ReviewFlagRequest{Reason: "Оскорбления"}).
+				Return(review.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Review not found",
+			"instance": "/reviews/1/flags",
+			"code": "review_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully reject a review",
+		2,
+		http.MethodPost,
+		"/reviews/1/moderation",
+		`{"action": "reject", "reason": "Спойлеры"}`,
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			rejected := titanicReview
+			rejected.Status = review.StatusRejected
+			rejected.ModerationReason = "Спойлеры"
+			mockReviewRepository.EXPECT().
+				ModerateReview(1, 2, &models.ReviewModerationRequest{Action: "reject", Reason: "Спойлеры"}).
+				Return(&rejected, nil)
+		},
+		`{
+			"id": 1,
+			"film_id": 2,
+			"user_id": 1,
+			"rating": 9,
+			"text": "Лучший фильм Кэмерона",
+			"status": "rejected",
+			"moderation_reason": "Спойлеры",
+			"created_at": "2024-03-18T12:00:00Z",
+			"updated_at": "2024-03-18T12:00:00Z"
+		}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to reject a review without reason",
+		2,
+		http.MethodPost,
+		"/reviews/1/moderation",
+		`{"action": "reject"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/reviews/1/moderation",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "reason", "code": "required", "message": "reason is required"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+}
+
+func TestReviewModeration(t *testing.T) {
+	runReviewTests(t, reviewModerationTests, func(reviewDelivery *ReviewDelivery) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/flags") {
+				reviewDelivery.HandleReviewFlags(w, r)
+				return
+			}
+			reviewDelivery.HandleReviewModeration(w, r)
+		}
+	})
+}
+
+var moderationQueueTests = []reviewTest{
+	{
+		"Successfully get flagged reviews of a Film",
+		2,
+		http.MethodGet,
+		"/moderation/reviews?queue=flagged&film_id=2&limit=10",
+		"",
+		func(mockReviewRepository *mock.MockReviewRepository) {
+			mockReviewRepository.EXPECT().
+				GetModerationQueue(&models.ModerationFilter{Queue: review.QueueFlagged, FilmID: 2, Limit: 10}).
+				Return([]models.ModerationQueueItem{{
+					Review:      titanicReview,
+					FilmTitle:   "Титаник",
+					FlagCount:   2,
+					FlagReasons: []string{"Оскорбления"},
+				}}, 1, nil)
+		},
+		`[{
+			"id": 1,
+			"film_id": 2,
+			"user_id": 1,
+			"rating": 9,
+			"text": "Лучший фильм Кэмерона",
+			"status": "published",
+			"created_at": "2024-03-18T12:00:00Z",
+			"updated_at": "2024-03-18T12:00:00Z",
+			"film_title": "Титаник",
+			"flag_count": 2,
+			"flag_reasons": ["Оскорбления"]
+		}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get unknown moderation queue",
+		2,
+		http.MethodGet,
+		"/moderation/reviews?queue=spam",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown queue",
+			"instance": "/moderation/reviews",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+}
+
+func TestHandleModerationQueue(t *testing.T) {
+	runReviewTests(t, moderationQueueTests, func(reviewDelivery *ReviewDelivery) http.HandlerFunc {
+		return reviewDelivery.HandleModerationQueue
+	})
+}
+
+func runReviewTests(t *testing.T, tests []reviewTest, handler func(*ReviewDelivery) http.HandlerFunc) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockReviewRepository := mock.NewMockReviewRepository(ctrl)
+			reviewDeliveryTest := NewReviewDelivery(mockReviewRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockReviewRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+			if test.userID != 0 {
+				request = request.WithContext(middleware.WithUserID(request.Context(), test.userID))
+			}
+
+			handler(reviewDeliveryTest)(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/review/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReviewRepository is a mock of ReviewRepository interface.
+type MockReviewRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReviewRepositoryMockRecorder
+}
+
+// MockReviewRepositoryMockRecorder is the mock recorder for MockReviewRepository.
+type MockReviewRepositoryMockRecorder struct {
+	mock *MockReviewRepository
+}
+
+// NewMockReviewRepository creates a new mock instance.
+func NewMockReviewRepository(ctrl *gomock.Controller) *MockReviewRepository {
+	mock := &MockReviewRepository{ctrl: ctrl}
+	mock.recorder = &MockReviewRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReviewRepository) EXPECT() *MockReviewRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteReview mocks base method.
+func (m *MockReviewRepository) DeleteReview(filmID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReview", filmID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReview indicates an expected call of DeleteReview.
+func (mr *MockReviewRepositoryMockRecorder) DeleteReview(filmID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReview", reflect.TypeOf((*MockReviewRepository)(nil).DeleteReview), filmID, userID)
+}
+
+// FlagReview mocks base method.
+func (m *MockReviewRepository) FlagReview(reviewID, userID int, flag *models.ReviewFlagRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagReview", reviewID, userID, flag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagReview indicates an expected call of FlagReview.
+func (mr *MockReviewRepositoryMockRecorder) FlagReview(reviewID, userID, flag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagReview", reflect.TypeOf((*MockReviewRepository)(nil).FlagReview), reviewID, userID, flag)
+}
+
+// GetFilmReviews mocks base method.
+func (m *MockReviewRepository) GetFilmReviews(filmID int) ([]models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmReviews", filmID)
+	ret0, _ := ret[0].([]models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmReviews indicates an expected call of GetFilmReviews.
+func (mr *MockReviewRepositoryMockRecorder) GetFilmReviews(filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmReviews", reflect.TypeOf((*MockReviewRepository)(nil).GetFilmReviews), filmID)
+}
+
+// GetModerationQueue mocks base method.
+func (m *MockReviewRepository) GetModerationQueue(filter *models.ModerationFilter) ([]models.ModerationQueueItem, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetModerationQueue", filter)
+	ret0, _ := ret[0].([]models.ModerationQueueItem)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetModerationQueue indicates an expected call of GetModerationQueue.
+func (mr *MockReviewRepositoryMockRecorder) GetModerationQueue(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetModerationQueue", reflect.TypeOf((*MockReviewRepository)(nil).GetModerationQueue), filter)
+}
+
+// ModerateReview mocks base method.
+func (m *MockReviewRepository) ModerateReview(reviewID, moderatorID int, moderation *models.ReviewModerationRequest) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReview", reviewID, moderatorID, moderation)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateReview indicates an expected call of ModerateReview.
+func (mr *MockReviewRepositoryMockRecorder) ModerateReview(reviewID, moderatorID, moderation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReview", reflect.TypeOf((*MockReviewRepository)(nil).ModerateReview), reviewID, moderatorID, moderation)
+}
+
+// SetReview mocks base method.
+func (m *MockReviewRepository) SetReview(filmID, userID int, review *models.ReviewRequest) (*models.Review, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReview", filmID, userID, review)
+	ret0, _ := ret[0].(*models.Review)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetReview indicates an expected call of SetReview.
+func (mr *MockReviewRepositoryMockRecorder) SetReview(filmID, userID, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReview", reflect.TypeOf((*MockReviewRepository)(nil).SetReview), filmID, userID, review)
+}
@@ -0,0 +1,55 @@
+package queries
+
+const reviewColumns = `r.id, r.film_id, r.user_id, r.rating, r.text, r.status, r.moderation_reason, r.created_at, r.updated_at`
+
+const (
+	GetFilmIDByID  = `select id from film where id = $1;`
+	GetFilmReviews = `select ` + reviewColumns + ` from review as r
+		where r.film_id = $1 and r.status = 'published'
+		order by r.updated_at desc, r.id desc;`
+	// Доверенные пользователи - модераторы, администраторы и авторы нескольких опубликованных отзывов
+	// без отклонённых
+	IsTrustedUser = `select u.role in ('Администратор', 'Модератор')
+		or ((select count(*) from review as r where r.user_id = u.id and r.status = 'published' and r.text <> '') >= $2
+			and not exists (select 1 from review as r where r.user_id = u.id and r.status = 'rejected'))
+		from service_user as u where u.id = $1;`
+	// Повторная оценка того же фильма заменяет прежнюю и заново проходит модерацию,
+	// прежнее решение модератора сбрасывается
+	SetReview = `insert into review as r (film_id, user_id, rating, text, status) values ($1, $2, $3, $4, $5)
+		on conflict (film_id, user_id) do update set rating = excluded.rating, text = excluded.text,
+			status = excluded.status, moderation_reason = '', moderated_by = null, moderated_at = null,
+			updated_at = now()
+		returning ` + reviewColumns + `;`
+	// Жалобы относятся к тексту отзыва и снимаются, когда автор его меняет
+	DeleteOutdatedReviewFlags = `delete from review_flag as fl using review as r
+		where fl.review_id = r.id and r.film_id = $1 and r.user_id = $2 and r.text <> $3;`
+	DeleteReview = `delete from review where film_id = $1 and user_id = $2;`
+)
+
+const (
+	GetPublishedReviewID = `select id from review where id = $1 and status = 'published';`
+	FlagReview           = `insert into review_flag (review_id, user_id, reason) values ($1, $2, $3)
+		on conflict (review_id, user_id) do update set reason = excluded.reason;`
+	ModerateReview = `update review as r set status = $1, moderation_reason = $2, moderated_by = $3, moderated_at = now()
+		where r.id = $4
+		returning ` + reviewColumns + `;`
+	// Жалобы на отзыв считаются рассмотренными после решения модератора
+	DeleteReviewFlags = `delete from review_flag where review_id = $1;`
+)
+
+// Очередь модерации, условия добавляются при построении запроса
+const (
+	moderationQueueFrom = ` from review as r join film as f on f.id = r.film_id where `
+	GetModerationQueue  = `select ` + reviewColumns + `, f.title,
+		(select count(*)::int from review_flag as fl where fl.review_id = r.id),
+		array(select fl.reason from review_flag as fl where fl.review_id = r.id and fl.reason <> ''
+			order by fl.created_at)` + moderationQueueFrom
+	CountModerationQueue = `select count(*)` + moderationQueueFrom
+	PendingCondition     = `r.status = 'pending'`
+	FlaggedCondition     = `r.status = 'published' and exists (select 1 from review_flag as fl where fl.review_id = r.id)`
+	QueueFilmCondition   = `r.film_id = $%d`
+	QueueUserCondition   = `r.user_id = $%d`
+	// Сначала отзывы с большим числом жалоб, затем - давно ожидающие проверки
+	ModerationQueueOrder = ` order by (select count(*) from review_flag as fl where fl.review_id = r.id) desc,
+		r.updated_at, r.id limit $%d offset $%d;`
+)
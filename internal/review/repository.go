@@ -0,0 +1,12 @@
+package review
+
+import "vk-intern_test-case/models"
+
+type ReviewRepository interface {
+	GetFilmReviews(filmID int) ([]models.Review, error)
+	SetReview(filmID int, userID int, review *models.ReviewRequest) (*models.Review, error)
+	DeleteReview(filmID int, userID int) error
+	FlagReview(reviewID int, userID int, flag *models.ReviewFlagRequest) error
+	ModerateReview(reviewID int, moderatorID int, moderation *models.ReviewModerationRequest) (*models.Review, error)
+	GetModerationQueue(filter *models.ModerationFilter) ([]models.ModerationQueueItem, int, error)
+}
@@ -0,0 +1,324 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	filmPackage "vk-intern_test-case/internal/film"
+	reviewPackage "vk-intern_test-case/internal/review"
+	reviewQueries "vk-intern_test-case/internal/review/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "review:repository:"
+
+type ReviewRepository struct {
+	pool database.PgxIface
+}
+
+func NewReviewRepository(pool database.PgxIface) *ReviewRepository {
+	return &ReviewRepository{
+		pool: pool,
+	}
+}
+
+func (rR *ReviewRepository) GetFilmReviews(filmID int) ([]models.Review, error) {
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.Review{}, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return []models.Review{}, err
+	}
+
+	reviews := []models.Review{}
+	rows, err := tx.Query(transactionCtx, reviewQueries.GetFilmReviews, &filmID)
+	if err != nil {
+		return []models.Review{}, err
+	}
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return []models.Review{}, err
+		}
+		reviews = append(reviews, *review)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.Review{}, err
+	}
+	return reviews, nil
+}
+
+// SetReview добавляет оценку пользователя или заменяет его прежнюю оценку фильма
+func (rR *ReviewRepository) SetReview(filmID int, userID int, reviewRequest *models.ReviewRequest) (*models.Review, error) {
+	message := logMessage + "SetReview:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	err = checkFilmExists(transactionCtx, tx, filmID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Оценки без текста и отзывы доверенных пользователей публикуются сразу
+	status := reviewPackage.StatusPublished
+	if reviewRequest.Text != "" {
+		var trusted bool
+		row := tx.QueryRow(transactionCtx, reviewQueries.IsTrustedUser, &userID, reviewPackage.TrustedReviewsThreshold)
+		err = row.Scan(&trusted)
+		if err != nil {
+			return nil, err
+		}
+		if !trusted {
+			status = reviewPackage.StatusPending
+		}
+	}
+
+	_, err = tx.Exec(transactionCtx, reviewQueries.DeleteOutdatedReviewFlags, &filmID, &userID, &reviewRequest.Text)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+
+	row := tx.QueryRow(transactionCtx, reviewQueries.SetReview, &filmID, &userID, &reviewRequest.Rating,
+		&reviewRequest.Text, status)
+	review, err := scanReview(row)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+	return review, nil
+}
+
+func (rR *ReviewRepository) DeleteReview(filmID int, userID int) error {
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, reviewQueries.DeleteReview, &filmID, &userID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = reviewPackage.ErrNotFound
+		return err
+	}
+	return nil
+}
+
+// FlagReview сохраняет жалобу пользователя на опубликованный отзыв. Повторная жалоба заменяет прежнюю
+func (rR *ReviewRepository) FlagReview(reviewID int, userID int, flag *models.ReviewFlagRequest) error {
+	message := logMessage + "FlagReview:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var id int
+	row := tx.QueryRow(transactionCtx, reviewQueries.GetPublishedReviewID, &reviewID)
+	err = row.Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = reviewPackage.ErrNotFound
+			return err
+		}
+		return err
+	}
+
+	_, err = tx.Exec(transactionCtx, reviewQueries.FlagReview, &reviewID, &userID, &flag.Reason)
+	if err != nil {
+		log.Error(message + err.Error())
+		return database.MapError(err)
+	}
+	return nil
+}
+
+// ModerateReview меняет статус отзыва по решению модератора и закрывает жалобы на него
+func (rR *ReviewRepository) ModerateReview(reviewID int, moderatorID int, moderation *models.ReviewModerationRequest) (*models.Review, error) {
+	message := logMessage + "ModerateReview:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	status := reviewPackage.ActionStatuses[moderation.Action]
+	row := tx.QueryRow(transactionCtx, reviewQueries.ModerateReview, status, &moderation.Reason, &moderatorID, &reviewID)
+	review, err := scanReview(row)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = reviewPackage.ErrNotFound
+			return nil, err
+		}
+		return nil, err
+	}
+
+	_, err = tx.Exec(transactionCtx, reviewQueries.DeleteReviewFlags, &reviewID)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+// GetModerationQueue возвращает страницу очереди модерации и общее число отзывов в ней
+func (rR *ReviewRepository) GetModerationQueue(filter *models.ModerationFilter) ([]models.ModerationQueueItem, int, error) {
+	message := logMessage + "GetModerationQueue:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := rR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.ModerationQueueItem{}, 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	conditions, args := moderationConditions(filter)
+	var total int
+	row := tx.QueryRow(transactionCtx, reviewQueries.CountModerationQueue+conditions, args...)
+	err = row.Scan(&total)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.ModerationQueueItem{}, 0, err
+	}
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := reviewQueries.GetModerationQueue + conditions +
+		fmt.Sprintf(reviewQueries.ModerationQueueOrder, len(args)-1, len(args))
+	rows, err := tx.Query(transactionCtx, query, args...)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.ModerationQueueItem{}, 0, err
+	}
+
+	items := []models.ModerationQueueItem{}
+	for rows.Next() {
+		item := models.ModerationQueueItem{}
+		review, err := scanReview(rows, &item.FilmTitle, &item.FlagCount, &item.FlagReasons)
+		if err != nil {
+			return []models.ModerationQueueItem{}, 0, err
+		}
+		item.Review = *review
+		items = append(items, item)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.ModerationQueueItem{}, 0, err
+	}
+	return items, total, nil
+}
+
+// moderationConditions returns where clause of the moderation queue and its arguments
+func moderationConditions(filter *models.ModerationFilter) (string, []any) {
+	var conditions []string
+	args := []any{}
+	switch filter.Queue {
+	case reviewPackage.QueuePending:
+		conditions = append(conditions, reviewQueries.PendingCondition)
+	case reviewPackage.QueueFlagged:
+		conditions = append(conditions, reviewQueries.FlaggedCondition)
+	default:
+		conditions = append(conditions, "(("+reviewQueries.PendingCondition+") or ("+reviewQueries.FlaggedCondition+"))")
+	}
+	if filter.FilmID > 0 {
+		args = append(args, filter.FilmID)
+		conditions = append(conditions, fmt.Sprintf(reviewQueries.QueueFilmCondition, len(args)))
+	}
+	if filter.UserID > 0 {
+		args = append(args, filter.UserID)
+		conditions = append(conditions, fmt.Sprintf(reviewQueries.QueueUserCondition, len(args)))
+	}
+	return strings.Join(conditions, " and "), args
+}
+
+func scanReview(row pgx.Row, extra ...any) (*models.Review, error) {
+	review := &models.Review{}
+	dest := []any{&review.ID, &review.FilmID, &review.UserID, &review.Rating, &review.Text, &review.Status,
+		&review.ModerationReason, &review.CreatedAt, &review.UpdatedAt}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	return review, nil
+}
+
+func checkFilmExists(ctx context.Context, tx pgx.Tx, filmID int) error {
+	var id int
+	row := tx.QueryRow(ctx, reviewQueries.GetFilmIDByID, &filmID)
+	err := row.Scan(&id)
+	if err == pgx.ErrNoRows {
+		return filmPackage.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/review"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*ReviewRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testReviewRepo := NewReviewRepository(mock)
+	return testReviewRepo, mock
+}
+
+var reviewColumns = []string{"id", "film_id", "user_id", "rating", "text", "status", "moderation_reason",
+	"created_at", "updated_at"}
+
+func TestShouldSuccessfullySetReview(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID, userID := 2, 1
+	reviewRequest := &models.ReviewRequest{Rating: 9, Text: "Лучший фильм Кэмерона"}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("from service_user as u where u.id = \\$1").
+		WithArgs(&userID, review.TrustedReviewsThreshold).
+		WillReturnRows(pgxmock.NewRows([]string{"trusted"}).AddRow(true))
+	mock.ExpectExec("delete from review_flag as fl using review as r (.+) and r.text <> \\$3").
+		WithArgs(&filmID, &userID, &reviewRequest.Text).WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectQuery("insert into review (.+) on conflict \\(film_id, user_id\\) do update (.+) moderated_by = null, moderated_at = null").
+		WithArgs(&filmID, &userID, &reviewRequest.Rating, &reviewRequest.Text, review.StatusPublished).
+		WillReturnRows(pgxmock.NewRows(reviewColumns).
+			AddRow(1, filmID, userID, 9, reviewRequest.Text, review.StatusPublished, "", now, now))
+	mock.ExpectCommit()
+
+	resultReview, err := reviewRepo.SetReview(filmID, userID, reviewRequest)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, &models.Review{ID: 1, FilmID: filmID, UserID: userID, ReviewRequest: *reviewRequest,
+		Status: review.StatusPublished, CreatedAt: now, UpdatedAt: now}, resultReview)
+}
+
+func TestShouldKeepUntrustedReviewPending(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID, userID := 2, 1
+	reviewRequest := &models.ReviewRequest{Rating: 9, Text: "Лучший фильм Кэмерона"}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("from service_user as u where u.id = \\$1").
+		WithArgs(&userID, review.TrustedReviewsThreshold).
+		WillReturnRows(pgxmock.NewRows([]string{"trusted"}).AddRow(false))
+	mock.ExpectExec("delete from review_flag").
+		WithArgs(&filmID, &userID, &reviewRequest.Text).WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectQuery("insert into review").
+		WithArgs(&filmID, &userID, &reviewRequest.Rating, &reviewRequest.Text, review.StatusPending).
+		WillReturnRows(pgxmock.NewRows(reviewColumns).
+			AddRow(1, filmID, userID, 9, reviewRequest.Text, review.StatusPending, "", now, now))
+	mock.ExpectCommit()
+
+	resultReview, err := reviewRepo.SetReview(filmID, userID, reviewRequest)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, review.StatusPending, resultReview.Status)
+}
+
+func TestShouldFailToReviewNonExistentFilm(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultReview, err := reviewRepo.SetReview(filmID, 1, &models.ReviewRequest{Rating: 9})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultReview)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyGetFilmReviews(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectQuery("from review as r where r.film_id = \\$1 and r.status = 'published'").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows(reviewColumns).
+			AddRow(2, filmID, 2, 7, "", review.StatusPublished, "", now, now).
+			AddRow(1, filmID, 1, 9, "Лучший фильм Кэмерона", review.StatusPublished, "", now, now)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultReviews, err := reviewRepo.GetFilmReviews(filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resultReviews))
+	assert.Equal(t, 7, resultReviews[0].Rating)
+}
+
+func TestShouldReturnNotFoundWhenDeletingMissingReview(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID, userID := 2, 1
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from review").WithArgs(&filmID, &userID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := reviewRepo.DeleteReview(filmID, userID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, review.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyRejectReview(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	reviewID, moderatorID := 1, 2
+	moderation := &models.ReviewModerationRequest{Action: review.ActionReject, Reason: "Спойлеры"}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("update review as r set status = \\$1").
+		WithArgs(review.StatusRejected, &moderation.Reason, &moderatorID, &reviewID).
+		WillReturnRows(pgxmock.NewRows(reviewColumns).
+			AddRow(reviewID, 2, 1, 9, "Спойлер!", review.StatusRejected, moderation.Reason, now, now))
+	mock.ExpectExec("delete from review_flag").WithArgs(&reviewID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+	mock.ExpectCommit()
+
+	resultReview, err := reviewRepo.ModerateReview(reviewID, moderatorID, moderation)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, review.StatusRejected, resultReview.Status)
+	assert.Equal(t, "Спойлеры", resultReview.ModerationReason)
+}
+
+func TestShouldFailToFlagNonPublishedReview(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	reviewID := 1
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from review where id = \\$1 and status = 'published'").WithArgs(&reviewID).
+		WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	err := reviewRepo.FlagReview(reviewID, 3, &models.ReviewFlagRequest{})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, review.ErrNotFound, err)
+}
+
+func TestShouldSuccessfullyGetModerationQueue(t *testing.T) {
+	reviewRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filter := &models.ModerationFilter{Queue: review.QueueFlagged, FilmID: 2, Limit: 20}
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select count\\(\\*\\) (.+) exists (.+) and r.film_id = \\$1").WithArgs(2).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery("and r.film_id = \\$1 (.+) limit \\$2 offset \\$3").WithArgs(2, 20, 0).
+		WillReturnRows(pgxmock.NewRows(append(reviewColumns, "title", "flag_count", "flag_reasons")).
+			AddRow(1, 2, 1, 9, "Спойлер!", review.StatusPublished, "", now, now,
+				"Титаник", 2, []string{"Спойлеры"})).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultItems, total, err := reviewRepo.GetModerationQueue(filter)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, []models.ModerationQueueItem{{
+		Review: models.Review{ID: 1, FilmID: 2, UserID: 1, ReviewRequest: models.ReviewRequest{Rating: 9, Text: "Спойлер!"},
+			Status: review.StatusPublished, CreatedAt: now, UpdatedAt: now},
+		FilmTitle:   "Титаник",
+		FlagCount:   2,
+		FlagReasons: []string{"Спойлеры"},
+	}}, resultItems)
+}
@@ -0,0 +1,51 @@
+package review
+
+import "vk-intern_test-case/utils/apperror"
+
+var ErrNotFound = apperror.NotFound("review_not_found", "Review not found")
+
+// Статусы отзыва. Пользователям видны и в оценке фильма учитываются только опубликованные отзывы
+const (
+	StatusPending   = "pending"
+	StatusPublished = "published"
+	StatusRejected  = "rejected"
+	StatusHidden    = "hidden"
+)
+
+// Действия модератора
+const (
+	ActionApprove = "approve"
+	ActionReject  = "reject"
+	ActionHide    = "hide"
+)
+
+// ActionStatuses maps moderator actions to resulting review statuses
+var ActionStatuses = map[string]string{
+	ActionApprove: StatusPublished,
+	ActionReject:  StatusRejected,
+	ActionHide:    StatusHidden,
+}
+
+// Очереди модерации: отзывы на проверке и опубликованные отзывы с жалобами
+const (
+	QueuePending = "pending"
+	QueueFlagged = "flagged"
+)
+
+func IsValidQueue(queue string) bool {
+	switch queue {
+	case QueuePending, QueueFlagged:
+		return true
+	}
+	return false
+}
+
+// Сколько опубликованных отзывов с текстом нужно пользователю без отклонённых отзывов,
+// чтобы его новые отзывы публиковались без проверки
+const TrustedReviewsThreshold = 3
+
+// Размер страницы очереди модерации
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
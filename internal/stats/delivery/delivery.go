@@ -0,0 +1,163 @@
+package delivery
+
+import (
+	"net/http"
+	"strconv"
+	"vk-intern_test-case/internal/stats"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "stats:delivery:"
+
+type StatsDelivery struct {
+	statsRepo stats.StatsRepository
+}
+
+func NewStatsDelivery(sR stats.StatsRepository) *StatsDelivery {
+	return &StatsDelivery{
+		statsRepo: sR,
+	}
+}
+
+// HandleStats handles /stats/{report}
+func (sD *StatsDelivery) HandleStats(w http.ResponseWriter, r *http.Request) {
+	report := router.PathSegments(r.URL.Path, "/stats/")[0]
+	switch r.Method {
+	case http.MethodGet:
+		sD.GetStats(w, r, report)
+	case http.MethodPost:
+		if report != stats.Refresh {
+			response.WriteError(w, r, stats.ErrNotFound)
+			return
+		}
+		sD.RefreshStats(w, r)
+	}
+}
+
+// swagger:route GET /stats/films-per-year Stats getFilmsPerYear
+// Число фильмов по годам выхода
+// responses:
+//
+//	200: []filmsPerYear
+//	500: problemResponse
+
+// swagger:route GET /stats/films-per-decade Stats getFilmsPerDecade
+// Число фильмов по десятилетиям выхода
+// responses:
+//
+//	200: []filmsPerDecade
+//	500: problemResponse
+
+// swagger:route GET /stats/ratings Stats getRatingDistribution
+// Распределение оценок: сколько фильмов получили каждую редакционную оценку от 0 до 10
+// и сколько раз её ставили пользователи в опубликованных отзывах
+// responses:
+//
+//	200: []ratingBucket
+//	500: problemResponse
+
+// swagger:route GET /stats/genres Stats getGenreStats
+// Число фильмов, средняя редакционная оценка и средняя оценка пользователей по жанрам
+// responses:
+//
+//	200: []genreStats
+//	500: problemResponse
+
+// swagger:route GET /stats/actors Stats getProlificActors
+// Актёры, снявшиеся в наибольшем числе фильмов. limit - число актёров, по умолчанию 10, не больше 100
+// responses:
+//
+//	200: []prolificActor
+//	400: problemResponse
+//	500: problemResponse
+
+// swagger:route GET /stats/cast-gender Stats getCastGender
+// Роли в фильмах каждого года по полу актёров
+// responses:
+//
+//	200: []castGenderStats
+//	500: problemResponse
+
+// swagger:route GET /stats/growth Stats getCatalogGrowth
+// Рост каталога: сколько фильмов добавлено за каждый месяц и сколько их было на его конец
+// responses:
+//
+//	200: []catalogGrowth
+//	500: problemResponse
+
+// GetStats returns report by name. Statistics is read from materialized views and may be
+// up to stats.RefreshInterval old
+func (sD *StatsDelivery) GetStats(w http.ResponseWriter, r *http.Request, report string) {
+	message := logMessage + "GetStats:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+
+	var result any
+	var err error
+	switch report {
+	case stats.ReportFilmsPerYear:
+		result, err = sD.statsRepo.GetFilmsPerYear()
+	case stats.ReportFilmsPerDecade:
+		result, err = sD.statsRepo.GetFilmsPerDecade()
+	case stats.ReportRatings:
+		result, err = sD.statsRepo.GetRatingDistribution()
+	case stats.ReportGenres:
+		result, err = sD.statsRepo.GetGenreStats()
+	case stats.ReportActors:
+		var limit int
+		limit, err = parseLimit(r)
+		if err == nil {
+			result, err = sD.statsRepo.GetProlificActors(limit)
+		}
+	case stats.ReportCastGender:
+		result, err = sD.statsRepo.GetCastGenderPerYear()
+	case stats.ReportGrowth:
+		result, err = sD.statsRepo.GetCatalogGrowth()
+	default:
+		err = stats.ErrNotFound
+	}
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, result)
+}
+
+// swagger:route POST /stats/refresh Stats refreshStats
+// Пересчитывает статистику сразу, не дожидаясь обновления по расписанию
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//  401: problemResponse
+//	403: problemResponse
+//	500: problemResponse
+func (sD *StatsDelivery) RefreshStats(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "RefreshStats:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+
+	err := sD.statsRepo.RefreshViews()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+func parseLimit(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("limit")
+	if value == "" {
+		return stats.DefaultActorsLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > stats.MaxActorsLimit {
+		return 0, apperror.ErrInvalidQuery.WithMessage("Invalid limit")
+	}
+	return limit, nil
+}
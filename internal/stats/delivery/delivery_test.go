@@ -0,0 +1,171 @@
+package delivery
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"vk-intern_test-case/internal/stats/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type statsTest struct {
+	name               string
+	method             string
+	path               string
+	beforeTest         func(mockStatsRepository *mock.MockStatsRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var statsTests = []statsTest{
+	{
+		"Successfully get films per year",
+		http.MethodGet,
+		"/stats/films-per-year",
+		func(mockStatsRepository *mock.MockStatsRepository) {
+			mockStatsRepository.EXPECT().
+				GetFilmsPerYear().
+				Return([]models.FilmsPerYear{{Year: 1997, FilmCount: 2}, {Year: 2010, FilmCount: 1}}, nil)
+		},
+		`[{"year": 1997, "film_count": 2}, {"year": 2010, "film_count": 1}]`,
+		http.StatusOK,
+	},
+	{
+		"Successfully get catalog growth",
+		http.MethodGet,
+		"/stats/growth",
+		func(mockStatsRepository *mock.MockStatsRepository) {
+			mockStatsRepository.EXPECT().
+				GetCatalogGrowth().
+				Return([]models.CatalogGrowth{
+					{Month: "2024-02", FilmsAdded: 3, TotalFilms: 3},
+					{Month: "2024-03", FilmsAdded: 2, TotalFilms: 5},
+				}, nil)
+		},
+		`[
+			{"month": "2024-02", "films_added": 3, "total_films": 3},
+			{"month": "2024-03", "films_added": 2, "total_films": 5}
+		]`,
+		http.StatusOK,
+	},
+	{
+		"Successfully get prolific actors",
+		http.MethodGet,
+		"/stats/actors?limit=1",
+		func(mockStatsRepository *mock.MockStatsRepository) {
+			mockStatsRepository.EXPECT().
+				GetProlificActors(1).
+				Return([]models.ProlificActor{{ActorID: 1, Name: "Леонардо Ди Каприо", FilmCount: 3}}, nil)
+		},
+		`[{"actor_id": 1, "name": "Леонардо Ди Каприо", "film_count": 3}]`,
+		http.StatusOK,
+	},
+	{
+		"Fail to get prolific actors with invalid limit",
+		http.MethodGet,
+		"/stats/actors?limit=101",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Invalid limit",
+			"instance": "/stats/actors",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Fail to get unknown report",
+		http.MethodGet,
+		"/stats/unknown",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Statistics not found",
+			"instance": "/stats/unknown",
+			"code": "stats_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully refresh statistics",
+		http.MethodPost,
+		"/stats/refresh",
+		func(mockStatsRepository *mock.MockStatsRepository) {
+			mockStatsRepository.EXPECT().
+				RefreshViews().
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to refresh statistics",
+		http.MethodPost,
+		"/stats/refresh",
+		func(mockStatsRepository *mock.MockStatsRepository) {
+			mockStatsRepository.EXPECT().
+				RefreshViews().
+				Return(errors.New("refresh failed"))
+		},
+		`{
+			"type": "about:blank",
+			"title": "Internal Server Error",
+			"status": 500,
+			"detail": "Internal server error",
+			"instance": "/stats/refresh",
+			"code": "internal_error"
+		}`,
+		http.StatusInternalServerError,
+	},
+}
+
+func TestHandleStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range statsTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockStatsRepository := mock.NewMockStatsRepository(ctrl)
+			statsDeliveryTest := NewStatsDelivery(mockStatsRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockStatsRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(""))
+			assert.Nil(t, err)
+
+			statsDeliveryTest.HandleStats(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/stats/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStatsRepository is a mock of StatsRepository interface.
+type MockStatsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatsRepositoryMockRecorder
+}
+
+// MockStatsRepositoryMockRecorder is the mock recorder for MockStatsRepository.
+type MockStatsRepositoryMockRecorder struct {
+	mock *MockStatsRepository
+}
+
+// NewMockStatsRepository creates a new mock instance.
+func NewMockStatsRepository(ctrl *gomock.Controller) *MockStatsRepository {
+	mock := &MockStatsRepository{ctrl: ctrl}
+	mock.recorder = &MockStatsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStatsRepository) EXPECT() *MockStatsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetCastGenderPerYear mocks base method.
+func (m *MockStatsRepository) GetCastGenderPerYear() ([]models.CastGenderStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCastGenderPerYear")
+	ret0, _ := ret[0].([]models.CastGenderStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCastGenderPerYear indicates an expected call of GetCastGenderPerYear.
+func (mr *MockStatsRepositoryMockRecorder) GetCastGenderPerYear() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCastGenderPerYear", reflect.TypeOf((*MockStatsRepository)(nil).GetCastGenderPerYear))
+}
+
+// GetCatalogGrowth mocks base method.
+func (m *MockStatsRepository) GetCatalogGrowth() ([]models.CatalogGrowth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCatalogGrowth")
+	ret0, _ := ret[0].([]models.CatalogGrowth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCatalogGrowth indicates an expected call of GetCatalogGrowth.
+func (mr *MockStatsRepositoryMockRecorder) GetCatalogGrowth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCatalogGrowth", reflect.TypeOf((*MockStatsRepository)(nil).GetCatalogGrowth))
+}
+
+// GetFilmsPerDecade mocks base method.
+func (m *MockStatsRepository) GetFilmsPerDecade() ([]models.FilmsPerDecade, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmsPerDecade")
+	ret0, _ := ret[0].([]models.FilmsPerDecade)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmsPerDecade indicates an expected call of GetFilmsPerDecade.
+func (mr *MockStatsRepositoryMockRecorder) GetFilmsPerDecade() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsPerDecade", reflect.TypeOf((*MockStatsRepository)(nil).GetFilmsPerDecade))
+}
+
+// GetFilmsPerYear mocks base method.
+func (m *MockStatsRepository) GetFilmsPerYear() ([]models.FilmsPerYear, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilmsPerYear")
+	ret0, _ := ret[0].([]models.FilmsPerYear)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilmsPerYear indicates an expected call of GetFilmsPerYear.
+func (mr *MockStatsRepositoryMockRecorder) GetFilmsPerYear() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilmsPerYear", reflect.TypeOf((*MockStatsRepository)(nil).GetFilmsPerYear))
+}
+
+// GetGenreStats mocks base method.
+func (m *MockStatsRepository) GetGenreStats() ([]models.GenreStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGenreStats")
+	ret0, _ := ret[0].([]models.GenreStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGenreStats indicates an expected call of GetGenreStats.
+func (mr *MockStatsRepositoryMockRecorder) GetGenreStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGenreStats", reflect.TypeOf((*MockStatsRepository)(nil).GetGenreStats))
+}
+
+// GetProlificActors mocks base method.
+func (m *MockStatsRepository) GetProlificActors(limit int) ([]models.ProlificActor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProlificActors", limit)
+	ret0, _ := ret[0].([]models.ProlificActor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProlificActors indicates an expected call of GetProlificActors.
+func (mr *MockStatsRepositoryMockRecorder) GetProlificActors(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProlificActors", reflect.TypeOf((*MockStatsRepository)(nil).GetProlificActors), limit)
+}
+
+// GetRatingDistribution mocks base method.
+func (m *MockStatsRepository) GetRatingDistribution() ([]models.RatingBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRatingDistribution")
+	ret0, _ := ret[0].([]models.RatingBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRatingDistribution indicates an expected call of GetRatingDistribution.
+func (mr *MockStatsRepositoryMockRecorder) GetRatingDistribution() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRatingDistribution", reflect.TypeOf((*MockStatsRepository)(nil).GetRatingDistribution))
+}
+
+// RefreshViews mocks base method.
+func (m *MockStatsRepository) RefreshViews() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshViews")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshViews indicates an expected call of RefreshViews.
+func (mr *MockStatsRepositoryMockRecorder) RefreshViews() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshViews", reflect.TypeOf((*MockStatsRepository)(nil).RefreshViews))
+}
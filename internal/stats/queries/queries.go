@@ -0,0 +1,25 @@
+package queries
+
+// Отчёты читаются из материализованных представлений db/migrations/014_stats.sql
+const (
+	GetFilmsPerYear   = `select year, film_count from stats_films_per_year order by year;`
+	GetFilmsPerDecade = `select year / 10 * 10 as decade, sum(film_count)::int from stats_films_per_year
+		group by 1 order by 1;`
+	GetRatingDistribution = `select rating, film_count, review_count from stats_rating_distribution order by rating;`
+	GetGenreStats         = `select genre_id, genre, film_count, average_rating, average_community_rating
+		from stats_genre_rating order by film_count desc, genre;`
+	GetProlificActors = `select actor_id, name, film_count from stats_actor_film_count
+		order by film_count desc, name limit $1;`
+	GetCastGenderPerYear = `select year, male, female, unknown from stats_cast_gender_per_year order by year;`
+	GetCatalogGrowth     = `select month, films_added, total_films from stats_catalog_growth order by month;`
+)
+
+// Представления обновляются без блокировки чтения
+var RefreshViews = []string{
+	`refresh materialized view concurrently stats_films_per_year;`,
+	`refresh materialized view concurrently stats_rating_distribution;`,
+	`refresh materialized view concurrently stats_genre_rating;`,
+	`refresh materialized view concurrently stats_actor_film_count;`,
+	`refresh materialized view concurrently stats_cast_gender_per_year;`,
+	`refresh materialized view concurrently stats_catalog_growth;`,
+}
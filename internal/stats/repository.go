@@ -0,0 +1,14 @@
+package stats
+
+import "vk-intern_test-case/models"
+
+type StatsRepository interface {
+	GetFilmsPerYear() ([]models.FilmsPerYear, error)
+	GetFilmsPerDecade() ([]models.FilmsPerDecade, error)
+	GetRatingDistribution() ([]models.RatingBucket, error)
+	GetGenreStats() ([]models.GenreStats, error)
+	GetProlificActors(limit int) ([]models.ProlificActor, error)
+	GetCastGenderPerYear() ([]models.CastGenderStats, error)
+	GetCatalogGrowth() ([]models.CatalogGrowth, error)
+	RefreshViews() error
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	statsQueries "vk-intern_test-case/internal/stats/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "stats:repository:"
+
+type StatsRepository struct {
+	pool database.PgxIface
+}
+
+func NewStatsRepository(pool database.PgxIface) *StatsRepository {
+	return &StatsRepository{
+		pool: pool,
+	}
+}
+
+func (sR *StatsRepository) GetFilmsPerYear() ([]models.FilmsPerYear, error) {
+	result := []models.FilmsPerYear{}
+	err := sR.getStats("GetFilmsPerYear:", statsQueries.GetFilmsPerYear, func(rows pgx.Rows) error {
+		item := models.FilmsPerYear{}
+		err := rows.Scan(&item.Year, &item.FilmCount)
+		result = append(result, item)
+		return err
+	})
+	if err != nil {
+		return []models.FilmsPerYear{}, err
+	}
+	return result, nil
+}
+
+func (sR *StatsRepository) GetFilmsPerDecade() ([]models.FilmsPerDecade, error) {
+	result := []models.FilmsPerDecade{}
+	err := sR.getStats("GetFilmsPerDecade:", statsQueries.GetFilmsPerDecade, func(rows pgx.Rows) error {
+		item := models.FilmsPerDecade{}
+		err := rows.Scan(&item.Decade, &item.FilmCount)
+		result = append(result, item)
+		return err
+	})
+	if err != nil {
+		return []models.FilmsPerDecade{}, err
+	}
+	return result, nil
+}
+
+func (sR *StatsRepository) GetRatingDistribution() ([]models.RatingBucket, error) {
+	result := []models.RatingBucket{}
+	err := sR.getStats("GetRatingDistribution:", statsQueries.GetRatingDistribution, func(rows pgx.Rows) error {
+		item := models.RatingBucket{}
+		err := rows.Scan(&item.Rating, &item.FilmCount, &item.ReviewCount)
+		result = append(result, item)
+		return err
+	})
+	if err != nil {
+		return []models.RatingBucket{}, err
+	}
+	return result, nil
+}
+
+func (sR *StatsRepository) GetGenreStats() ([]models.GenreStats, error) {
+	result := []models.GenreStats{}
+	err := sR.getStats("GetGenreStats:", statsQueries.GetGenreStats, func(rows pgx.Rows) error {
+		item := models.GenreStats{}
+		err := rows.Scan(&item.GenreID, &item.Genre, &item.FilmCount, &item.AverageRating,
+			&item.AverageCommunityRating)
+		result = append(result, item)
+		return err
+	})
+	if err != nil {
+		return []models.GenreStats{}, err
+	}
+	return result, nil
+}
+
+func (sR *StatsRepository) GetProlificActors(limit int) ([]models.ProlificActor, error) {
+	result := []models.ProlificActor{}
+	err := sR.getStats("GetProlificActors:", statsQueries.GetProlificActors, func(rows pgx.Rows) error {
+		item := models.ProlificActor{}
+		err := rows.Scan(&item.ActorID, &item.Name, &item.FilmCount)
+		result = append(result, item)
+		return err
+	}, limit)
+	if err != nil {
+		return []models.ProlificActor{}, err
+	}
+	return result, nil
+}
+
+func (sR *StatsRepository) GetCastGenderPerYear() ([]models.CastGenderStats, error) {
+	result := []models.CastGenderStats{}
+	err := sR.getStats("GetCastGenderPerYear:", statsQueries.GetCastGenderPerYear, func(rows pgx.Rows) error {
+		item := models.CastGenderStats{}
+		err := rows.Scan(&item.Year, &item.Male, &item.Female, &item.Unknown)
+		result = append(result, item)
+		return err
+	})
+	if err != nil {
+		return []models.CastGenderStats{}, err
+	}
+	return result, nil
+}
+
+func (sR *StatsRepository) GetCatalogGrowth() ([]models.CatalogGrowth, error) {
+	result := []models.CatalogGrowth{}
+	err := sR.getStats("GetCatalogGrowth:", statsQueries.GetCatalogGrowth, func(rows pgx.Rows) error {
+		item := models.CatalogGrowth{}
+		err := rows.Scan(&item.Month, &item.FilmsAdded, &item.TotalFilms)
+		result = append(result, item)
+		return err
+	})
+	if err != nil {
+		return []models.CatalogGrowth{}, err
+	}
+	return result, nil
+}
+
+// RefreshViews пересчитывает все представления статистики в одной транзакции
+func (sR *StatsRepository) RefreshViews() error {
+	message := logMessage + "RefreshViews:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := sR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	for _, query := range statsQueries.RefreshViews {
+		_, err = tx.Exec(transactionCtx, query)
+		if err != nil {
+			log.Error(message + err.Error())
+			return err
+		}
+	}
+	return nil
+}
+
+// getStats выполняет запрос отчёта и передаёт каждую строку в scan
+func (sR *StatsRepository) getStats(method, query string, scan func(pgx.Rows) error, args ...any) error {
+	message := logMessage + method
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := sR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	rows, err := tx.Query(transactionCtx, query, args...)
+	if err != nil {
+		log.Error(message + err.Error())
+		return err
+	}
+	for rows.Next() {
+		err = scan(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+	}
+	rows.Close()
+	err = rows.Err()
+	return err
+}
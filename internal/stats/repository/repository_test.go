@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*StatsRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testStatsRepo := NewStatsRepository(mock)
+	return testStatsRepo, mock
+}
+
+func TestShouldSuccessfullyGetFilmsPerDecade(t *testing.T) {
+	statsRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from stats_films_per_year").
+		WillReturnRows(pgxmock.NewRows([]string{"decade", "film_count"}).
+			AddRow(1990, 4).
+			AddRow(2000, 7)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	decades, err := statsRepo.GetFilmsPerDecade()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(decades))
+	assert.Equal(t, 2000, decades[1].Decade)
+	assert.Equal(t, 7, decades[1].FilmCount)
+}
+
+func TestShouldSuccessfullyGetGenreStats(t *testing.T) {
+	statsRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	averageRating := 7.5
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from stats_genre_rating").
+		WillReturnRows(pgxmock.NewRows([]string{"genre_id", "genre", "film_count", "average_rating",
+			"average_community_rating"}).
+			AddRow(1, "Драма", 2, &averageRating, nil).
+			AddRow(4, "Боевик", 0, nil, nil)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	genres, err := statsRepo.GetGenreStats()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(genres))
+	assert.Equal(t, 7.5, *genres[0].AverageRating)
+	assert.Nil(t, genres[0].AverageCommunityRating)
+	assert.Nil(t, genres[1].AverageRating)
+}
+
+func TestShouldSuccessfullyGetProlificActors(t *testing.T) {
+	statsRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("from stats_actor_film_count").WithArgs(1).
+		WillReturnRows(pgxmock.NewRows([]string{"actor_id", "name", "film_count"}).
+			AddRow(1, "Леонардо Ди Каприо", 3)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	actors, err := statsRepo.GetProlificActors(1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Леонардо Ди Каприо", actors[0].Name)
+	assert.Equal(t, 3, actors[0].FilmCount)
+}
+
+func TestShouldSuccessfullyRefreshViews(t *testing.T) {
+	statsRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+
+	mock.ExpectBegin()
+	for _, view := range []string{"stats_films_per_year", "stats_rating_distribution", "stats_genre_rating",
+		"stats_actor_film_count", "stats_cast_gender_per_year", "stats_catalog_growth"} {
+		mock.ExpectExec("refresh materialized view concurrently " + view).
+			WillReturnResult(pgxmock.NewResult("REFRESH", 0))
+	}
+	mock.ExpectCommit()
+
+	err := statsRepo.RefreshViews()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+}
+
+func TestShouldRollbackFailedRefresh(t *testing.T) {
+	statsRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	refreshErr := errors.New("refresh failed")
+
+	mock.ExpectBegin()
+	mock.ExpectExec("refresh materialized view concurrently stats_films_per_year").WillReturnError(refreshErr)
+	mock.ExpectRollback()
+
+	err := statsRepo.RefreshViews()
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, refreshErr, err)
+}
@@ -0,0 +1,49 @@
+package stats
+
+import (
+	"context"
+	"time"
+	"vk-intern_test-case/utils/apperror"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var ErrNotFound = apperror.NotFound("stats_not_found", "Statistics not found")
+
+// Отчёты статистики - последний сегмент пути /stats/{report}
+const (
+	ReportFilmsPerYear   = "films-per-year"
+	ReportFilmsPerDecade = "films-per-decade"
+	ReportRatings        = "ratings"
+	ReportGenres         = "genres"
+	ReportActors         = "actors"
+	ReportCastGender     = "cast-gender"
+	ReportGrowth         = "growth"
+	// POST /stats/refresh обновляет представления статистики
+	Refresh = "refresh"
+)
+
+// Число актёров в отчёте о самых снимаемых актёрах
+const (
+	DefaultActorsLimit = 10
+	MaxActorsLimit     = 100
+)
+
+// Как часто обновляются материализованные представления статистики
+const RefreshInterval = 10 * time.Minute
+
+// RefreshPeriodically обновляет представления статистики каждые interval, пока не отменён ctx
+func RefreshPeriodically(ctx context.Context, repo StatsRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := repo.RefreshViews(); err != nil {
+				log.Error("stats:RefreshPeriodically:" + err.Error())
+			}
+		}
+	}
+}
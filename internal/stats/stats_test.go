@@ -0,0 +1,36 @@
+package stats_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/stats"
+	"vk-intern_test-case/internal/stats/mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestRefreshPeriodically(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStatsRepository := mock.NewMockStatsRepository(ctrl)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Тикер может сработать ещё раз, пока отмена не дошла до цикла
+	mockStatsRepository.EXPECT().RefreshViews().DoAndReturn(func() error {
+		cancel()
+		return nil
+	}).MinTimes(1)
+
+	done := make(chan struct{})
+	go func() {
+		stats.RefreshPeriodically(ctx, mockStatsRepository, time.Millisecond)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("statistics was not refreshed")
+	}
+}
@@ -0,0 +1,220 @@
+package delivery
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/internal/userlist"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/response"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/validation"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "userlist:delivery:"
+
+type UserListDelivery struct {
+	userListRepo userlist.UserListRepository
+}
+
+func NewUserListDelivery(ulR userlist.UserListRepository) *UserListDelivery {
+	return &UserListDelivery{
+		userListRepo: ulR,
+	}
+}
+
+// HandleUserLists handles /me/{list} and /me/{list}/{film_id}
+func (ulD *UserListDelivery) HandleUserLists(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		ulD.GetListFilms(w, r)
+	case http.MethodPut:
+		ulD.AddToList(w, r)
+	case http.MethodDelete:
+		ulD.RemoveFromList(w, r)
+	}
+}
+
+// swagger:route GET /me/{list} UserLists getListFilms
+// Возвращает фильмы из личного списка пользователя из заголовка Authorization:
+// watchlist - посмотреть позже, favorites - избранное, watched - просмотренные с датой просмотра.
+// sort_by - added_at (по умолчанию, сначала недавно добавленные), watched_at, title, release_date или rating,
+// limit (по умолчанию 20, не больше 100) и offset - страница. Общее число фильмов в списке - в заголовке X-Total-Count
+// security:
+// - key:
+// responses:
+//
+//	200: []userListFilm
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (ulD *UserListDelivery) GetListFilms(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "GetListFilms:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	list, _, err := parseListPath(r, false)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	filter, err := parseListFilter(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFilms, total, err := ulD.userListRepo.GetListFilms(userID, list, filter)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilms)
+}
+
+// swagger:route PUT /me/{list}/{film_id} UserLists addToList
+// Добавляет фильм в личный список пользователя из заголовка Authorization, повторное добавление не дублирует фильм.
+// Для списка watched можно указать дату просмотра (по умолчанию - сегодня), просмотренный фильм
+// убирается из watchlist
+// security:
+// - key:
+// responses:
+//
+//	200: userListFilm
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	422: problemResponse
+//	500: problemResponse
+func (ulD *UserListDelivery) AddToList(w http.ResponseWriter, r *http.Request) {
+	message := logMessage + "AddToList:"
+	log.Debug(message + "started")
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	list, filmID, err := parseListPath(r, true)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	// Тело запроса необязательно
+	var request models.UserListRequest
+	err = json.NewDecoder(r.Body).Decode(&request)
+	if err != nil && err != io.EOF {
+		response.WriteError(w, r, apperror.ErrInvalidJSON)
+		return
+	}
+
+	err = validation.ValidateUserListRequest(list == userlist.ListWatched, &request).Err()
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	resultFilm, err := ulD.userListRepo.AddToList(userID, list, filmID, &request)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteResponse(w, jsonEnc, http.StatusOK, resultFilm)
+}
+
+// swagger:route DELETE /me/{list}/{film_id} UserLists removeFromList
+// Убирает фильм из личного списка пользователя из заголовка Authorization
+// security:
+// - key:
+// responses:
+//
+//	200: basicResponse
+//	400: problemResponse
+//  401: problemResponse
+//	404: problemResponse
+//	500: problemResponse
+func (ulD *UserListDelivery) RemoveFromList(w http.ResponseWriter, r *http.Request) {
+	jsonEnc := response.MakeJsonEncoder(w)
+	userID, ok := middleware.UserID(r.Context())
+	if !ok {
+		response.WriteError(w, r, middleware.ErrUnauthorized)
+		return
+	}
+	list, filmID, err := parseListPath(r, true)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	err = ulD.userListRepo.RemoveFromList(userID, list, filmID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteBasicResponse(w, jsonEnc, http.StatusOK, "OK")
+}
+
+// parseListPath returns list name and, if withFilm is set, film id from path like /me/{list}/{film_id}
+func parseListPath(r *http.Request, withFilm bool) (string, int, error) {
+	segments := router.PathSegments(r.URL.Path, "/me/")
+	list := segments[0]
+	if !userlist.IsValidList(list) {
+		return "", 0, userlist.ErrListNotFound
+	}
+	if !withFilm {
+		return list, 0, nil
+	}
+	if len(segments) < 2 {
+		return "", 0, apperror.ErrInvalidID
+	}
+	filmID, err := strconv.Atoi(segments[1])
+	if err != nil {
+		return "", 0, apperror.ErrInvalidID
+	}
+	return list, filmID, nil
+}
+
+func parseListFilter(r *http.Request) (*models.UserListFilter, error) {
+	query := r.URL.Query()
+	filter := &models.UserListFilter{
+		SortBy: query.Get("sort_by"),
+		Limit:  userlist.DefaultPageSize,
+	}
+	if filter.SortBy != "" && !userlist.IsValidSortField(filter.SortBy) {
+		return nil, apperror.ErrInvalidQuery.WithMessage("Unknown sort_by")
+	}
+
+	intParams := []struct {
+		name     string
+		target   *int
+		min, max int
+	}{
+		{"limit", &filter.Limit, 1, userlist.MaxPageSize},
+		{"offset", &filter.Offset, 0, math.MaxInt32},
+	}
+	for _, param := range intParams {
+		value := query.Get(param.name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil || number < param.min || number > param.max {
+			return nil, apperror.ErrInvalidQuery.WithMessage("Invalid " + param.name)
+		}
+		*param.target = number
+	}
+	return filter, nil
+}
@@ -0,0 +1,245 @@
+package delivery
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/middleware"
+	"vk-intern_test-case/internal/userlist"
+	"vk-intern_test-case/internal/userlist/mock"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/response"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment() *httptest.ResponseRecorder {
+	responseRecorder := httptest.NewRecorder()
+	return responseRecorder
+}
+
+func expectedContentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+	return "application/json"
+}
+
+type userListTest struct {
+	name string
+	// ID пользователя, которого определил middleware, 0 - без авторизации
+	userID             int
+	method             string
+	path               string
+	inputBodyJSON      string
+	beforeTest         func(mockUserListRepository *mock.MockUserListRepository)
+	expectedJSON       string
+	expectedStatusCode int
+}
+
+var (
+	addedAt   = time.Date(2024, 3, 18, 12, 0, 0, 0, time.UTC)
+	falseFlag = false
+	trueFlag  = true
+)
+
+var watchedTitanic = models.UserListFilm{
+	Film: models.Film{
+		ID: 2,
+		FilmRequest: models.FilmRequest{
+			Title:       "Титаник",
+			Description: "Cool film",
+			ReleaseDate: "1997-12-19",
+			Rating:      8,
+		},
+		InWatchlist: &falseFlag,
+		Watched:     &trueFlag,
+	},
+	AddedAt:   addedAt,
+	WatchedAt: "2024-03-17",
+}
+
+const watchedTitanicJSON = `{
+	"id": 2,
+	"title": "Титаник",
+	"description": "Cool film",
+	"release_date": "1997-12-19",
+	"rating": 8,
+	"in_watchlist": false,
+	"watched": true,
+	"added_at": "2024-03-18T12:00:00Z",
+	"watched_at": "2024-03-17"
+}`
+
+var userListTests = []userListTest{
+	{
+		"Successfully get watched films sorted by title",
+		1,
+		http.MethodGet,
+		"/me/watched?sort_by=title&limit=10",
+		"",
+		func(mockUserListRepository *mock.MockUserListRepository) {
+			mockUserListRepository.EXPECT().
+				GetListFilms(1, userlist.ListWatched, &models.UserListFilter{SortBy: "title", Limit: 10}).
+				Return([]models.UserListFilm{watchedTitanic}, 1, nil)
+		},
+		"[" + watchedTitanicJSON + "]",
+		http.StatusOK,
+	},
+	{
+		"Fail to get unknown list",
+		1,
+		http.MethodGet,
+		"/me/wishlist",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "List not found",
+			"instance": "/me/wishlist",
+			"code": "list_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Fail to get list with unknown sorting",
+		1,
+		http.MethodGet,
+		"/me/favorites?sort_by=budget",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Bad Request",
+			"status": 400,
+			"detail": "Unknown sort_by",
+			"instance": "/me/favorites",
+			"code": "invalid_query"
+		}`,
+		http.StatusBadRequest,
+	},
+	{
+		"Successfully mark a Film as watched",
+		1,
+		http.MethodPut,
+		"/me/watched/2",
+		`{"watched_at": "2024-03-17"}`,
+		func(mockUserListRepository *mock.MockUserListRepository) {
+			mockUserListRepository.EXPECT().
+				AddToList(1, userlist.ListWatched, 2, &models.UserListRequest{WatchedAt: "2024-03-17"}).
+				Return(&watchedTitanic, nil)
+		},
+		watchedTitanicJSON,
+		http.StatusOK,
+	},
+	{
+		"Fail to add a Film to watchlist with watched date",
+		1,
+		http.MethodPut,
+		"/me/watchlist/2",
+		`{"watched_at": "2024-03-17"}`,
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unprocessable Entity",
+			"status": 422,
+			"detail": "Request validation failed",
+			"instance": "/me/watchlist/2",
+			"code": "validation_failed",
+			"errors": [
+				{"field": "watched_at", "code": "not_allowed", "message": "watched_at is allowed only in the watched list"}
+			]
+		}`,
+		http.StatusUnprocessableEntity,
+	},
+	{
+		"Fail to add a non-existent Film to favorites",
+		1,
+		http.MethodPut,
+		"/me/favorites/2",
+		"",
+		func(mockUserListRepository *mock.MockUserListRepository) {
+			mockUserListRepository.EXPECT().
+				AddToList(1, userlist.ListFavorites, 2, &models.UserListRequest{}).
+				Return(nil, film.ErrNotFound)
+		},
+		`{
+			"type": "about:blank",
+			"title": "Not Found",
+			"status": 404,
+			"detail": "Film not found",
+			"instance": "/me/favorites/2",
+			"code": "film_not_found"
+		}`,
+		http.StatusNotFound,
+	},
+	{
+		"Successfully remove a Film from watchlist",
+		1,
+		http.MethodDelete,
+		"/me/watchlist/2",
+		"",
+		func(mockUserListRepository *mock.MockUserListRepository) {
+			mockUserListRepository.EXPECT().
+				RemoveFromList(1, userlist.ListWatchlist, 2).
+				Return(nil)
+		},
+		`{"status": "OK"}`,
+		http.StatusOK,
+	},
+	{
+		"Fail to remove a Film without authorization",
+		0,
+		http.MethodDelete,
+		"/me/watchlist/2",
+		"",
+		nil,
+		`{
+			"type": "about:blank",
+			"title": "Unauthorized",
+			"status": 401,
+			"detail": "Authorization header with a valid user id is required",
+			"instance": "/me/watchlist/2",
+			"code": "unauthorized"
+		}`,
+		http.StatusUnauthorized,
+	},
+}
+
+func TestHandleUserLists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	for _, test := range userListTests {
+		t.Run(test.name, func(t *testing.T) {
+			mockUserListRepository := mock.NewMockUserListRepository(ctrl)
+			userListDeliveryTest := NewUserListDelivery(mockUserListRepository)
+			if test.beforeTest != nil {
+				test.beforeTest(mockUserListRepository)
+			}
+			responseRecorder := prepareTestEnvironment()
+
+			request, err := http.NewRequest(test.method, test.path, strings.NewReader(test.inputBodyJSON))
+			assert.Nil(t, err)
+			if test.userID != 0 {
+				request = request.WithContext(middleware.WithUserID(request.Context(), test.userID))
+			}
+
+			userListDeliveryTest.HandleUserLists(responseRecorder, request)
+			result := responseRecorder.Result()
+			defer result.Body.Close()
+			data, err := io.ReadAll(result.Body)
+
+			assert.Nil(t, err)
+			assert.Equal(t, test.expectedStatusCode, responseRecorder.Code)
+			assert.Equal(t, expectedContentType(test.expectedStatusCode), result.Header[http.CanonicalHeaderKey("content-type")][0])
+			assert.JSONEq(t, test.expectedJSON, string(data))
+		})
+	}
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/userlist/repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+	models "vk-intern_test-case/models"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserListRepository is a mock of UserListRepository interface.
+type MockUserListRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserListRepositoryMockRecorder
+}
+
+// MockUserListRepositoryMockRecorder is the mock recorder for MockUserListRepository.
+type MockUserListRepositoryMockRecorder struct {
+	mock *MockUserListRepository
+}
+
+// NewMockUserListRepository creates a new mock instance.
+func NewMockUserListRepository(ctrl *gomock.Controller) *MockUserListRepository {
+	mock := &MockUserListRepository{ctrl: ctrl}
+	mock.recorder = &MockUserListRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserListRepository) EXPECT() *MockUserListRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddToList mocks base method.
+func (m *MockUserListRepository) AddToList(userID int, list string, filmID int, request *models.UserListRequest) (*models.UserListFilm, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToList", userID, list, filmID, request)
+	ret0, _ := ret[0].(*models.UserListFilm)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddToList indicates an expected call of AddToList.
+func (mr *MockUserListRepositoryMockRecorder) AddToList(userID, list, filmID, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToList", reflect.TypeOf((*MockUserListRepository)(nil).AddToList), userID, list, filmID, request)
+}
+
+// GetListFilms mocks base method.
+func (m *MockUserListRepository) GetListFilms(userID int, list string, filter *models.UserListFilter) ([]models.UserListFilm, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListFilms", userID, list, filter)
+	ret0, _ := ret[0].([]models.UserListFilm)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetListFilms indicates an expected call of GetListFilms.
+func (mr *MockUserListRepositoryMockRecorder) GetListFilms(userID, list, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListFilms", reflect.TypeOf((*MockUserListRepository)(nil).GetListFilms), userID, list, filter)
+}
+
+// RemoveFromList mocks base method.
+func (m *MockUserListRepository) RemoveFromList(userID int, list string, filmID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromList", userID, list, filmID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromList indicates an expected call of RemoveFromList.
+func (mr *MockUserListRepositoryMockRecorder) RemoveFromList(userID, list, filmID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromList", reflect.TypeOf((*MockUserListRepository)(nil).RemoveFromList), userID, list, filmID)
+}
@@ -0,0 +1,32 @@
+package queries
+
+import filmQueries "vk-intern_test-case/internal/film/queries"
+
+// Колонки фильма списка: колонки фильма, когда он добавлен, дата просмотра и отметки из других списков
+const listFilmColumns = filmQueries.FilmColumns + `, l.added_at, l.watched_at,
+	exists (select 1 from user_film_list as w where w.user_id = l.user_id and w.film_id = l.film_id and w.list = 'watchlist'),
+	exists (select 1 from user_film_list as w where w.user_id = l.user_id and w.film_id = l.film_id and w.list = 'watched')`
+
+const (
+	GetFilmIDByID = `select id from film where id = $1;`
+	listFilmsFrom = ` from user_film_list as l join film as f on f.id = l.film_id
+		where l.user_id = $1 and l.list = $2`
+	GetListFilms   = `select ` + listFilmColumns + listFilmsFrom
+	CountListFilms = `select count(*)` + listFilmsFrom + `;`
+	GetListFilm    = `select ` + listFilmColumns + listFilmsFrom + ` and l.film_id = $3;`
+	// Повторное добавление сохраняет дату добавления и меняет только дату просмотра
+	AddToList = `insert into user_film_list (user_id, list, film_id, watched_at) values ($1, $2, $3, $4)
+		on conflict (user_id, list, film_id) do update set watched_at = excluded.watched_at;`
+	RemoveFromList = `delete from user_film_list where user_id = $1 and list = $2 and film_id = $3;`
+	// Просмотренный фильм больше не нужно смотреть позже
+	RemoveFromWatchlist = `delete from user_film_list where user_id = $1 and list = 'watchlist' and film_id = $2;`
+)
+
+// Сортировки фильмов списка по полю из sort_by. По умолчанию - сначала недавно добавленные
+var ListOrderBy = map[string]string{
+	"added_at":     ` order by l.added_at desc, f.id`,
+	"watched_at":   ` order by l.watched_at desc nulls last, l.added_at desc, f.id`,
+	"title":        ` order by f.title, f.id`,
+	"release_date": ` order by f.release_date desc, f.id`,
+	"rating":       ` order by f.rating desc, f.id`,
+}
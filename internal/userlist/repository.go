@@ -0,0 +1,9 @@
+package userlist
+
+import "vk-intern_test-case/models"
+
+type UserListRepository interface {
+	GetListFilms(userID int, list string, filter *models.UserListFilter) ([]models.UserListFilm, int, error)
+	AddToList(userID int, list string, filmID int, request *models.UserListRequest) (*models.UserListFilm, error)
+	RemoveFromList(userID int, list string, filmID int) error
+}
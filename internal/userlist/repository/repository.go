@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"time"
+	filmPackage "vk-intern_test-case/internal/film"
+	filmRepository "vk-intern_test-case/internal/film/repository"
+	userListPackage "vk-intern_test-case/internal/userlist"
+	userListQueries "vk-intern_test-case/internal/userlist/queries"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/database"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	log "github.com/sirupsen/logrus"
+)
+
+const logMessage = "userlist:repository:"
+
+type UserListRepository struct {
+	pool database.PgxIface
+}
+
+func NewUserListRepository(pool database.PgxIface) *UserListRepository {
+	return &UserListRepository{
+		pool: pool,
+	}
+}
+
+// GetListFilms возвращает страницу фильмов из списка пользователя и общее число фильмов в нём
+func (ulR *UserListRepository) GetListFilms(userID int, list string, filter *models.UserListFilter) ([]models.UserListFilm, int, error) {
+	message := logMessage + "GetListFilms:"
+	log.Debug(message + "started")
+	orderBy, ok := userListQueries.ListOrderBy[filter.SortBy]
+	if !ok {
+		orderBy = userListQueries.ListOrderBy[userListPackage.SortByAddedAt]
+	}
+
+	transactionCtx := context.Background()
+	tx, err := ulR.pool.Begin(transactionCtx)
+	if err != nil {
+		return []models.UserListFilm{}, 0, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var total int
+	row := tx.QueryRow(transactionCtx, userListQueries.CountListFilms, &userID, &list)
+	err = row.Scan(&total)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.UserListFilm{}, 0, err
+	}
+
+	query := userListQueries.GetListFilms + orderBy + " limit $3 offset $4"
+	rows, err := tx.Query(transactionCtx, query, &userID, &list, filter.Limit, filter.Offset)
+	if err != nil {
+		log.Error(message + err.Error())
+		return []models.UserListFilm{}, 0, err
+	}
+
+	films := []models.UserListFilm{}
+	for rows.Next() {
+		film, err := scanListFilm(rows)
+		if err != nil {
+			return []models.UserListFilm{}, 0, err
+		}
+		films = append(films, *film)
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return []models.UserListFilm{}, 0, err
+	}
+	return films, total, nil
+}
+
+// AddToList добавляет фильм в список пользователя. Просмотренный фильм убирается из списка watchlist
+func (ulR *UserListRepository) AddToList(userID int, list string, filmID int, request *models.UserListRequest) (*models.UserListFilm, error) {
+	message := logMessage + "AddToList:"
+	log.Debug(message + "started")
+	transactionCtx := context.Background()
+	tx, err := ulR.pool.Begin(transactionCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	var id int
+	row := tx.QueryRow(transactionCtx, userListQueries.GetFilmIDByID, &filmID)
+	err = row.Scan(&id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			err = filmPackage.ErrNotFound
+			return nil, err
+		}
+		return nil, err
+	}
+
+	var watchedAt *string
+	if list == userListPackage.ListWatched {
+		date := request.WatchedAt
+		if date == "" {
+			date = time.Now().Format(time.DateOnly)
+		}
+		watchedAt = &date
+	}
+	_, err = tx.Exec(transactionCtx, userListQueries.AddToList, &userID, &list, &filmID, watchedAt)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, database.MapError(err)
+	}
+
+	if list == userListPackage.ListWatched {
+		_, err = tx.Exec(transactionCtx, userListQueries.RemoveFromWatchlist, &userID, &filmID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	row = tx.QueryRow(transactionCtx, userListQueries.GetListFilm, &userID, &list, &filmID)
+	film, err := scanListFilm(row)
+	if err != nil {
+		log.Error(message + err.Error())
+		return nil, err
+	}
+	return film, nil
+}
+
+func (ulR *UserListRepository) RemoveFromList(userID int, list string, filmID int) error {
+	transactionCtx := context.Background()
+	tx, err := ulR.pool.Begin(transactionCtx)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		switch err {
+		case nil:
+			err = tx.Commit(transactionCtx)
+		default:
+			_ = tx.Rollback(transactionCtx)
+		}
+	}()
+
+	commandTag, err := tx.Exec(transactionCtx, userListQueries.RemoveFromList, &userID, &list, &filmID)
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		err = userListPackage.ErrFilmNotInList
+		return err
+	}
+	return nil
+}
+
+func scanListFilm(row pgx.Row) (*models.UserListFilm, error) {
+	var addedAt time.Time
+	var watchedAtPG pgtype.Date
+	var inWatchlist, watched bool
+	film, err := filmRepository.ScanFilm(row, &addedAt, &watchedAtPG, &inWatchlist, &watched)
+	if err != nil {
+		return nil, err
+	}
+	film.InWatchlist = &inWatchlist
+	film.Watched = &watched
+
+	listFilm := &models.UserListFilm{
+		Film:    *film,
+		AddedAt: addedAt,
+	}
+	if watchedAtPG.Valid {
+		listFilm.WatchedAt = watchedAtPG.Time.Format(time.DateOnly)
+	}
+	return listFilm, nil
+}
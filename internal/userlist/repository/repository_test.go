@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"testing"
+	"time"
+	"vk-intern_test-case/internal/film"
+	"vk-intern_test-case/internal/userlist"
+	"vk-intern_test-case/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func prepareTestEnvironment(t *testing.T) (*UserListRepository, pgxmock.PgxPoolIface) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testUserListRepo := NewUserListRepository(mock)
+	return testUserListRepo, mock
+}
+
+var listFilmColumns = []string{"id", "title", "description", "release_date", "rating", "genres", "runtime", "countries",
+	"original_language", "spoken_languages", "age_rating", "budget", "box_office", "currency", "poster", "community_rating",
+	"vote_count", "added_at", "watched_at", "in_watchlist", "watched"}
+
+func listFilmRow(id int, title string, addedAt time.Time, watchedAt any, inWatchlist, watched bool) []any {
+	return []any{id, title, "", "1997-12-19", 8, []string{}, 0, []string{}, "", []string{}, "",
+		int64(0), int64(0), "", nil, nil, 0, addedAt, watchedAt, inWatchlist, watched}
+}
+
+func TestShouldSuccessfullyGetListFilms(t *testing.T) {
+	userListRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID, list := 1, userlist.ListWatched
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select count\\(\\*\\) from user_film_list").WithArgs(&userID, &list).
+		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery("where l.user_id = \\$1 and l.list = \\$2 order by l.watched_at desc nulls last(.+) limit \\$3 offset \\$4").
+		WithArgs(&userID, &list, 1, 1).
+		WillReturnRows(pgxmock.NewRows(listFilmColumns).
+			AddRow(listFilmRow(2, "Титаник", now, "2024-03-17", false, true)...)).
+		RowsWillBeClosed()
+	mock.ExpectCommit()
+
+	resultFilms, total, err := userListRepo.GetListFilms(userID, list,
+		&models.UserListFilter{SortBy: userlist.SortByWatchedAt, Limit: 1, Offset: 1})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, 1, len(resultFilms))
+	assert.Equal(t, "2024-03-17", resultFilms[0].WatchedAt)
+	assert.Equal(t, now, resultFilms[0].AddedAt)
+	assert.False(t, *resultFilms[0].InWatchlist)
+	assert.True(t, *resultFilms[0].Watched)
+}
+
+func TestShouldRemoveWatchedFilmFromWatchlist(t *testing.T) {
+	userListRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID, filmID, list := 1, 2, userlist.ListWatched
+	watchedAt := time.Now().Format(time.DateOnly)
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectExec("insert into user_film_list (.+) on conflict").WithArgs(&userID, &list, &filmID, &watchedAt).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec("delete from user_film_list where user_id = \\$1 and list = 'watchlist'").WithArgs(&userID, &filmID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mock.ExpectQuery("and l.film_id = \\$3").WithArgs(&userID, &list, &filmID).
+		WillReturnRows(pgxmock.NewRows(listFilmColumns).
+			AddRow(listFilmRow(filmID, "Титаник", now, watchedAt, false, true)...))
+	mock.ExpectCommit()
+
+	resultFilm, err := userListRepo.AddToList(userID, list, filmID, &models.UserListRequest{})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, watchedAt, resultFilm.WatchedAt)
+}
+
+func TestShouldSuccessfullyAddFilmToFavorites(t *testing.T) {
+	userListRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID, filmID, list := 1, 2, userlist.ListFavorites
+	now := time.Now()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(filmID))
+	mock.ExpectExec("insert into user_film_list").WithArgs(&userID, &list, &filmID, (*string)(nil)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectQuery("and l.film_id = \\$3").WithArgs(&userID, &list, &filmID).
+		WillReturnRows(pgxmock.NewRows(listFilmColumns).
+			AddRow(listFilmRow(filmID, "Титаник", now, nil, true, false)...))
+	mock.ExpectCommit()
+
+	resultFilm, err := userListRepo.AddToList(userID, list, filmID, &models.UserListRequest{})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, err)
+	assert.Equal(t, "", resultFilm.WatchedAt)
+	assert.True(t, *resultFilm.InWatchlist)
+}
+
+func TestShouldFailToAddNonExistentFilmToList(t *testing.T) {
+	userListRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	filmID := 2
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("select id from film").WithArgs(&filmID).WillReturnError(pgx.ErrNoRows)
+	mock.ExpectRollback()
+
+	resultFilm, err := userListRepo.AddToList(1, userlist.ListWatchlist, filmID, &models.UserListRequest{})
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Nil(t, resultFilm)
+	assert.Equal(t, film.ErrNotFound, err)
+}
+
+func TestShouldReturnNotFoundWhenRemovingFilmNotInList(t *testing.T) {
+	userListRepo, mock := prepareTestEnvironment(t)
+	defer mock.Close()
+	userID, filmID, list := 1, 2, userlist.ListWatchlist
+
+	mock.ExpectBegin()
+	mock.ExpectExec("delete from user_film_list").WithArgs(&userID, &list, &filmID).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectRollback()
+
+	err := userListRepo.RemoveFromList(userID, list, filmID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("there were unfulfilled expectations: %s", err)
+	}
+
+	assert.Equal(t, userlist.ErrFilmNotInList, err)
+}
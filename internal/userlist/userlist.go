@@ -0,0 +1,46 @@
+package userlist
+
+import "vk-intern_test-case/utils/apperror"
+
+var (
+	ErrListNotFound  = apperror.NotFound("list_not_found", "List not found")
+	ErrFilmNotInList = apperror.NotFound("film_not_in_list", "Film is not in the list")
+)
+
+// Личные списки пользователя
+const (
+	ListWatchlist = "watchlist"
+	ListFavorites = "favorites"
+	ListWatched   = "watched"
+)
+
+func IsValidList(list string) bool {
+	switch list {
+	case ListWatchlist, ListFavorites, ListWatched:
+		return true
+	}
+	return false
+}
+
+// Поля сортировки фильмов в списке
+const (
+	SortByAddedAt     = "added_at"
+	SortByWatchedAt   = "watched_at"
+	SortByTitle       = "title"
+	SortByReleaseDate = "release_date"
+	SortByRating      = "rating"
+)
+
+func IsValidSortField(field string) bool {
+	switch field {
+	case SortByAddedAt, SortByWatchedAt, SortByTitle, SortByReleaseDate, SortByRating:
+		return true
+	}
+	return false
+}
+
+// Размер страницы списка
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
@@ -20,20 +20,60 @@
 package main
 
 import (
+	"context"
 	"net/http"
 	actorDelivery "vk-intern_test-case/internal/actor/delivery"
 	actorRepository "vk-intern_test-case/internal/actor/repository"
 	"vk-intern_test-case/internal/middleware"
 	"vk-intern_test-case/utils/database"
+	"vk-intern_test-case/utils/router"
+	"vk-intern_test-case/utils/storage"
 
 	log "github.com/sirupsen/logrus"
 
+	awardDelivery "vk-intern_test-case/internal/award/delivery"
+	awardRepository "vk-intern_test-case/internal/award/repository"
+
+	collectionDelivery "vk-intern_test-case/internal/collection/delivery"
+	collectionRepository "vk-intern_test-case/internal/collection/repository"
+
+	costarDelivery "vk-intern_test-case/internal/costar/delivery"
+	costarRepository "vk-intern_test-case/internal/costar/repository"
+
+	feedDelivery "vk-intern_test-case/internal/feed/delivery"
+	feedRepository "vk-intern_test-case/internal/feed/repository"
+
 	filmDelivery "vk-intern_test-case/internal/film/delivery"
 	filmRepository "vk-intern_test-case/internal/film/repository"
 
+	franchiseDelivery "vk-intern_test-case/internal/franchise/delivery"
+	franchiseRepository "vk-intern_test-case/internal/franchise/repository"
+
+	genreDelivery "vk-intern_test-case/internal/genre/delivery"
+	genreRepository "vk-intern_test-case/internal/genre/repository"
+
+	personDelivery "vk-intern_test-case/internal/person/delivery"
+	personRepository "vk-intern_test-case/internal/person/repository"
+
+	recommendationDelivery "vk-intern_test-case/internal/recommendation/delivery"
+	recommendationRepository "vk-intern_test-case/internal/recommendation/repository"
+
+	reviewDelivery "vk-intern_test-case/internal/review/delivery"
+	reviewRepository "vk-intern_test-case/internal/review/repository"
+
+	"vk-intern_test-case/internal/stats"
+	statsDelivery "vk-intern_test-case/internal/stats/delivery"
+	statsRepository "vk-intern_test-case/internal/stats/repository"
+
+	userListDelivery "vk-intern_test-case/internal/userlist/delivery"
+	userListRepository "vk-intern_test-case/internal/userlist/repository"
+
 	openApiMiddleware "github.com/go-openapi/runtime/middleware"
 )
 
+// Каталог с загруженными изображениями, раздаётся по /media/
+const mediaDir = "./media"
+
 func main() {
 	logLevel := log.DebugLevel
 	log.SetLevel(logLevel)
@@ -45,24 +85,128 @@ func main() {
 	}
 	defer dbPool.Close()
 
+	mediaStorage := storage.NewLocalStorage(mediaDir, "/media")
+
 	fR := filmRepository.NewFilmRepository(dbPool)
-	fD := filmDelivery.NewFilmDelivery(fR)
+	fD := filmDelivery.NewFilmDelivery(fR, mediaStorage)
 
 	aR := actorRepository.NewActorRepository(dbPool)
-	aD := actorDelivery.NewActorDelivery(aR)
+	aD := actorDelivery.NewActorDelivery(aR, mediaStorage)
+
+	gR := genreRepository.NewGenreRepository(dbPool)
+	gD := genreDelivery.NewGenreDelivery(gR)
+
+	pR := personRepository.NewPersonRepository(dbPool)
+	pD := personDelivery.NewPersonDelivery(pR)
+
+	frR := franchiseRepository.NewFranchiseRepository(dbPool)
+	frD := franchiseDelivery.NewFranchiseDelivery(frR)
+
+	awR := awardRepository.NewAwardRepository(dbPool)
+	awD := awardDelivery.NewAwardDelivery(awR)
+
+	rvR := reviewRepository.NewReviewRepository(dbPool)
+	rvD := reviewDelivery.NewReviewDelivery(rvR)
+
+	recR := recommendationRepository.NewRecommendationRepository(dbPool)
+	recD := recommendationDelivery.NewRecommendationDelivery(recR)
+
+	ulR := userListRepository.NewUserListRepository(dbPool)
+	ulD := userListDelivery.NewUserListDelivery(ulR)
+
+	cR := collectionRepository.NewCollectionRepository(dbPool)
+	cD := collectionDelivery.NewCollectionDelivery(cR)
+
+	csR := costarRepository.NewCostarRepository(dbPool)
+	csD := costarDelivery.NewCostarDelivery(csR)
+
+	stR := statsRepository.NewStatsRepository(dbPool)
+	stD := statsDelivery.NewStatsDelivery(stR)
+	go stats.RefreshPeriodically(context.Background(), stR, stats.RefreshInterval)
+
+	fdR := feedRepository.NewFeedRepository(dbPool)
+	fdD := feedDelivery.NewFeedDelivery(fdR)
 
 	authMw := middleware.NewAuthMiddleware(dbPool)
 
 	r := http.NewServeMux()
-	actorsHandler := http.HandlerFunc(aD.HandleActors)
+	actorsHandler := router.WithSubresources(http.HandlerFunc(aD.HandleActors), "/actors/", map[string]http.Handler{
+		"photo":       http.HandlerFunc(aD.HandleActorPhoto),
+		"nominations": http.HandlerFunc(awD.HandleActorNominations),
+		"costars":     http.HandlerFunc(csD.HandleCostars),
+	})
 	r.Handle("/actors", authMw.MiddlewareCheckAdmin(actorsHandler))
 	r.Handle("/actors/", authMw.MiddlewareCheckAdmin(actorsHandler))
+	r.Handle("/actors/path", authMw.MiddlewareCheckAdmin(http.HandlerFunc(csD.HandleActorPath)))
+
+	adminFilmsHandler := router.WithSubresources(http.HandlerFunc(fD.HandleFilms), "/films/", map[string]http.Handler{
+		"crew":         http.HandlerFunc(pD.HandleFilmCrew),
+		"cast":         http.HandlerFunc(pD.HandleFilmCast),
+		"translations": http.HandlerFunc(fD.HandleFilmTranslations),
+		"poster":       http.HandlerFunc(fD.HandleFilmPoster),
+		"relations":    http.HandlerFunc(frD.HandleFilmRelations),
+		"related":      http.HandlerFunc(frD.HandleRelatedFilms),
+		"nominations":  http.HandlerFunc(awD.HandleFilmNominations),
+		"similar":      http.HandlerFunc(recD.HandleSimilarFilms),
+	})
+	// Отзывы пишут все пользователи, остальное в фильмах меняют только администраторы
+	filmsHandler := router.WithSubresources(authMw.MiddlewareCheckAdmin(adminFilmsHandler), "/films/", map[string]http.Handler{
+		"reviews": authMw.MiddlewareCheckUser(http.HandlerFunc(rvD.HandleFilmReviews)),
+	})
+	// Авторизованным пользователям фильмы приходят с отметками из их списков
+	r.Handle("/films", authMw.MiddlewareIdentifyUser(filmsHandler))
+	r.Handle("/films/", authMw.MiddlewareIdentifyUser(filmsHandler))
+	r.Handle("/films/upcoming", authMw.MiddlewareIdentifyUser(http.HandlerFunc(fD.HandleUpcomingFilms)))
+	r.Handle("/films/calendar", authMw.MiddlewareIdentifyUser(http.HandlerFunc(fD.HandleReleaseCalendar)))
+	r.HandleFunc("/films/upcoming.ics", fD.HandleReleaseFeed)
+
+	r.Handle("/film", authMw.MiddlewareIdentifyUser(http.HandlerFunc(fD.HandleFilm)))
+	r.Handle("/media/", http.StripPrefix("/media/", http.FileServer(http.Dir(mediaDir))))
+	r.HandleFunc("/persons/", pD.HandlePersons)
+
+	genresHandler := http.HandlerFunc(gD.HandleGenres)
+	r.Handle("/genres", authMw.MiddlewareCheckAdmin(genresHandler))
+	r.Handle("/genres/", authMw.MiddlewareCheckAdmin(genresHandler))
+
+	franchisesHandler := router.WithSubresources(http.HandlerFunc(frD.HandleFranchises), "/franchises/", map[string]http.Handler{
+		"films": http.HandlerFunc(frD.HandleFranchiseFilms),
+	})
+	r.Handle("/franchises", authMw.MiddlewareCheckAdmin(franchisesHandler))
+	r.Handle("/franchises/", authMw.MiddlewareCheckAdmin(franchisesHandler))
+
+	awardsHandler := router.WithSubresources(http.HandlerFunc(awD.HandleAwards), "/awards/", map[string]http.Handler{
+		"ceremonies": http.HandlerFunc(awD.HandleAwardCeremonies),
+		"categories": http.HandlerFunc(awD.HandleAwardCategories),
+	})
+	r.Handle("/awards", authMw.MiddlewareCheckAdmin(awardsHandler))
+	r.Handle("/awards/", authMw.MiddlewareCheckAdmin(awardsHandler))
+
+	nominationsHandler := http.HandlerFunc(awD.HandleNominations)
+	r.Handle("/nominations", authMw.MiddlewareCheckAdmin(nominationsHandler))
+	r.Handle("/nominations/", authMw.MiddlewareCheckAdmin(nominationsHandler))
+
+	reviewsHandler := router.WithSubresources(http.NotFoundHandler(), "/reviews/", map[string]http.Handler{
+		"flags":      authMw.MiddlewareCheckUser(http.HandlerFunc(rvD.HandleReviewFlags)),
+		"moderation": authMw.MiddlewareCheckModerator(http.HandlerFunc(rvD.HandleReviewModeration)),
+	})
+	r.Handle("/reviews/", reviewsHandler)
+	r.Handle("/moderation/reviews", authMw.MiddlewareCheckModerator(http.HandlerFunc(rvD.HandleModerationQueue)))
+
+	// Коллекции читают все, приватные - только владельцы, меняют коллекции их владельцы
+	collectionsHandler := router.WithSubresources(http.HandlerFunc(cD.HandleCollections), "/collections/", map[string]http.Handler{
+		"films": http.HandlerFunc(cD.HandleCollectionFilms),
+		"order": http.HandlerFunc(cD.HandleCollectionOrder),
+		"forks": http.HandlerFunc(cD.HandleCollectionForks),
+	})
+	r.Handle("/collections", authMw.MiddlewareIdentifyUser(authMw.MiddlewareCheckUser(collectionsHandler)))
+	r.Handle("/collections/", authMw.MiddlewareIdentifyUser(authMw.MiddlewareCheckUser(collectionsHandler)))
+
+	r.Handle("/stats/", authMw.MiddlewareCheckAdmin(http.HandlerFunc(stD.HandleStats)))
 
-	filmsHandler := http.HandlerFunc(fD.HandleFilms)
-	r.Handle("/films", authMw.MiddlewareCheckAdmin(filmsHandler))
-	r.Handle("/films/", authMw.MiddlewareCheckAdmin(filmsHandler))
+	r.HandleFunc("/feeds/", fdD.HandleFeeds)
 
-	r.HandleFunc("/film", fD.HandleFilm)
+	r.Handle("/me/recommendations", authMw.MiddlewareRequireUser(http.HandlerFunc(recD.HandleRecommendations)))
+	r.Handle("/me/", authMw.MiddlewareRequireUser(http.HandlerFunc(ulD.HandleUserLists)))
 
 	opts := openApiMiddleware.SwaggerUIOpts{SpecURL: "/swagger.yaml"}
 	sh := openApiMiddleware.SwaggerUI(opts, nil)
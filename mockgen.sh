@@ -5,4 +5,48 @@ mockgen -source=internal/film/repository.go \
 
 mockgen -source=internal/actor/repository.go \
   -destination=internal/actor/mock/repository_mock.go \
-  -package=mock
\ No newline at end of file
+  -package=mock
+
+mockgen -source=internal/genre/repository.go \
+  -destination=internal/genre/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/person/repository.go \
+  -destination=internal/person/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/franchise/repository.go \
+  -destination=internal/franchise/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/award/repository.go \
+  -destination=internal/award/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/review/repository.go \
+  -destination=internal/review/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/userlist/repository.go \
+  -destination=internal/userlist/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/collection/repository.go \
+  -destination=internal/collection/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/recommendation/repository.go \
+  -destination=internal/recommendation/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/costar/repository.go \
+  -destination=internal/costar/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/stats/repository.go \
+  -destination=internal/stats/mock/repository_mock.go \
+  -package=mock
+
+mockgen -source=internal/feed/repository.go \
+  -destination=internal/feed/mock/repository_mock.go \
+  -package=mock
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type BasicResponse struct {
 	Status string `json:"status"`
 }
@@ -13,6 +15,12 @@ type Actor struct {
 	// min: 1
 	ID int `json:"id"`
 	ActorRequest
+	// Фотография актёра, если загружена
+	Photo *Image `json:"photo,omitempty"`
+	// Текущий возраст или возраст на момент смерти, если известна дата рождения
+	//
+	// example: 49
+	Age *int `json:"age,omitempty"`
 }
 
 type ActorRequest struct {
@@ -32,6 +40,29 @@ type ActorRequest struct {
 	// В формате YYYY-MM_DD
 	// example: 2001-08-06
 	DateOfBirth string `json:"date_of_birth"`
+	// Date of death of the actor
+	//
+	// В формате YYYY-MM_DD
+	// example: 2023-01-15
+	DateOfDeath string `json:"date_of_death,omitempty"`
+	// Место рождения
+	//
+	// example: Лос-Анджелес, США
+	Birthplace string `json:"birthplace,omitempty"`
+	// Биография
+	Biography string `json:"biography,omitempty"`
+	// Другие имена и варианты написания, по ним тоже ищется актёр
+	//
+	// example: ["Leonardo DiCaprio"]
+	Aliases []string `json:"aliases,omitempty"`
+	// Идентификатор на IMDb
+	//
+	// example: nm0000138
+	ImdbID string `json:"imdb_id,omitempty"`
+	// Идентификатор на Кинопоиске
+	//
+	// example: 37859
+	KinopoiskID int `json:"kinopoisk_id,omitempty"`
 }
 
 type FilmRequest struct {
@@ -58,6 +89,45 @@ type FilmRequest struct {
 	// maximum: 10
 	// example: 7
 	Rating int `json:"rating"`
+	// Genres of the film. Если не передано при обновлении - жанры не меняются
+	//
+	// example: ["Драма", "Мелодрама"]
+	Genres []string `json:"genres,omitempty"`
+	// Runtime of the film in minutes
+	//
+	// minimum: 0
+	// example: 194
+	Runtime int `json:"runtime,omitempty"`
+	// Production countries, ISO 3166-1 alpha-2
+	//
+	// example: ["US"]
+	Countries []string `json:"countries,omitempty"`
+	// Original language, ISO 639-1
+	//
+	// example: en
+	OriginalLanguage string `json:"original_language,omitempty"`
+	// Spoken languages, ISO 639-1
+	//
+	// example: ["en", "fr"]
+	SpokenLanguages []string `json:"spoken_languages,omitempty"`
+	// Age rating. Возможные значения - 0+, 6+, 12+, 16+, 18+
+	//
+	// example: 12+
+	AgeRating string `json:"age_rating,omitempty"`
+	// Budget of the film in currency
+	//
+	// minimum: 0
+	// example: 200000000
+	Budget int64 `json:"budget,omitempty"`
+	// Box office of the film in currency
+	//
+	// minimum: 0
+	// example: 2264750694
+	BoxOffice int64 `json:"box_office,omitempty"`
+	// Currency of budget and box office, ISO 4217
+	//
+	// example: USD
+	Currency string `json:"currency,omitempty"`
 }
 
 // Film represents film in system
@@ -68,6 +138,135 @@ type Film struct {
 	// min: 1
 	ID int `json:"id"`
 	FilmRequest
+	// Оригинальное название. Заполняется, если title и description переведены на язык клиента
+	//
+	// example: Titanic
+	OriginalTitle string `json:"original_title,omitempty"`
+	// Язык перевода title и description, ISO 639-1
+	//
+	// example: ru
+	Language string `json:"language,omitempty"`
+	// Постер фильма, если загружен
+	Poster *Image `json:"poster,omitempty"`
+	// Средняя оценка пользователей. Нет, если фильм ещё никто не оценил
+	//
+	// example: 8.35
+	CommunityRating *float64 `json:"community_rating,omitempty"`
+	// Число оценок пользователей
+	//
+	// example: 124
+	VoteCount int `json:"vote_count,omitempty"`
+	// Фильм в списке "посмотреть позже" пользователя из заголовка Authorization.
+	// Только в ответах авторизованным пользователям
+	InWatchlist *bool `json:"in_watchlist,omitempty"`
+	// Пользователь из заголовка Authorization отметил фильм просмотренным.
+	// Только в ответах авторизованным пользователям
+	Watched *bool `json:"watched,omitempty"`
+}
+
+// Uploaded image and its thumbnails
+// swagger:model image
+type Image struct {
+	// URL of the image without metadata
+	//
+	// example: /media/films/1/poster/lx3k2/original.jpg
+	Original string `json:"original"`
+	// URL of the thumbnail 160px wide
+	Small string `json:"small"`
+	// URL of the thumbnail 320px wide
+	Medium string `json:"medium"`
+	// URL of the thumbnail 640px wide
+	Large string `json:"large"`
+}
+
+// Localized title and description of the film
+// swagger:model filmTranslationRequest
+type FilmTranslationRequest struct {
+	// Localized title
+	//
+	// required: true
+	// example: Титаник
+	Title string `json:"title"`
+	// Localized description
+	//
+	// example: Фильм о крушении лайнера
+	Description string `json:"description"`
+}
+
+// Translation of the film to one language
+// swagger:model filmTranslation
+type FilmTranslation struct {
+	// Language of translation, ISO 639-1
+	//
+	// example: ru
+	Language string `json:"language"`
+	FilmTranslationRequest
+}
+
+// Genre represents genre in system
+// swagger:model genre
+type Genre struct {
+	// The id for this genre
+	//
+	// min: 1
+	ID int `json:"id"`
+	GenreRequest
+}
+
+type GenreRequest struct {
+	// Name of the genre
+	//
+	// required: true
+	// example: Драма
+	Name string `json:"name"`
+}
+
+// Filter for film listing and search
+type FilmFilter struct {
+	// Жанры, по которым фильтруются фильмы
+	Genres []string
+	// any - фильм относится хотя бы к одному из жанров, all - ко всем
+	GenreMode string
+	// Фрагмент имени режиссёра
+	Director string
+	// Допустимые возрастные рейтинги
+	AgeRatings []string
+	// Страны производства, фильм подходит, если снят хотя бы в одной из них
+	Countries []string
+	// Язык оригинала или один из языков фильма
+	Language string
+	// Границы продолжительности в минутах, 0 - без ограничения
+	MinRuntime int
+	MaxRuntime int
+	// Языки ответа в порядке предпочтения, если перевода нет - используется оригинал
+	Languages []string
+	// Только фильмы, победившие хотя бы в одной номинации
+	WonAward bool
+	// Границы возраста актёра на момент выхода фильма, 0 - без ограничения.
+	// При поиске по актёру относятся к этому актёру, иначе - к любому актёру фильма
+	MinActorAge int
+	MaxActorAge int
+	// Границы даты выхода включительно в формате YYYY-MM-DD, пустая - без ограничения
+	ReleasedFrom string
+	ReleasedTo   string
+	// Только фильмы с этим актёром, 0 - с любыми
+	ActorID int
+}
+
+// Параметры поиска актёров
+type ActorFilter struct {
+	// Фрагмент имени или одного из других имён
+	Name   string
+	Gender string
+	// Границы года рождения, 0 - без ограничения
+	BornFrom int
+	BornTo   int
+	// Только актёры, снимавшиеся в фильме, 0 - без ограничения
+	FilmID int
+	// Поле сортировки: name, date_of_birth или film_count
+	SortBy string
+	Limit  int
+	Offset int
 }
 
 type FilmWithActors struct {
@@ -79,5 +278,695 @@ type FilmWithActors struct {
 // swagger:model actorWithFilms
 type ActorWithFilms struct {
 	Actor
+	Films []ActingCredit `json:"films"`
+}
+
+// Film with age of an actor on its release date
+type ActingCredit struct {
+	Film
+	// Возраст актёра на дату выхода фильма. Нет, если неизвестна дата рождения или выхода
+	//
+	// example: 23
+	AgeAtRelease *int `json:"age_at_release,omitempty"`
+}
+
+// Actor in a film cast
+// swagger:model castMember
+type CastMember struct {
+	// ID персоны
+	//
+	// example: 1
+	PersonID int `json:"person_id"`
+	// Имя актёра
+	//
+	// example: Леонардо Ди Каприо
+	Name string `json:"name"`
+	// Дата рождения актёра
+	//
+	// example: 1974-11-11
+	DateOfBirth string `json:"date_of_birth,omitempty"`
+	// Возраст актёра на дату выхода фильма
+	//
+	// example: 23
+	AgeAtRelease *int `json:"age_at_release,omitempty"`
+}
+
+// Participant of a film crew
+// swagger:model crewMember
+type CrewMember struct {
+	// ID персоны
+	//
+	// example: 3
+	PersonID int `json:"person_id"`
+	// Имя персоны
+	//
+	// example: Джеймс Кэмерон
+	Name string `json:"name"`
+	// Роль в съёмочной группе. Возможные значения - director, writer, composer, producer
+	//
+	// example: director
+	Role string `json:"role"`
+}
+
+type CrewRequest struct {
+	// ID персоны. Персона добавляется заранее через /actors
+	//
+	// required: true
+	// example: 3
+	PersonID int `json:"person_id"`
+	// Роль в съёмочной группе. Возможные значения - director, writer, composer, producer
+	//
+	// required: true
+	// example: director
+	Role string `json:"role"`
+}
+
+// Film with role of a person in its crew
+type CrewCredit struct {
+	Film
+	// Роль в съёмочной группе
+	//
+	// example: director
+	Role string `json:"role"`
+}
+
+// Person with acting and crew credits
+// swagger:model personWithCredits
+type PersonWithCredits struct {
+	Actor
+	// Фильмы, в которых персона снималась как актёр
+	Acting []ActingCredit `json:"acting"`
+	// Фильмы, в которых персона работала в съёмочной группе
+	Crew []CrewCredit `json:"crew"`
+}
+
+// Film added into system with actors resolution report
+// swagger:model addedFilm
+type AddedFilm struct {
+	Film
+	// Актёры, которых нет в базе и которые были пропущены (режим skip)
+	Warnings []string `json:"warnings,omitempty"`
+	// Актёры, которых не было в базе и которые были созданы как заглушки (режим create)
+	CreatedActors []string `json:"created_actors,omitempty"`
+}
+
+// Validation error of a single field
+// swagger:model fieldError
+type FieldError struct {
+	// Поле запроса с ошибкой
+	//
+	// example: rating
+	Field string `json:"field"`
+	// Машиночитаемый код ошибки
+	//
+	// example: out_of_range
+	Code string `json:"code"`
+	// Описание ошибки
+	//
+	// example: rating must be between 0 and 10
+	Message string `json:"message"`
+}
+
+// Error description in RFC 7807 format
+// swagger:model problem
+type Problem struct {
+	// URI reference of the problem type
+	//
+	// example: about:blank
+	Type string `json:"type"`
+	// Short summary of the problem type
+	//
+	// example: Not Found
+	Title string `json:"title"`
+	// HTTP status code
+	//
+	// example: 404
+	Status int `json:"status"`
+	// Explanation specific to this occurrence of the problem
+	//
+	// example: Film not found
+	Detail string `json:"detail,omitempty"`
+	// Request path
+	//
+	// example: /films/1
+	Instance string `json:"instance,omitempty"`
+	// Stable machine-readable error code
+	//
+	// example: film_not_found
+	Code string `json:"code"`
+	// Field errors for validation problems
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// Franchise or collection of films
+// swagger:model franchiseRequest
+type FranchiseRequest struct {
+	// Name of the franchise
+	//
+	// required: true
+	// example: Звёздные войны
+	Name string `json:"name"`
+	// Description of the franchise
+	//
+	// example: Космическая сага
+	Description string `json:"description"`
+}
+
+// Franchise represents franchise in system
+// swagger:model franchise
+type Franchise struct {
+	// The id for this franchise
+	//
+	// min: 1
+	ID int `json:"id"`
+	FranchiseRequest
+}
+
+// Franchise with its films in chronological order
+// swagger:model franchiseWithFilms
+type FranchiseWithFilms struct {
+	Franchise
 	Films []Film `json:"films"`
 }
+
+// Film to add into franchise
+// swagger:model franchiseFilmRequest
+type FranchiseFilmRequest struct {
+	// ID фильма
+	//
+	// required: true
+	// example: 2
+	FilmID int `json:"film_id"`
+}
+
+// Relation between two films
+// swagger:model filmRelationRequest
+type FilmRelationRequest struct {
+	// ID связанного фильма
+	//
+	// required: true
+	// example: 2
+	FilmID int `json:"film_id"`
+	// Кем связанный фильм приходится фильму из пути. Возможные значения - sequel, prequel, remake, spin_off
+	//
+	// required: true
+	// example: sequel
+	Type string `json:"type"`
+}
+
+// Film related to another one through relations or a common franchise
+// swagger:model relatedFilm
+type RelatedFilm struct {
+	Film
+	// Кем фильм приходится запрошенному, только для прямых связей.
+	// Возможные значения - sequel, prequel, remake, original, spin_off, parent
+	//
+	// example: sequel
+	Relation string `json:"relation,omitempty"`
+}
+
+// Film award
+// swagger:model awardRequest
+type AwardRequest struct {
+	// Name of the award
+	//
+	// required: true
+	// example: Оскар
+	Name string `json:"name"`
+}
+
+// Award represents film award in system
+// swagger:model award
+type Award struct {
+	// The id for this award
+	//
+	// min: 1
+	ID int `json:"id"`
+	AwardRequest
+}
+
+// Ceremony of the award
+// swagger:model awardCeremonyRequest
+type AwardCeremonyRequest struct {
+	// Год церемонии
+	//
+	// required: true
+	// example: 1998
+	Year int `json:"year"`
+}
+
+// Ceremony of the award held in a year
+// swagger:model awardCeremony
+type AwardCeremony struct {
+	ID int `json:"id"`
+	AwardCeremonyRequest
+}
+
+// Category of the award
+// swagger:model awardCategoryRequest
+type AwardCategoryRequest struct {
+	// Название категории
+	//
+	// required: true
+	// example: Лучший фильм
+	Name string `json:"name"`
+}
+
+// Category of the award
+// swagger:model awardCategory
+type AwardCategory struct {
+	ID int `json:"id"`
+	AwardCategoryRequest
+}
+
+// Award with its ceremonies and categories
+// swagger:model awardWithDetails
+type AwardWithDetails struct {
+	Award
+	Ceremonies []AwardCeremony `json:"ceremonies"`
+	Categories []AwardCategory `json:"categories"`
+}
+
+// Nomination of the film and optionally of a person
+// swagger:model nominationRequest
+type NominationRequest struct {
+	// ID церемонии
+	//
+	// required: true
+	// example: 1
+	CeremonyID int `json:"ceremony_id"`
+	// ID категории той же премии, что и церемония
+	//
+	// required: true
+	// example: 1
+	CategoryID int `json:"category_id"`
+	// ID фильма
+	//
+	// required: true
+	// example: 2
+	FilmID int `json:"film_id"`
+	// ID актёра или участника съёмочной группы, если номинирован человек
+	//
+	// example: 3
+	PersonID int `json:"person_id,omitempty"`
+	// Победа в номинации
+	Won bool `json:"won"`
+}
+
+// Nomination with award, ceremony, category, film and person names
+// swagger:model nomination
+type Nomination struct {
+	ID         int    `json:"id"`
+	AwardID    int    `json:"award_id"`
+	Award      string `json:"award"`
+	CeremonyID int    `json:"ceremony_id"`
+	Year       int    `json:"year"`
+	CategoryID int    `json:"category_id"`
+	Category   string `json:"category"`
+	FilmID     int    `json:"film_id"`
+	FilmTitle  string `json:"film_title"`
+	PersonID   int    `json:"person_id,omitempty"`
+	PersonName string `json:"person_name,omitempty"`
+	Won        bool   `json:"won"`
+}
+
+// User rating of a film with optional review text
+// swagger:model reviewRequest
+type ReviewRequest struct {
+	// Оценка фильма от 1 до 10
+	//
+	// required: true
+	// example: 9
+	Rating int `json:"rating"`
+	// Текст отзыва, необязателен
+	//
+	// example: Лучший фильм Кэмерона
+	Text string `json:"text,omitempty"`
+}
+
+// Rating and review of a film by a user
+// swagger:model review
+type Review struct {
+	ID     int `json:"id"`
+	FilmID int `json:"film_id"`
+	UserID int `json:"user_id"`
+	ReviewRequest
+	// Статус модерации: pending, published, rejected или hidden
+	//
+	// example: published
+	Status string `json:"status"`
+	// Причина отклонения или скрытия отзыва
+	ModerationReason string    `json:"moderation_reason,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Complaint about an abusive review
+// swagger:model reviewFlagRequest
+type ReviewFlagRequest struct {
+	// Причина жалобы
+	//
+	// example: Оскорбления
+	Reason string `json:"reason,omitempty"`
+}
+
+// Moderator decision on a review
+// swagger:model reviewModerationRequest
+type ReviewModerationRequest struct {
+	// Действие: approve - опубликовать, reject - отклонить, hide - скрыть
+	//
+	// required: true
+	// example: reject
+	Action string `json:"action"`
+	// Причина, обязательна при отклонении
+	//
+	// example: Спойлеры
+	Reason string `json:"reason,omitempty"`
+}
+
+// Review waiting for a moderator
+// swagger:model moderationQueueItem
+type ModerationQueueItem struct {
+	Review
+	// Название фильма
+	FilmTitle string `json:"film_title"`
+	// Число жалоб на отзыв
+	FlagCount int `json:"flag_count"`
+	// Причины жалоб, сначала старые
+	FlagReasons []string `json:"flag_reasons"`
+}
+
+// Filter of the moderation queue
+type ModerationFilter struct {
+	// pending, flagged или пустая строка - обе очереди
+	Queue  string
+	FilmID int
+	UserID int
+	Limit  int
+	Offset int
+}
+
+// Film to add to a personal list
+// swagger:model userListRequest
+type UserListRequest struct {
+	// Дата просмотра, только для списка watched. По умолчанию - сегодня
+	//
+	// example: 2024-03-18
+	WatchedAt string `json:"watched_at,omitempty"`
+}
+
+// Film in a personal list of the user
+// swagger:model userListFilm
+type UserListFilm struct {
+	Film
+	// Когда фильм добавлен в список
+	AddedAt time.Time `json:"added_at"`
+	// Дата просмотра, только в списке watched
+	//
+	// example: 2024-03-18
+	WatchedAt string `json:"watched_at,omitempty"`
+}
+
+// Film flags of the user lists
+type FilmListFlags struct {
+	InWatchlist bool
+	Watched     bool
+}
+
+// Sorting and page of a personal list
+type UserListFilter struct {
+	SortBy string
+	Limit  int
+	Offset int
+}
+
+// Collection fields set by its owner
+// swagger:model collectionRequest
+type CollectionRequest struct {
+	// Адрес коллекции из латинских букв, цифр и дефисов. Задаётся только при создании,
+	// по умолчанию получается из названия
+	//
+	// example: luchshie-sovetskie-komedii
+	Slug string `json:"slug,omitempty"`
+	// Название коллекции
+	//
+	// required: true
+	// example: Лучшие советские комедии
+	Name string `json:"name"`
+	// Описание коллекции
+	//
+	// example: Гайдай, Рязанов, Данелия
+	Description string `json:"description"`
+	// Коллекцию видят все пользователи. По умолчанию - только владелец
+	IsPublic bool `json:"is_public"`
+}
+
+// User-curated collection of films
+// swagger:model collection
+type Collection struct {
+	ID     int `json:"id"`
+	UserID int `json:"user_id"`
+	CollectionRequest
+	// ID коллекции, скопированной в эту
+	ForkedFrom *int `json:"forked_from,omitempty"`
+	// Число фильмов в коллекции
+	FilmCount int `json:"film_count"`
+	// Сколько раз коллекцию скопировали
+	ForkCount int       `json:"fork_count"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Note of the film in a collection
+// swagger:model collectionFilmRequest
+type CollectionFilmRequest struct {
+	// Заметка владельца о фильме
+	//
+	// example: Смотреть на Новый год
+	Note string `json:"note,omitempty"`
+}
+
+// Film in a collection
+// swagger:model collectionFilm
+type CollectionFilm struct {
+	Film
+	// Позиция фильма в коллекции, начиная с 1
+	Position int `json:"position"`
+	// Заметка владельца о фильме
+	Note string `json:"note,omitempty"`
+}
+
+// Collection with its films in order
+// swagger:model collectionWithFilms
+type CollectionWithFilms struct {
+	Collection
+	Films []CollectionFilm `json:"films"`
+}
+
+// New order of films in a collection
+// swagger:model collectionOrderRequest
+type CollectionOrderRequest struct {
+	// ID всех фильмов коллекции в новом порядке
+	//
+	// required: true
+	// example: [3, 1, 2]
+	FilmIDs []int `json:"film_ids"`
+}
+
+// Filter of the collections list
+type CollectionFilter struct {
+	// Коллекции только этого пользователя, 0 - всех
+	UserID int
+	Limit  int
+	Offset int
+}
+
+// Film similar to another one with explanation of the similarity
+// swagger:model similarFilm
+type SimilarFilm struct {
+	Film
+	// Оценка похожести, чем больше, тем ближе фильм
+	//
+	// example: 9.6
+	Score float64 `json:"score"`
+	// Почему фильм рекомендован: shared_cast, shared_genres, close_release, similar_rating
+	//
+	// example: ["shared_cast", "close_release"]
+	Reasons []string `json:"reasons"`
+	// Актёры, снимавшиеся в обоих фильмах
+	SharedActors []string `json:"shared_actors"`
+	// Общие жанры
+	SharedGenres []string `json:"shared_genres"`
+	// Разница в годах выхода
+	YearDifference int `json:"year_difference"`
+	// Разница редакционных оценок
+	RatingDifference int `json:"rating_difference"`
+}
+
+// Film recommended to the user
+// swagger:model recommendation
+type Recommendation struct {
+	Film
+	// Оценка рекомендации, у популярных фильмов - 0
+	//
+	// example: 1.37
+	Score float64 `json:"score"`
+	// Откуда рекомендация: collaborative - по оценкам похожих пользователей, popular - популярный фильм
+	//
+	// example: collaborative
+	Source string `json:"source"`
+	// Названия фильмов пользователя, из-за которых фильм рекомендован
+	//
+	// example: ["Титаник"]
+	BecauseOf []string `json:"because_of"`
+}
+
+// Actor who played in the same films as another one
+// swagger:model costar
+type Costar struct {
+	Actor
+	// Число общих фильмов
+	//
+	// example: 2
+	FilmCount int `json:"film_count"`
+	// Названия общих фильмов
+	//
+	// example: ["Титаник", "Дорога перемен"]
+	Films []string `json:"films"`
+}
+
+// Shortest chain of actors connected through films
+// swagger:model actorPath
+type ActorPath struct {
+	// Степень разделения - число фильмов в цепочке
+	//
+	// example: 2
+	Degrees int `json:"degrees"`
+	// Актёры цепочки от from до to
+	Actors []Actor `json:"actors"`
+	// Фильмы цепочки, films[i] связывает actors[i] и actors[i+1]
+	Films []Film `json:"films"`
+}
+
+// Pagination of co-stars
+type CostarFilter struct {
+	Limit  int
+	Offset int
+}
+
+// Number of films released in a year
+// swagger:model filmsPerYear
+type FilmsPerYear struct {
+	// example: 1997
+	Year int `json:"year"`
+	// example: 12
+	FilmCount int `json:"film_count"`
+}
+
+// Number of films released in a decade
+// swagger:model filmsPerDecade
+type FilmsPerDecade struct {
+	// Первый год десятилетия
+	//
+	// example: 1990
+	Decade int `json:"decade"`
+	// example: 87
+	FilmCount int `json:"film_count"`
+}
+
+// Number of films and user reviews with a rating
+// swagger:model ratingBucket
+type RatingBucket struct {
+	// example: 8
+	Rating int `json:"rating"`
+	// Фильмов с такой редакционной оценкой
+	//
+	// example: 14
+	FilmCount int `json:"film_count"`
+	// Опубликованных отзывов с такой оценкой
+	//
+	// example: 230
+	ReviewCount int `json:"review_count"`
+}
+
+// Ratings of films of a genre
+// swagger:model genreStats
+type GenreStats struct {
+	// example: 1
+	GenreID int `json:"genre_id"`
+	// example: Драма
+	Genre string `json:"genre"`
+	// example: 42
+	FilmCount int `json:"film_count"`
+	// Средняя редакционная оценка, нет - если фильмов жанра нет
+	//
+	// example: 7.45
+	AverageRating *float64 `json:"average_rating"`
+	// Средняя оценка пользователей, нет - если оценок нет
+	//
+	// example: 7.9
+	AverageCommunityRating *float64 `json:"average_community_rating"`
+}
+
+// Actor with number of films
+// swagger:model prolificActor
+type ProlificActor struct {
+	// example: 1
+	ActorID int `json:"actor_id"`
+	// example: Леонардо Ди Каприо
+	Name string `json:"name"`
+	// example: 31
+	FilmCount int `json:"film_count"`
+}
+
+// Roles in films of a year by gender of actors
+// swagger:model castGenderStats
+type CastGenderStats struct {
+	// example: 1997
+	Year int `json:"year"`
+	// example: 40
+	Male int `json:"male"`
+	// example: 25
+	Female int `json:"female"`
+	// Пол не указан
+	//
+	// example: 1
+	Unknown int `json:"unknown"`
+}
+
+// Films added into catalog in a month
+// swagger:model catalogGrowth
+type CatalogGrowth struct {
+	// example: 2024-03
+	Month string `json:"month"`
+	// example: 15
+	FilmsAdded int `json:"films_added"`
+	// Фильмов в каталоге на конец месяца
+	//
+	// example: 120
+	TotalFilms int `json:"total_films"`
+}
+
+// Films released on a date
+// swagger:model releaseDay
+type ReleaseDay struct {
+	// example: 2024-05-01
+	Date  string `json:"date"`
+	Films []Film `json:"films"`
+}
+
+// Film in a syndication feed
+type FeedFilm struct {
+	Film
+	// Когда фильм добавлен в каталог или в фильмографию актёра
+	AddedAt time.Time
+	// Когда фильм или его место в ленте последний раз менялись
+	UpdatedAt time.Time
+}
+
+// Feed of films featuring an actor
+type ActorFeed struct {
+	Actor
+	UpdatedAt time.Time
+	Films     []FeedFilm
+}
@@ -1,6 +1,6 @@
 package models
 
-// Ответ системы. В случае успеха - ОК. Иначе описание ошибки
+// Ответ системы в случае успеха - ОК
 // swagger:response basicResponse
 type basicResponseWrapper struct {
 	// Response Message
@@ -8,7 +8,7 @@ type basicResponseWrapper struct {
 	Body BasicResponse
 }
 
-// swagger:parameters updateActor deleteActor
+// swagger:parameters updateActor deleteActor patchActor uploadActorPhoto getActorNominations
 type actorIDParameterWrapper struct {
 	// ID актёра
 	// in: path
@@ -25,6 +25,34 @@ type actorResponseWrapper struct {
 }
 
 // An actor from database
+// swagger:parameters getActors
+type actorSearchParameterWrapper struct {
+	// Фрагмент имени или другого имени актёра
+	// in: query
+	Name string `json:"name"`
+	// Пол актёра
+	// in: query
+	Gender string `json:"gender"`
+	// Минимальный год рождения
+	// in: query
+	BornFrom int `json:"born_from"`
+	// Максимальный год рождения
+	// in: query
+	BornTo int `json:"born_to"`
+	// ID фильма, в котором снимался актёр
+	// in: query
+	FilmID int `json:"film_id"`
+	// Параметр для сортировки. Возможные поля - name, date_of_birth, film_count
+	// in: query
+	SortBy string `json:"sort_by"`
+	// Размер страницы, по умолчанию 20, не больше 100
+	// in: query
+	Limit int `json:"limit"`
+	// Сколько актёров пропустить
+	// in: query
+	Offset int `json:"offset"`
+}
+
 // swagger:parameters updateActor
 type actorParameterWrapper struct {
 	// Данные об актёре
@@ -47,6 +75,28 @@ type filmWithActorsWrapper struct {
 	Body FilmWithActorsRequest
 }
 
+// swagger:parameters addFilm
+type filmActorModeParameterWrapper struct {
+	// Режим обработки актёров, которых нет в базе. Возможные значения - skip, strict, create
+	// in: query
+	ActorMode string `json:"actor_mode"`
+}
+
+// Добавленный фильм. В warnings - пропущенные актёры, в created_actors - созданные заглушки
+// swagger:response addedFilm
+type addedFilmResponseWrapper struct {
+	// in: body
+	Body AddedFilm
+}
+
+// Описание ошибки в формате RFC 7807 (application/problem+json).
+// code - стабильный машиночитаемый код ошибки, errors - ошибки полей при валидации
+// swagger:response problemResponse
+type problemResponseWrapper struct {
+	// in: body
+	Body Problem
+}
+
 // Model for adding actor into database
 // swagger:parameters addActor
 type actorRequestWrapper struct {
@@ -63,7 +113,16 @@ type filmWrapper struct {
 	Body Film
 }
 
-// swagger:parameters updateFilm deleteFilm
+// Изменения фильма. Для JSON Merge Patch - объект только с изменяемыми полями,
+// для JSON Patch - массив операций
+// swagger:parameters patchFilm
+type filmPatchWrapper struct {
+	// Изменения фильма
+	// in: body
+	Body FilmRequest
+}
+
+// swagger:parameters updateFilm deleteFilm patchFilm uploadFilmPoster
 type filmIDParameterWrapper struct {
 	// ID фильма
 	// in: path
@@ -73,11 +132,51 @@ type filmIDParameterWrapper struct {
 
 // swagger:parameters getFilms
 type filmSortByParameterWrapper struct {
-	// Параметр для сортировки. Возможные поля - rating, title, release_date
+	// Параметр для сортировки. Возможные поля - rating, title, release_date, community_rating
 	// in: query
 	SortBy string `json:"sort_by"`
 }
 
+// swagger:parameters getFilms getFilm getUpcomingFilms getReleaseCalendar getReleaseFeed
+type filmGenreParameterWrapper struct {
+	// Фильтр по жанрам. Можно передать несколько раз или через запятую
+	// in: query
+	Genre []string `json:"genre"`
+	// Режим фильтра по жанрам. Возможные значения - any, all
+	// in: query
+	GenreMode string `json:"genre_mode"`
+	// Фильтр по фрагменту имени режиссёра
+	// in: query
+	Director string `json:"director"`
+	// Максимальный возрастной рейтинг: 0+, 6+, 12+, 16+, 18+
+	// in: query
+	MaxAgeRating string `json:"max_age_rating"`
+	// Фильтр по странам производства (ISO 3166-1 alpha-2). Можно передать несколько раз или через запятую
+	// in: query
+	Country []string `json:"country"`
+	// Фильтр по языку оригинала или озвучки (ISO 639-1)
+	// in: query
+	Language string `json:"language"`
+	// Минимальная продолжительность в минутах
+	// in: query
+	MinRuntime int `json:"min_runtime"`
+	// Максимальная продолжительность в минутах
+	// in: query
+	MaxRuntime int `json:"max_runtime"`
+	// Язык названия и описания в ответе. Важнее заголовка Accept-Language
+	// in: query
+	Lang string `json:"lang"`
+	// Только фильмы, победившие хотя бы в одной номинации
+	// in: query
+	WonAward bool `json:"won_award"`
+	// Минимальный возраст актёра на дату выхода фильма
+	// in: query
+	ActorMinAge int `json:"actor_min_age"`
+	// Максимальный возраст актёра на дату выхода фильма
+	// in: query
+	ActorMaxAge int `json:"actor_max_age"`
+}
+
 // swagger:parameters getFilm
 type filmByTitleParameterWrapper struct {
 	// Поиск по фрагменту названия
@@ -90,4 +189,554 @@ type filmByActorNameParameterWrapper struct {
 	// Поиск по фрагменту имени актёра
 	// in: query
 	Actor string `json:"actor"`
-}
\ No newline at end of file
+}
+
+// A genre from database
+// swagger:response genre
+type genreResponseWrapper struct {
+	// Данные о жанре
+	// in: body
+	Body Genre
+}
+
+// Model for adding or renaming genre
+// swagger:parameters addGenre updateGenre
+type genreRequestWrapper struct {
+	// Данные о жанре
+	// in: body
+	Body GenreRequest
+}
+
+// swagger:parameters getGenre updateGenre deleteGenre
+type genreIDParameterWrapper struct {
+	// ID жанра
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// A person with credits
+// swagger:response personWithCredits
+type personWithCreditsResponseWrapper struct {
+	// Данные о персоне и её работах
+	// in: body
+	Body PersonWithCredits
+}
+
+// A crew member of a film
+// swagger:response crewMember
+type crewMemberResponseWrapper struct {
+	// Участник съёмочной группы
+	// in: body
+	Body CrewMember
+}
+
+// swagger:parameters getPerson
+type personIDParameterWrapper struct {
+	// ID персоны
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// swagger:parameters getFilmCrew getFilmCast addCrewMember deleteCrewMember
+type crewFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// Model for adding person into film crew
+// swagger:parameters addCrewMember
+type crewRequestWrapper struct {
+	// Персона и её роль
+	// in: body
+	Body CrewRequest
+}
+
+// swagger:parameters deleteCrewMember
+type crewMemberParameterWrapper struct {
+	// ID персоны
+	// in: path
+	// required: true
+	PersonID int `json:"person_id"`
+	// Роль, которую нужно удалить. Если не указана - удаляются все роли персоны в фильме
+	// in: query
+	Role string `json:"role"`
+}
+
+// A translation of the film
+// swagger:response filmTranslation
+type filmTranslationResponseWrapper struct {
+	// Перевод названия и описания фильма
+	// in: body
+	Body FilmTranslation
+}
+
+// swagger:parameters getFilmTranslations setFilmTranslation deleteFilmTranslation
+type translationFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// swagger:parameters setFilmTranslation deleteFilmTranslation
+type translationLanguageParameterWrapper struct {
+	// Язык перевода, ISO 639-1
+	// in: path
+	// required: true
+	Lang string `json:"lang"`
+}
+
+// Model for adding or replacing translation of the film
+// swagger:parameters setFilmTranslation
+type filmTranslationRequestWrapper struct {
+	// Перевод названия и описания
+	// in: body
+	Body FilmTranslationRequest
+}
+
+// An uploaded image with thumbnails
+// swagger:response image
+type imageResponseWrapper struct {
+	// Ссылки на изображение и миниатюры
+	// in: body
+	Body Image
+}
+
+// swagger:parameters uploadFilmPoster uploadActorPhoto
+type imageUploadParameterWrapper struct {
+	// Изображение в формате JPEG, PNG или WebP, не больше 10 МБ
+	// in: formData
+	// required: true
+	// swagger:file
+	File []byte `json:"file"`
+}
+
+// A franchise from database
+// swagger:response franchise
+type franchiseResponseWrapper struct {
+	// Данные о франшизе
+	// in: body
+	Body Franchise
+}
+
+// A franchise with its films
+// swagger:response franchiseWithFilms
+type franchiseWithFilmsResponseWrapper struct {
+	// Франшиза и её фильмы в хронологическом порядке
+	// in: body
+	Body FranchiseWithFilms
+}
+
+// Model for adding or updating franchise
+// swagger:parameters addFranchise updateFranchise
+type franchiseRequestWrapper struct {
+	// Данные о франшизе
+	// in: body
+	Body FranchiseRequest
+}
+
+// swagger:parameters getFranchise updateFranchise deleteFranchise addFranchiseFilm deleteFranchiseFilm
+type franchiseIDParameterWrapper struct {
+	// ID франшизы
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// Model for adding film into franchise
+// swagger:parameters addFranchiseFilm
+type franchiseFilmRequestWrapper struct {
+	// Фильм франшизы
+	// in: body
+	Body FranchiseFilmRequest
+}
+
+// swagger:parameters deleteFranchiseFilm deleteFilmRelation
+type nestedFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	FilmID int `json:"film_id"`
+}
+
+// swagger:parameters getRelatedFilms addFilmRelation deleteFilmRelation getFilmNominations
+type relationFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// Model for adding relation between films
+// swagger:parameters addFilmRelation
+type filmRelationRequestWrapper struct {
+	// Связанный фильм и тип связи
+	// in: body
+	Body FilmRelationRequest
+}
+
+// An award from database
+// swagger:response award
+type awardResponseWrapper struct {
+	// Данные о премии
+	// in: body
+	Body Award
+}
+
+// An award with ceremonies and categories
+// swagger:response awardWithDetails
+type awardWithDetailsResponseWrapper struct {
+	// Премия, её церемонии и категории
+	// in: body
+	Body AwardWithDetails
+}
+
+// Model for adding or renaming award
+// swagger:parameters addAward updateAward
+type awardRequestWrapper struct {
+	// Данные о премии
+	// in: body
+	Body AwardRequest
+}
+
+// swagger:parameters getAward updateAward deleteAward addCeremony getCeremonyNominations deleteCeremony addAwardCategory deleteAwardCategory
+type awardIDParameterWrapper struct {
+	// ID премии
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// A ceremony of the award
+// swagger:response awardCeremony
+type awardCeremonyResponseWrapper struct {
+	// Церемония премии
+	// in: body
+	Body AwardCeremony
+}
+
+// Model for adding ceremony
+// swagger:parameters addCeremony
+type awardCeremonyRequestWrapper struct {
+	// Год церемонии
+	// in: body
+	Body AwardCeremonyRequest
+}
+
+// swagger:parameters getCeremonyNominations deleteCeremony
+type ceremonyYearParameterWrapper struct {
+	// Год церемонии
+	// in: path
+	// required: true
+	Year int `json:"year"`
+}
+
+// A category of the award
+// swagger:response awardCategory
+type awardCategoryResponseWrapper struct {
+	// Категория премии
+	// in: body
+	Body AwardCategory
+}
+
+// Model for adding category
+// swagger:parameters addAwardCategory
+type awardCategoryRequestWrapper struct {
+	// Название категории
+	// in: body
+	Body AwardCategoryRequest
+}
+
+// swagger:parameters deleteAwardCategory
+type awardCategoryIDParameterWrapper struct {
+	// ID категории
+	// in: path
+	// required: true
+	CategoryID int `json:"category_id"`
+}
+
+// A nomination
+// swagger:response nomination
+type nominationResponseWrapper struct {
+	// Номинация
+	// in: body
+	Body Nomination
+}
+
+// Model for adding or replacing nomination
+// swagger:parameters addNomination updateNomination
+type nominationRequestWrapper struct {
+	// Данные о номинации
+	// in: body
+	Body NominationRequest
+}
+
+// swagger:parameters updateNomination deleteNomination
+type nominationIDParameterWrapper struct {
+	// ID номинации
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// A review
+// swagger:response review
+type reviewResponseWrapper struct {
+	// Оценка и отзыв пользователя
+	// in: body
+	Body Review
+}
+
+// Model for rating a film
+// swagger:parameters setReview
+type reviewRequestWrapper struct {
+	// Оценка и текст отзыва
+	// in: body
+	Body ReviewRequest
+}
+
+// swagger:parameters getFilmReviews setReview deleteReview
+type reviewFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// Model for flagging a review
+// swagger:parameters flagReview
+type reviewFlagRequestWrapper struct {
+	// Причина жалобы
+	// in: body
+	Body ReviewFlagRequest
+}
+
+// Model for moderating a review
+// swagger:parameters moderateReview
+type reviewModerationRequestWrapper struct {
+	// Решение модератора
+	// in: body
+	Body ReviewModerationRequest
+}
+
+// swagger:parameters flagReview moderateReview
+type reviewIDParameterWrapper struct {
+	// ID отзыва
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
+
+// swagger:parameters getModerationQueue
+type moderationQueueParameterWrapper struct {
+	// Очередь: pending или flagged, по умолчанию обе
+	// in: query
+	Queue string `json:"queue"`
+	// ID фильма
+	// in: query
+	FilmID int `json:"film_id"`
+	// ID автора отзыва
+	// in: query
+	UserID int `json:"user_id"`
+	// Размер страницы, по умолчанию 20, не больше 100
+	// in: query
+	Limit int `json:"limit"`
+	// Сколько отзывов пропустить
+	// in: query
+	Offset int `json:"offset"`
+}
+
+// Model for adding a film to a personal list
+// swagger:parameters addToList
+type userListRequestWrapper struct {
+	// Дата просмотра для списка watched
+	// in: body
+	Body UserListRequest
+}
+
+// swagger:parameters getListFilms addToList removeFromList
+type userListParameterWrapper struct {
+	// Список: watchlist, favorites или watched
+	// in: path
+	// required: true
+	List string `json:"list"`
+}
+
+// swagger:parameters addToList removeFromList
+type userListFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	FilmID int `json:"film_id"`
+}
+
+// swagger:parameters getListFilms
+type userListQueryParameterWrapper struct {
+	// Сортировка: added_at, watched_at, title, release_date или rating
+	// in: query
+	SortBy string `json:"sort_by"`
+	// Размер страницы, по умолчанию 20, не больше 100
+	// in: query
+	Limit int `json:"limit"`
+	// Сколько фильмов пропустить
+	// in: query
+	Offset int `json:"offset"`
+}
+
+// Model for creating and updating a collection
+// swagger:parameters addCollection updateCollection
+type collectionRequestWrapper struct {
+	// Название, описание и видимость коллекции
+	// in: body
+	Body CollectionRequest
+}
+
+// swagger:parameters getCollection updateCollection deleteCollection setCollectionFilm deleteCollectionFilm reorderCollection forkCollection
+type collectionSlugParameterWrapper struct {
+	// Адрес коллекции
+	// in: path
+	// required: true
+	Slug string `json:"slug"`
+}
+
+// swagger:parameters setCollectionFilm deleteCollectionFilm
+type collectionFilmIDParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	FilmID int `json:"film_id"`
+}
+
+// Model for a note of the film in a collection
+// swagger:parameters setCollectionFilm
+type collectionFilmRequestWrapper struct {
+	// Заметка о фильме
+	// in: body
+	Body CollectionFilmRequest
+}
+
+// Model for reordering a collection
+// swagger:parameters reorderCollection
+type collectionOrderRequestWrapper struct {
+	// Новый порядок фильмов
+	// in: body
+	Body CollectionOrderRequest
+}
+
+// swagger:parameters getCollections
+type collectionsQueryParameterWrapper struct {
+	// ID владельца коллекций
+	// in: query
+	UserID int `json:"user_id"`
+	// Размер страницы, по умолчанию 20, не больше 100
+	// in: query
+	Limit int `json:"limit"`
+	// Сколько коллекций пропустить
+	// in: query
+	Offset int `json:"offset"`
+}
+
+// swagger:parameters getSimilarFilms
+type similarFilmsParameterWrapper struct {
+	// ID фильма
+	// in: path
+	// required: true
+	ID int `json:"id"`
+	// Число фильмов, по умолчанию 10, не больше 50
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters getRecommendations
+type recommendationsParameterWrapper struct {
+	// Число фильмов, по умолчанию 20, не больше 50
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters getCostars
+type costarsParameterWrapper struct {
+	// ID актёра
+	// in: path
+	// required: true
+	ID int `json:"id"`
+	// Размер страницы, по умолчанию 20, не больше 100
+	// in: query
+	Limit int `json:"limit"`
+	// Сколько партнёров пропустить
+	// in: query
+	Offset int `json:"offset"`
+}
+
+// swagger:parameters getActorPath
+type actorPathParameterWrapper struct {
+	// ID актёра, с которого начинается цепочка
+	// in: query
+	// required: true
+	From int `json:"from"`
+	// ID актёра, которым заканчивается цепочка
+	// in: query
+	// required: true
+	To int `json:"to"`
+	// Наибольшее число фильмов в цепочке, по умолчанию 6, не больше 10
+	// in: query
+	MaxDepth int `json:"max_depth"`
+}
+
+// swagger:parameters getProlificActors
+type prolificActorsParameterWrapper struct {
+	// Число актёров, по умолчанию 10, не больше 100
+	// in: query
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters getUpcomingFilms getReleaseCalendar getReleaseFeed
+type releaseActorParameterWrapper struct {
+	// Только фильмы с этим актёром
+	// in: query
+	ActorID int `json:"actor_id"`
+}
+
+// swagger:parameters getReleaseCalendar
+type releaseCalendarParameterWrapper struct {
+	// Первый день календаря, YYYY-MM-DD
+	// in: query
+	// required: true
+	From string `json:"from"`
+	// Последний день календаря, YYYY-MM-DD, не больше чем через 366 дней после from
+	// in: query
+	// required: true
+	To string `json:"to"`
+}
+
+// Лента релизов в формате iCalendar
+// swagger:response releaseFeed
+type releaseFeedResponseWrapper struct {
+	// in: body
+	Body string
+}
+
+// Лента в формате Atom или RSS 2.0
+// swagger:response feed
+type feedResponseWrapper struct {
+	// Хэш ленты для If-None-Match
+	ETag string `json:"ETag"`
+	// in: body
+	Body string
+}
+
+// Лента не изменилась с указанного в If-None-Match
+// swagger:response notModified
+type notModifiedResponseWrapper struct{}
+
+// swagger:parameters getActorFeed
+type actorFeedParameterWrapper struct {
+	// ID актёра
+	// in: path
+	// required: true
+	ID int `json:"id"`
+}
@@ -0,0 +1,102 @@
+package apperror
+
+import "vk-intern_test-case/models"
+
+type Kind int
+
+const (
+	KindInternal Kind = iota
+	KindBadRequest
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindUnauthorized
+	KindForbidden
+	KindUnsupportedMediaType
+)
+
+// Error is a domain error which is safe to show to clients.
+// Code is a stable machine-readable identifier, Message is a human-readable description.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []models.FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Message + ": " + e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is reports errors with the same kind and code as equal, so package level
+// errors can be used as sentinels while carrying different messages
+func (e *Error) Is(target error) bool {
+	targetErr, ok := target.(*Error)
+	return ok && targetErr.Kind == e.Kind && targetErr.Code == e.Code
+}
+
+// WithMessage returns a copy of the error with another message
+func (e *Error) WithMessage(message string) *Error {
+	copied := *e
+	copied.Message = message
+	return &copied
+}
+
+// WithFields returns a copy of the error with field errors attached
+func (e *Error) WithFields(fields []models.FieldError) *Error {
+	copied := *e
+	copied.Fields = fields
+	return &copied
+}
+
+// Wrap returns a copy of the error with the cause attached. The cause is never shown to clients
+func (e *Error) Wrap(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+func New(kind Kind, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+func BadRequest(code, message string) *Error {
+	return New(KindBadRequest, code, message)
+}
+
+func NotFound(code, message string) *Error {
+	return New(KindNotFound, code, message)
+}
+
+func Conflict(code, message string) *Error {
+	return New(KindConflict, code, message)
+}
+
+func Validation(code, message string, fields []models.FieldError) *Error {
+	err := New(KindValidation, code, message)
+	err.Fields = fields
+	return err
+}
+
+func Unauthorized(code, message string) *Error {
+	return New(KindUnauthorized, code, message)
+}
+
+func Forbidden(code, message string) *Error {
+	return New(KindForbidden, code, message)
+}
+
+var (
+	ErrInternal     = New(KindInternal, "internal_error", "Internal server error")
+	ErrInvalidID    = BadRequest("invalid_id", "Invalid id in request path")
+	ErrInvalidJSON  = BadRequest("invalid_json", "Request body is not a valid JSON")
+	ErrInvalidQuery = BadRequest("invalid_query", "Invalid query parameter")
+)
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"errors"
+	"vk-intern_test-case/utils/apperror"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Коды ошибок Postgres, которые вызваны данными запроса
+const (
+	uniqueViolation           = "23505"
+	foreignKeyViolation       = "23503"
+	checkViolation            = "23514"
+	notNullViolation          = "23502"
+	invalidDatetimeFormat     = "22007"
+	datetimeFieldOverflow     = "22008"
+	numericValueOutOfRange    = "22003"
+	invalidTextRepresentation = "22P02"
+)
+
+var (
+	ErrAlreadyExists      = apperror.Conflict("already_exists", "Resource already exists")
+	ErrReferenceViolation = apperror.Conflict("reference_violation", "Referenced resource does not exist or is still in use")
+	ErrInvalidValue       = apperror.Validation("invalid_value", "Request contains a value not accepted by the storage", nil)
+)
+
+// MapError translates Postgres errors caused by request data into domain errors.
+// The original error is kept as a cause and is never shown to clients
+func MapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation:
+		return ErrAlreadyExists.Wrap(err)
+	case foreignKeyViolation:
+		return ErrReferenceViolation.Wrap(err)
+	case checkViolation, notNullViolation, invalidDatetimeFormat, datetimeFieldOverflow,
+		numericValueOutOfRange, invalidTextRepresentation:
+		return ErrInvalidValue.Wrap(err)
+	}
+	return err
+}
@@ -0,0 +1,180 @@
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Content types of syndication feeds
+const (
+	AtomContentType = "application/atom+xml; charset=utf-8"
+	RSSContentType  = "application/rss+xml; charset=utf-8"
+)
+
+const (
+	atomNamespace = "http://www.w3.org/2005/Atom"
+	author        = "VK-Intern 2024"
+)
+
+// Feed - лента в формате, не зависящем от Atom или RSS
+type Feed struct {
+	// Постоянный идентификатор ленты (IRI), по нему читалки отличают ленты
+	ID    string
+	Title string
+	// Страница, которую описывает лента
+	Link string
+	// Адрес самой ленты
+	SelfLink string
+	// Время последнего изменения ленты или любой из её записей
+	Updated time.Time
+	Entries []Entry
+}
+
+// Entry - запись ленты
+type Entry struct {
+	// Постоянный идентификатор записи, по нему читалки узнают уже прочитанное
+	ID        string
+	Title     string
+	Link      string
+	Summary   string
+	Published time.Time
+	Updated   time.Time
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string   `xml:"id"`
+	Title     string   `xml:"title"`
+	Link      atomLink `xml:"link"`
+	Published string   `xml:"published"`
+	Updated   string   `xml:"updated"`
+	Summary   string   `xml:"summary,omitempty"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	SelfLink      atomLink  `xml:"atom:link"`
+	LastBuildDate string    `xml:"lastBuildDate"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rss struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	AtomXMLNS string     `xml:"xmlns:atom,attr"`
+	Channel   rssChannel `xml:"channel"`
+}
+
+// Atom returns feed in Atom 1.0 format (RFC 4287)
+func Atom(feed *Feed) ([]byte, error) {
+	result := atomFeed{
+		XMLNS:   atomNamespace,
+		ID:      feed.ID,
+		Title:   feed.Title,
+		Updated: atomTime(feed.Updated),
+		Links: []atomLink{
+			{Href: feed.Link, Rel: "alternate"},
+			{Href: feed.SelfLink, Rel: "self", Type: "application/atom+xml"},
+		},
+		Author:  atomAuthor{Name: author},
+		Entries: []atomEntry{},
+	}
+	for _, entry := range feed.Entries {
+		result.Entries = append(result.Entries, atomEntry{
+			ID:        entry.ID,
+			Title:     entry.Title,
+			Link:      atomLink{Href: entry.Link, Rel: "alternate"},
+			Published: atomTime(entry.Published),
+			Updated:   atomTime(entry.Updated),
+			Summary:   entry.Summary,
+		})
+	}
+	return marshal(result)
+}
+
+// RSS returns feed in RSS 2.0 format
+func RSS(feed *Feed) ([]byte, error) {
+	result := rss{
+		Version:   "2.0",
+		AtomXMLNS: atomNamespace,
+		Channel: rssChannel{
+			Title:         feed.Title,
+			Link:          feed.Link,
+			Description:   feed.Title,
+			SelfLink:      atomLink{Href: feed.SelfLink, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: rssTime(feed.Updated),
+			Items:         []rssItem{},
+		},
+	}
+	for _, entry := range feed.Entries {
+		result.Channel.Items = append(result.Channel.Items, rssItem{
+			Title:       entry.Title,
+			Link:        entry.Link,
+			Description: entry.Summary,
+			GUID:        rssGUID{IsPermaLink: "false", Value: entry.ID},
+			// В RSS нет даты изменения записи, поэтому указывается дата публикации
+			PubDate: rssTime(entry.Published),
+		})
+	}
+	return marshal(result)
+}
+
+// marshal encodes value as indented XML document
+func marshal(value any) ([]byte, error) {
+	body, err := xml.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(body, '\n')...), nil
+}
+
+// normalize returns t in UTC, replacing zero time with Unix epoch so dates stay valid
+func normalize(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return t.UTC()
+}
+
+func atomTime(t time.Time) string {
+	return normalize(t).Format(time.RFC3339)
+}
+
+func rssTime(t time.Time) string {
+	return normalize(t).Format(time.RFC1123Z)
+}
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testFeed = &Feed{
+	ID:       "tag:vk-intern,2024:films",
+	Title:    "Новые фильмы",
+	Link:     "http://localhost:8080/films",
+	SelfLink: "http://localhost:8080/feeds/films.atom",
+	Updated:  time.Date(2024, time.March, 18, 15, 30, 0, 0, time.FixedZone("MSK", 3*60*60)),
+	Entries: []Entry{{
+		ID:        "tag:vk-intern,2024:film:1",
+		Title:     "Дюна & Co <2024>",
+		Link:      "http://localhost:8080/films/1",
+		Summary:   "Описание",
+		Published: time.Date(2024, time.March, 17, 10, 0, 0, 0, time.UTC),
+		Updated:   time.Date(2024, time.March, 18, 12, 30, 0, 0, time.UTC),
+	}},
+}
+
+func TestAtom(t *testing.T) {
+	body, err := Atom(testFeed)
+	assert.NoError(t, err)
+
+	atom := string(body)
+	assert.True(t, strings.HasPrefix(atom, xml.Header))
+	assert.Contains(t, atom, `<feed xmlns="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, atom, "<id>tag:vk-intern,2024:films</id>")
+	assert.Contains(t, atom, "<updated>2024-03-18T12:30:00Z</updated>")
+	assert.Contains(t, atom, `<link href="http://localhost:8080/feeds/films.atom" rel="self" type="application/atom+xml"></link>`)
+	assert.Contains(t, atom, "<title>Дюна &amp; Co &lt;2024&gt;</title>")
+	assert.Contains(t, atom, "<published>2024-03-17T10:00:00Z</published>")
+	assert.Contains(t, atom, "<summary>Описание</summary>")
+
+	parsed := atomFeed{}
+	assert.NoError(t, xml.Unmarshal(body, &parsed))
+	assert.Len(t, parsed.Entries, 1)
+}
+
+func TestRSS(t *testing.T) {
+	body, err := RSS(testFeed)
+	assert.NoError(t, err)
+
+	rss := string(body)
+	assert.Contains(t, rss, `<rss version="2.0" xmlns:atom="http://www.w3.org/2005/Atom">`)
+	assert.Contains(t, rss, "<lastBuildDate>Mon, 18 Mar 2024 12:30:00 +0000</lastBuildDate>")
+	assert.Contains(t, rss, `<atom:link href="http://localhost:8080/feeds/films.atom" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, rss, `<guid isPermaLink="false">tag:vk-intern,2024:film:1</guid>`)
+	assert.Contains(t, rss, "<pubDate>Sun, 17 Mar 2024 10:00:00 +0000</pubDate>")
+}
+
+func TestZeroTimeFallsBackToEpoch(t *testing.T) {
+	body, err := Atom(&Feed{ID: "tag:vk-intern,2024:empty", Title: "Пусто"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "<updated>1970-01-01T00:00:00Z</updated>")
+}
@@ -0,0 +1,76 @@
+package ical
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType of iCalendar feeds
+const ContentType = "text/calendar; charset=utf-8"
+
+// Строки длиннее стольких байт переносятся (RFC 5545, 3.1)
+const maxLineLength = 75
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+)
+
+// Event - событие на целый день
+type Event struct {
+	// Постоянный идентификатор, по нему календари обновляют событие
+	UID         string
+	Date        time.Time
+	Summary     string
+	Description string
+}
+
+// Marshal returns calendar with all-day events in iCalendar format. stamp is the time the feed was built
+func Marshal(name string, events []Event, stamp time.Time) []byte {
+	builder := &strings.Builder{}
+	writeLine(builder, "BEGIN:VCALENDAR")
+	writeLine(builder, "VERSION:2.0")
+	writeLine(builder, "PRODID:-//VK-Intern 2024//Films//RU")
+	writeLine(builder, "CALSCALE:GREGORIAN")
+	writeLine(builder, "METHOD:PUBLISH")
+	writeLine(builder, "X-WR-CALNAME:"+escape(name))
+	for _, event := range events {
+		writeLine(builder, "BEGIN:VEVENT")
+		writeLine(builder, "UID:"+escape(event.UID))
+		writeLine(builder, "DTSTAMP:"+stamp.UTC().Format(dateTimeFormat))
+		writeLine(builder, "DTSTART;VALUE=DATE:"+event.Date.Format(dateFormat))
+		writeLine(builder, "DTEND;VALUE=DATE:"+event.Date.AddDate(0, 0, 1).Format(dateFormat))
+		writeLine(builder, "SUMMARY:"+escape(event.Summary))
+		if event.Description != "" {
+			writeLine(builder, "DESCRIPTION:"+escape(event.Description))
+		}
+		writeLine(builder, "TRANSP:TRANSPARENT")
+		writeLine(builder, "END:VEVENT")
+	}
+	writeLine(builder, "END:VCALENDAR")
+	return []byte(builder.String())
+}
+
+// escape escapes special characters of a text value
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(value)
+}
+
+// writeLine writes content line ending with CRLF, folding it without splitting UTF-8 characters
+func writeLine(builder *strings.Builder, line string) {
+	limit := maxLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+		// Пробел в начале продолжения тоже считается
+		limit = maxLineLength - 1
+	}
+	builder.WriteString(line)
+	builder.WriteString("\r\n")
+}
@@ -0,0 +1,44 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshal(t *testing.T) {
+	stamp := time.Date(2024, time.March, 18, 12, 30, 0, 0, time.UTC)
+	events := []Event{{
+		UID:         "film-1@vk-intern",
+		Date:        time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Summary:     "Дюна; часть вторая, IMAX",
+		Description: "Строка 1\nСтрока 2",
+	}}
+
+	calendar := string(Marshal("Премьеры", events, stamp))
+
+	assert.True(t, strings.HasPrefix(calendar, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(calendar, "END:VCALENDAR\r\n"))
+	assert.Contains(t, calendar, "X-WR-CALNAME:Премьеры\r\n")
+	assert.Contains(t, calendar, "UID:film-1@vk-intern\r\n")
+	assert.Contains(t, calendar, "DTSTAMP:20240318T123000Z\r\n")
+	assert.Contains(t, calendar, "DTSTART;VALUE=DATE:20241231\r\n")
+	assert.Contains(t, calendar, "DTEND;VALUE=DATE:20250101\r\n")
+	assert.Contains(t, calendar, `SUMMARY:Дюна\; часть вторая\, IMAX`+"\r\n")
+	assert.Contains(t, calendar, `DESCRIPTION:Строка 1\nСтрока 2`+"\r\n")
+}
+
+func TestMarshalFoldsLongLines(t *testing.T) {
+	summary := strings.Repeat("Очень длинное название ", 10)
+	events := []Event{{UID: "film-2@vk-intern", Date: time.Now(), Summary: summary}}
+
+	calendar := string(Marshal("Премьеры", events, time.Now()))
+
+	for _, line := range strings.Split(strings.TrimSuffix(calendar, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineLength)
+	}
+	unfolded := strings.ReplaceAll(calendar, "\r\n ", "")
+	assert.Contains(t, unfolded, "SUMMARY:"+summary+"\r\n")
+}
@@ -0,0 +1,201 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/storage"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+const (
+	// Максимальный размер загружаемого файла
+	MaxUploadSize = 10 << 20
+	// Максимальная ширина и высота изображения, защищает от распаковки огромных картинок
+	MaxDimension = 8000
+	jpegQuality  = 85
+)
+
+var (
+	ErrUnsupportedFormat = apperror.New(apperror.KindUnsupportedMediaType,
+		"unsupported_image_format", "Image must be JPEG, PNG or WebP")
+	ErrInvalidImage = apperror.Validation("invalid_image", "File is not a valid image", nil)
+	ErrTooLarge     = apperror.Validation("image_too_large", "Image is too large", nil)
+	ErrMissingFile  = apperror.BadRequest("missing_file", "Multipart form field file is required")
+)
+
+// Миниатюры и их ширина в пикселях. Высота подбирается с сохранением пропорций
+var thumbnailWidths = []struct {
+	name  string
+	width int
+}{
+	{"small", 160},
+	{"medium", 320},
+	{"large", 640},
+}
+
+// Processed is an uploaded image re-encoded without metadata together with its thumbnails
+type Processed struct {
+	Extension  string
+	Original   []byte
+	Thumbnails map[string][]byte
+}
+
+// Process checks that data is a JPEG, PNG or WebP image and prepares it for storing.
+// Images are decoded and encoded again, so EXIF and other metadata are dropped.
+// JPEG stays JPEG, PNG and WebP are stored as PNG to keep transparency
+func Process(data []byte) (*Processed, error) {
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" && contentType != "image/webp" {
+		return nil, ErrUnsupportedFormat
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width > MaxDimension || config.Height > MaxDimension {
+		return nil, ErrTooLarge.WithMessage("Image must be at most " +
+			strconv.Itoa(MaxDimension) + "x" + strconv.Itoa(MaxDimension) + " pixels")
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	processed := &Processed{Extension: "png", Thumbnails: map[string][]byte{}}
+	encode := encodePNG
+	if contentType == "image/jpeg" {
+		processed.Extension = "jpg"
+		encode = encodeJPEG
+	}
+
+	processed.Original, err = encode(img)
+	if err != nil {
+		return nil, err
+	}
+	for _, thumbnail := range thumbnailWidths {
+		processed.Thumbnails[thumbnail.name], err = encode(resize(img, thumbnail.width))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return processed, nil
+}
+
+// Version - сохранённая версия изображения. Пока она не закреплена Commit,
+// предыдущие версии остаются на месте, а Discard удаляет её саму
+type Version struct {
+	Image *models.Image
+
+	store storage.Storage
+	dir   string
+	name  string
+}
+
+// Save сохраняет новую версию изображения в отдельный подкаталог dir, чтобы клиенты
+// не получили старую картинку из кэша. Предыдущие версии не удаляются
+func Save(store storage.Storage, dir string, processed *Processed) (*Version, error) {
+	version := &Version{
+		Image: &models.Image{},
+		store: store,
+		dir:   dir,
+		name:  strconv.FormatInt(time.Now().UnixNano(), 36),
+	}
+	save := func(name string, data []byte) (string, error) {
+		fileName := dir + "/" + version.name + "/" + name + "." + processed.Extension
+		err := store.Save(fileName, data)
+		return store.URL(fileName), err
+	}
+
+	var err error
+	for _, file := range []struct {
+		url  *string
+		name string
+		data []byte
+	}{
+		{&version.Image.Original, "original", processed.Original},
+		{&version.Image.Small, "small", processed.Thumbnails["small"]},
+		{&version.Image.Medium, "medium", processed.Thumbnails["medium"]},
+		{&version.Image.Large, "large", processed.Thumbnails["large"]},
+	} {
+		*file.url, err = save(file.name, file.data)
+		if err != nil {
+			_ = version.Discard()
+			return nil, err
+		}
+	}
+	return version, nil
+}
+
+// Commit удаляет предыдущие версии изображения, вызывается после того, как новая записана в базу
+func (v *Version) Commit() error {
+	names, err := v.store.List(v.dir)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == v.name {
+			continue
+		}
+		err = v.store.DeleteAll(v.dir + "/" + name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Discard удаляет эту версию, если её не удалось записать в базу
+func (v *Version) Discard() error {
+	return v.store.DeleteAll(v.dir + "/" + v.name)
+}
+
+// resize уменьшает изображение до ширины width. Изображения меньше не увеличиваются
+func resize(img image.Image, width int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= width {
+		return img
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+	thumbnail := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(thumbnail, thumbnail.Bounds(), img, bounds, draw.Over, nil)
+	return thumbnail
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: jpegQuality})
+	return buffer.Bytes(), err
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	var buffer bytes.Buffer
+	err := png.Encode(&buffer, img)
+	return buffer.Bytes(), err
+}
+
+// ReadUpload читает файл из поля file multipart-формы. Размер запроса ограничен MaxUploadSize
+func ReadUpload(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxUploadSize)
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, ErrTooLarge.WithMessage("Image must be at most " + strconv.Itoa(MaxUploadSize>>20) + " MB")
+		}
+		return nil, ErrMissingFile
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
@@ -0,0 +1,112 @@
+package images
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+	"vk-intern_test-case/utils/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+	return img
+}
+
+func imageWidth(t *testing.T, data []byte) int {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	assert.Nil(t, err)
+	return config.Width
+}
+
+func TestProcessPNG(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.Nil(t, png.Encode(&buffer, testImage(400, 200)))
+
+	processed, err := Process(buffer.Bytes())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "png", processed.Extension)
+	assert.Equal(t, 400, imageWidth(t, processed.Original))
+	assert.Equal(t, 160, imageWidth(t, processed.Thumbnails["small"]))
+	assert.Equal(t, 320, imageWidth(t, processed.Thumbnails["medium"]))
+	assert.Equal(t, 400, imageWidth(t, processed.Thumbnails["large"]))
+}
+
+func TestProcessJPEGDropsMetadata(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.Nil(t, jpeg.Encode(&buffer, testImage(200, 100), nil))
+	exif := []byte("Exif\x00\x00secret-gps-position")
+	segment := append([]byte{0xFF, 0xE1, 0x00, byte(len(exif) + 2)}, exif...)
+	data := append(append([]byte{0xFF, 0xD8}, segment...), buffer.Bytes()[2:]...)
+
+	processed, err := Process(data)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "jpg", processed.Extension)
+	assert.NotContains(t, string(processed.Original), "secret-gps-position")
+}
+
+func TestProcessRejectsInvalidImages(t *testing.T) {
+	_, err := Process([]byte("GIF89a not supported"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+
+	_, err = Process(append([]byte("\x89PNG\r\n\x1a\n"), strings.Repeat("x", 100)...))
+	assert.ErrorIs(t, err, ErrInvalidImage)
+}
+
+func testProcessed() *Processed {
+	return &Processed{
+		Extension:  "png",
+		Original:   []byte("original"),
+		Thumbnails: map[string][]byte{"small": []byte("small"), "medium": []byte("medium"), "large": []byte("large")},
+	}
+}
+
+func TestSaveKeepsPreviousVersionUntilCommit(t *testing.T) {
+	root := t.TempDir()
+	store := storage.NewLocalStorage(root, "/media")
+
+	first, err := Save(store, "films/1/poster", testProcessed())
+	assert.Nil(t, err)
+	assert.Nil(t, first.Commit())
+	second, err := Save(store, "films/1/poster", testProcessed())
+	assert.Nil(t, err)
+
+	assert.NotEqual(t, first.Image.Original, second.Image.Original)
+	assert.True(t, strings.HasPrefix(second.Image.Small, "/media/films/1/poster/"))
+	assert.True(t, strings.HasSuffix(second.Image.Small, "/small.png"))
+	versions, err := store.List("films/1/poster")
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+
+	assert.Nil(t, second.Commit())
+	versions, err = store.List("films/1/poster")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{second.name}, versions)
+}
+
+func TestDiscardRemovesOnlyNewVersion(t *testing.T) {
+	store := storage.NewLocalStorage(t.TempDir(), "/media")
+
+	first, err := Save(store, "actors/1/photo", testProcessed())
+	assert.Nil(t, err)
+	assert.Nil(t, first.Commit())
+	second, err := Save(store, "actors/1/photo", testProcessed())
+	assert.Nil(t, err)
+
+	assert.Nil(t, second.Discard())
+	versions, err := store.List("actors/1/photo")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{first.name}, versions)
+}
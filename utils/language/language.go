@@ -0,0 +1,60 @@
+package language
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Preferred returns languages of the response in order of preference.
+// Parameter lang takes precedence over Accept-Language header.
+// Only primary subtags are kept: en-US becomes en
+func Preferred(r *http.Request) []string {
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		return collect(strings.Split(lang, ","))
+	}
+
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		if quality > 0 {
+			tags = append(tags, weighted{tag: tag, quality: quality})
+		}
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	values := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, tag.tag)
+	}
+	return collect(values)
+}
+
+func collect(tags []string) []string {
+	var languages []string
+	for _, tag := range tags {
+		primary, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+		primary = strings.ToLower(primary)
+		if primary == "" || primary == "*" || slices.Contains(languages, primary) {
+			continue
+		}
+		languages = append(languages, primary)
+	}
+	return languages
+}
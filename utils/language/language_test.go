@@ -0,0 +1,37 @@
+package language
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type preferredTest struct {
+	name              string
+	query             string
+	acceptLanguage    string
+	expectedLanguages []string
+}
+
+var preferredTests = []preferredTest{
+	{"No preferences", "", "", nil},
+	{"Parameter lang", "lang=EN", "ru", []string{"en"}},
+	{"Parameter lang with fallbacks", "lang=de,en-GB", "", []string{"de", "en"}},
+	{"Accept-Language ordered by quality", "", "en;q=0.5, ru-RU, de;q=0.8", []string{"ru", "de", "en"}},
+	{"Accept-Language with wildcard and zero quality", "", "fr;q=0, *, en-US, en;q=0.9", []string{"en"}},
+	{"Accept-Language with invalid quality", "", "fr;q=high, ru", []string{"ru"}},
+}
+
+func TestPreferred(t *testing.T) {
+	for _, test := range preferredTests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/films?"+test.query, nil)
+			if test.acceptLanguage != "" {
+				request.Header.Set("Accept-Language", test.acceptLanguage)
+			}
+			assert.Equal(t, test.expectedLanguages, Preferred(request))
+		})
+	}
+}
@@ -0,0 +1,486 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+	"vk-intern_test-case/utils/apperror"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+var (
+	ErrUnsupportedContentType = apperror.New(apperror.KindUnsupportedMediaType,
+		"unsupported_patch_content_type", "Unsupported patch content type")
+	ErrInvalidPatch  = apperror.BadRequest("invalid_patch", "Invalid patch document")
+	ErrTestFailed    = apperror.Conflict("patch_test_failed", "Patch test operation failed")
+	ErrInvalidResult = apperror.Validation("invalid_patch_result", "Patched document does not match the resource", nil)
+)
+
+// Apply applies patch to doc. Patch format is chosen by content type:
+// JSON Patch (RFC 6902) for application/json-patch+json,
+// JSON Merge Patch (RFC 7396) for application/merge-patch+json and application/json.
+func Apply(contentType string, doc, patch []byte) ([]byte, error) {
+	mediaType := ""
+	if contentType != "" {
+		var err error
+		mediaType, _, err = mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, ErrUnsupportedContentType
+		}
+	}
+
+	switch mediaType {
+	case JSONPatchContentType:
+		return JSONPatch(doc, patch)
+	case MergePatchContentType, "application/json", "":
+		return MergePatch(doc, patch)
+	}
+	return nil, ErrUnsupportedContentType
+}
+
+// ApplyTo marshals original, applies patch to it and decodes the result into target.
+// Fields of the result unknown to target are not allowed.
+func ApplyTo(contentType string, original any, patch []byte, target any) error {
+	doc, err := json.Marshal(original)
+	if err != nil {
+		return err
+	}
+
+	patched, err := Apply(contentType, doc, patch)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	err = decoder.Decode(target)
+	if err != nil {
+		return ErrInvalidResult.WithMessage("Patched document does not match the resource: " + err.Error())
+	}
+	return nil
+}
+
+// ChangedFields returns values of after's fields which differ from before's, keyed by json name.
+// before and after must be pointers to structs of the same type.
+func ChangedFields(before, after any) map[string]any {
+	changed := map[string]any{}
+	collectChangedFields(reflect.ValueOf(before).Elem(), reflect.ValueOf(after).Elem(), changed)
+	return changed
+}
+
+func collectChangedFields(before, after reflect.Value, changed map[string]any) {
+	for i := 0; i < before.NumField(); i++ {
+		field := before.Type().Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectChangedFields(before.Field(i), after.Field(i), changed)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		if !equalFields(before.Field(i), after.Field(i)) {
+			changed[name] = after.Field(i).Interface()
+		}
+	}
+}
+
+// equalFields compares field values, treating nil and empty slices and maps as equal:
+// empty array columns are read as {}, but come back from a patch as nil because of omitempty
+func equalFields(before, after reflect.Value) bool {
+	switch before.Kind() {
+	case reflect.Slice, reflect.Map:
+		if before.Len() == 0 && after.Len() == 0 {
+			return true
+		}
+	}
+	return reflect.DeepEqual(before.Interface(), after.Interface())
+}
+
+// MergePatch applies JSON Merge Patch (RFC 7396) to doc
+func MergePatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	patchValue, err := decode(patch)
+	if err != nil {
+		return nil, ErrInvalidPatch.WithMessage("Invalid patch document: " + err.Error())
+	}
+
+	return json.Marshal(mergeValue(target, patchValue))
+}
+
+func mergeValue(target, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = map[string]any{}
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergeValue(targetObject[key], value)
+	}
+	return targetObject
+}
+
+type operation struct {
+	Op    string          `json:"op"`
+	Path  *string         `json:"path"`
+	From  *string         `json:"from"`
+	Value json.RawMessage `json:"value"`
+}
+
+// JSONPatch applies JSON Patch (RFC 6902) to doc
+func JSONPatch(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var operations []operation
+	err = json.Unmarshal(patch, &operations)
+	if err != nil {
+		return nil, ErrInvalidPatch.WithMessage("Invalid patch document: " + err.Error())
+	}
+
+	for index, op := range operations {
+		target, err = applyOperation(target, op)
+		if err != nil {
+			if errors.Is(err, ErrTestFailed) {
+				return nil, ErrTestFailed.WithMessage(fmt.Sprintf("Patch test operation %d failed", index))
+			}
+			return nil, ErrInvalidPatch.WithMessage(fmt.Sprintf("Invalid patch operation %d: %s", index, err.Error()))
+		}
+	}
+
+	return json.Marshal(target)
+}
+
+func applyOperation(doc any, op operation) (any, error) {
+	if op.Path == nil {
+		return nil, errors.New("missing path")
+	}
+	path, err := parsePointer(*op.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch op.Op {
+	case "add", "replace", "test":
+		if op.Value == nil {
+			return nil, errors.New("missing value")
+		}
+		value, err := decode(op.Value)
+		if err != nil {
+			return nil, err
+		}
+		switch op.Op {
+		case "add":
+			return add(doc, path, value)
+		case "replace":
+			return replace(doc, path, value)
+		}
+		current, err := get(doc, path)
+		if err != nil {
+			return nil, err
+		}
+		if !equal(current, value) {
+			return nil, ErrTestFailed
+		}
+		return doc, nil
+	case "remove":
+		return remove(doc, path)
+	case "move", "copy":
+		if op.From == nil {
+			return nil, errors.New("missing from")
+		}
+		from, err := parsePointer(*op.From)
+		if err != nil {
+			return nil, err
+		}
+		value, err := get(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		if op.Op == "copy" {
+			value, err = deepCopy(value)
+			if err != nil {
+				return nil, err
+			}
+			return add(doc, path, value)
+		}
+		if isPrefix(from, path) && len(from) < len(path) {
+			return nil, errors.New("cannot move a value into its own child")
+		}
+		doc, err = remove(doc, from)
+		if err != nil {
+			return nil, err
+		}
+		return add(doc, path, value)
+	}
+	return nil, fmt.Errorf("unknown operation %q", op.Op)
+}
+
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid pointer %q", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func isPrefix(prefix, path []string) bool {
+	if len(prefix) > len(path) {
+		return false
+	}
+	for i := range prefix {
+		if prefix[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func arrayIndex(token string, length int, allowEnd bool) (int, error) {
+	if allowEnd && token == "-" {
+		return length, nil
+	}
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+
+	maxIndex := length - 1
+	if allowEnd {
+		maxIndex = length
+	}
+	if index > maxIndex {
+		return 0, fmt.Errorf("array index %d out of range", index)
+	}
+	return index, nil
+}
+
+func get(doc any, path []string) (any, error) {
+	current := doc
+	for _, token := range path {
+		switch container := current.(type) {
+		case map[string]any:
+			value, ok := container[token]
+			if !ok {
+				return nil, fmt.Errorf("path member %q not found", token)
+			}
+			current = value
+		case []any:
+			index, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			current = container[index]
+		default:
+			return nil, fmt.Errorf("path member %q not found", token)
+		}
+	}
+	return current, nil
+}
+
+// modify walks to the container holding the last path token and replaces it with the result of change
+func modify(doc any, path []string, change func(container any, token string) (any, error)) (any, error) {
+	if len(path) == 1 {
+		return change(doc, path[0])
+	}
+
+	switch container := doc.(type) {
+	case map[string]any:
+		child, ok := container[path[0]]
+		if !ok {
+			return nil, fmt.Errorf("path member %q not found", path[0])
+		}
+		newChild, err := modify(child, path[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		container[path[0]] = newChild
+		return container, nil
+	case []any:
+		index, err := arrayIndex(path[0], len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		newChild, err := modify(container[index], path[1:], change)
+		if err != nil {
+			return nil, err
+		}
+		container[index] = newChild
+		return container, nil
+	}
+	return nil, fmt.Errorf("path member %q not found", path[0])
+}
+
+func add(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return modify(doc, path, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			container[token] = value
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("path member %q not found", token)
+	})
+}
+
+func remove(doc any, path []string) (any, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot remove the whole document")
+	}
+
+	return modify(doc, path, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("path member %q not found", token)
+			}
+			delete(container, token)
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			return append(container[:index], container[index+1:]...), nil
+		}
+		return nil, fmt.Errorf("path member %q not found", token)
+	})
+}
+
+func replace(doc any, path []string, value any) (any, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+
+	return modify(doc, path, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			if _, ok := container[token]; !ok {
+				return nil, fmt.Errorf("path member %q not found", token)
+			}
+			container[token] = value
+			return container, nil
+		case []any:
+			index, err := arrayIndex(token, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			container[index] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("path member %q not found", token)
+	})
+}
+
+func decode(data []byte) (any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	err := decoder.Decode(&value)
+	if err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return value, nil
+}
+
+func deepCopy(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return decode(data)
+}
+
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		bNumber, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		aFloat, aErr := a.Float64()
+		bFloat, bErr := bNumber.Float64()
+		return aErr == nil && bErr == nil && aFloat == bFloat
+	case map[string]any:
+		bObject, ok := b.(map[string]any)
+		if !ok || len(a) != len(bObject) {
+			return false
+		}
+		for key, value := range a {
+			bValue, ok := bObject[key]
+			if !ok || !equal(value, bValue) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		bArray, ok := b.([]any)
+		if !ok || len(a) != len(bArray) {
+			return false
+		}
+		for i := range a {
+			if !equal(a[i], bArray[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return a == b
+}
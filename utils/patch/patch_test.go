@@ -0,0 +1,141 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type patchTest struct {
+	name         string
+	contentType  string
+	doc          string
+	patch        string
+	expectedJSON string
+	expectedErr  error
+}
+
+var patchTests = []patchTest{
+	{
+		"Merge patch replaces and removes fields",
+		MergePatchContentType,
+		`{"title": "Titanic", "description": "Cool film", "rating": 8}`,
+		`{"rating": 9, "description": null}`,
+		`{"title": "Titanic", "rating": 9}`,
+		nil,
+	},
+	{
+		"Merge patch with application/json content type",
+		"application/json; charset=utf-8",
+		`{"title": "Titanic", "tags": {"a": 1, "b": 2}}`,
+		`{"tags": {"b": null, "c": 3}}`,
+		`{"title": "Titanic", "tags": {"a": 1, "c": 3}}`,
+		nil,
+	},
+	{
+		"JSON patch operations",
+		JSONPatchContentType,
+		`{"title": "Titanic", "rating": 8, "actors": ["Leo", "Kate"]}`,
+		`[
+			{"op": "test", "path": "/rating", "value": 8},
+			{"op": "replace", "path": "/rating", "value": 9},
+			{"op": "add", "path": "/actors/1", "value": "Billy"},
+			{"op": "remove", "path": "/actors/0"},
+			{"op": "copy", "from": "/title", "path": "/original_title"},
+			{"op": "move", "from": "/actors", "path": "/cast"}
+		]`,
+		`{"title": "Titanic", "original_title": "Titanic", "rating": 9, "cast": ["Billy", "Kate"]}`,
+		nil,
+	},
+	{
+		"JSON patch with escaped pointer and append",
+		JSONPatchContentType,
+		`{"a/b": [1], "m~n": 0}`,
+		`[
+			{"op": "add", "path": "/a~1b/-", "value": 2},
+			{"op": "replace", "path": "/m~0n", "value": 1}
+		]`,
+		`{"a/b": [1, 2], "m~n": 1}`,
+		nil,
+	},
+	{
+		"JSON patch failed test operation",
+		JSONPatchContentType,
+		`{"rating": 8}`,
+		`[{"op": "test", "path": "/rating", "value": 7}, {"op": "replace", "path": "/rating", "value": 9}]`,
+		"",
+		ErrTestFailed,
+	},
+	{
+		"JSON patch replace of a missing field",
+		JSONPatchContentType,
+		`{"rating": 8}`,
+		`[{"op": "replace", "path": "/title", "value": "Titanic"}]`,
+		"",
+		ErrInvalidPatch,
+	},
+	{
+		"JSON patch with unknown operation",
+		JSONPatchContentType,
+		`{"rating": 8}`,
+		`[{"op": "increment", "path": "/rating", "value": 1}]`,
+		"",
+		ErrInvalidPatch,
+	},
+	{
+		"Unsupported content type",
+		"text/plain",
+		`{"rating": 8}`,
+		`rating=9`,
+		"",
+		ErrUnsupportedContentType,
+	},
+}
+
+func TestApply(t *testing.T) {
+	for _, test := range patchTests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := Apply(test.contentType, []byte(test.doc), []byte(test.patch))
+			if test.expectedErr != nil {
+				assert.ErrorIs(t, err, test.expectedErr)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.JSONEq(t, test.expectedJSON, string(result))
+		})
+	}
+}
+
+type embedded struct {
+	Title  string   `json:"title"`
+	Rating int      `json:"rating"`
+	Genres []string `json:"genres,omitempty"`
+}
+
+type patchTarget struct {
+	ID int `json:"id"`
+	embedded
+	Hidden string `json:"-"`
+}
+
+func TestChangedFields(t *testing.T) {
+	before := &patchTarget{ID: 1, embedded: embedded{Title: "Titanic", Rating: 8}, Hidden: "a"}
+	after := &patchTarget{ID: 1, embedded: embedded{Title: "Titanic", Rating: 9}, Hidden: "b"}
+
+	assert.Equal(t, map[string]any{"rating": 9}, ChangedFields(before, after))
+}
+
+func TestChangedFieldsTreatsNilAndEmptySlicesAsEqual(t *testing.T) {
+	before := &embedded{Title: "Titanic", Genres: []string{}}
+	after := &embedded{Title: "Titanic 2"}
+
+	assert.Equal(t, map[string]any{"title": "Titanic 2"}, ChangedFields(before, after))
+}
+
+func TestApplyToRejectsUnknownFields(t *testing.T) {
+	var target embedded
+	err := ApplyTo(MergePatchContentType, &embedded{Title: "Titanic"}, []byte(`{"budget": 100}`), &target)
+
+	assert.ErrorIs(t, err, ErrInvalidResult)
+}
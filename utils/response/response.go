@@ -2,10 +2,16 @@ package response
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+
+	log "github.com/sirupsen/logrus"
 )
 
+const ProblemContentType = "application/problem+json"
+
 func MakeJsonEncoder(w http.ResponseWriter) *json.Encoder {
 	w.Header().Set("Content-Type", "application/json")
 	jsonEnc := json.NewEncoder(w)
@@ -21,3 +27,48 @@ func WriteBasicResponse(w http.ResponseWriter, jsonEnc *json.Encoder, httpStatus
 	w.WriteHeader(httpStatus)
 	jsonEnc.Encode(&models.BasicResponse{Status: message})
 }
+
+// WriteError writes err as application/problem+json. Errors which are not
+// *apperror.Error are logged and reported as internal without any details
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Kind == apperror.KindInternal {
+		log.Error(err)
+		appErr = apperror.ErrInternal
+	} else {
+		log.Debug(err)
+	}
+
+	status := StatusCode(appErr.Kind)
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&models.Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   appErr.Message,
+		Instance: r.URL.Path,
+		Code:     appErr.Code,
+		Errors:   appErr.Fields,
+	})
+}
+
+func StatusCode(kind apperror.Kind) int {
+	switch kind {
+	case apperror.KindBadRequest:
+		return http.StatusBadRequest
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict:
+		return http.StatusConflict
+	case apperror.KindValidation:
+		return http.StatusUnprocessableEntity
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusInternalServerError
+}
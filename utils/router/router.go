@@ -0,0 +1,27 @@
+package router
+
+import (
+	"net/http"
+	"strings"
+)
+
+// WithSubresources routes requests like prefix{id}/{name}/... to the handler of
+// sub-resource name and all other requests to base. http.ServeMux does not
+// support path parameters, so nested resources are dispatched here
+func WithSubresources(base http.Handler, prefix string, subresources map[string]http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		segments := PathSegments(r.URL.Path, prefix)
+		if len(segments) > 1 {
+			if handler, ok := subresources[segments[1]]; ok {
+				handler.ServeHTTP(w, r)
+				return
+			}
+		}
+		base.ServeHTTP(w, r)
+	})
+}
+
+// PathSegments splits the part of path after prefix into segments
+func PathSegments(path, prefix string) []string {
+	return strings.Split(strings.Trim(strings.TrimPrefix(path, prefix), "/"), "/")
+}
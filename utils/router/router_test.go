@@ -0,0 +1,50 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func namedHandler(name string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(name))
+	})
+}
+
+type withSubresourcesTest struct {
+	name            string
+	path            string
+	expectedHandler string
+}
+
+var withSubresourcesTests = []withSubresourcesTest{
+	{"Resource collection", "/films", "base"},
+	{"Single resource", "/films/1", "base"},
+	{"Sub-resource collection", "/films/1/crew", "crew"},
+	{"Single sub-resource", "/films/1/crew/3", "crew"},
+	{"Unknown sub-resource", "/films/1/unknown", "base"},
+}
+
+func TestWithSubresources(t *testing.T) {
+	handler := WithSubresources(namedHandler("base"), "/films/", map[string]http.Handler{
+		"crew": namedHandler("crew"),
+	})
+	for _, test := range withSubresourcesTests {
+		t.Run(test.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodGet, test.path, nil)
+
+			handler.ServeHTTP(responseRecorder, request)
+
+			assert.Equal(t, test.expectedHandler, responseRecorder.Body.String())
+		})
+	}
+}
+
+func TestPathSegments(t *testing.T) {
+	assert.Equal(t, []string{"1", "crew", "3"}, PathSegments("/films/1/crew/3/", "/films/"))
+	assert.Equal(t, []string{"1"}, PathSegments("/films/1", "/films/"))
+}
@@ -0,0 +1,48 @@
+package slug
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MaxLength limits length of generated slugs
+const MaxLength = 80
+
+// Транслитерация русских букв для адресов коллекций
+var cyrillic = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z", 'и': "i",
+	'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o", 'п': "p", 'р': "r", 'с': "s", 'т': "t",
+	'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts", 'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "",
+	'э': "e", 'ю': "yu", 'я': "ya",
+}
+
+// Make returns lowercase latin slug of the name: Russian letters are transliterated,
+// other characters except latin letters and digits separate words with a hyphen.
+// Returns empty string if nothing is left
+func Make(name string) string {
+	var builder strings.Builder
+	hyphen := false
+	for _, char := range strings.ToLower(name) {
+		var part string
+		if latin, ok := cyrillic[char]; ok {
+			part = latin
+		} else if char < unicode.MaxASCII && (unicode.IsLetter(char) || unicode.IsDigit(char)) {
+			part = string(char)
+		} else {
+			hyphen = builder.Len() > 0
+			continue
+		}
+		if part == "" {
+			continue
+		}
+		if builder.Len()+len(part)+1 > MaxLength {
+			break
+		}
+		if hyphen {
+			builder.WriteByte('-')
+			hyphen = false
+		}
+		builder.WriteString(part)
+	}
+	return builder.String()
+}
@@ -0,0 +1,25 @@
+package slug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMake(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Лучшие советские комедии", "luchshie-sovetskie-komedii"},
+		{"  Best of 2024!  ", "best-of-2024"},
+		{"Ёлки и щенки", "elki-i-shchenki"},
+		{"Подъезд", "podezd"},
+		{"!!!", ""},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, Make(test.name))
+	}
+	assert.LessOrEqual(t, len(Make(strings.Repeat("слово ", 50))), MaxLength)
+}
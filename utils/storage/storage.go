@@ -0,0 +1,73 @@
+package storage
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Storage keeps uploaded files. Names are slash-separated paths like films/1/poster/original.jpg
+type Storage interface {
+	Save(name string, data []byte) error
+	// DeleteAll removes the file or directory name with everything inside it
+	DeleteAll(name string) error
+	// List returns names of the entries directly inside directory name, empty if it does not exist
+	List(name string) ([]string, error)
+	URL(name string) string
+}
+
+// LocalStorage keeps files in a directory on the local filesystem,
+// the directory is expected to be served at baseURL
+type LocalStorage struct {
+	root    string
+	baseURL string
+}
+
+func NewLocalStorage(root, baseURL string) *LocalStorage {
+	return &LocalStorage{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (lS *LocalStorage) Save(name string, data []byte) error {
+	filePath := lS.filePath(name)
+	err := os.MkdirAll(filepath.Dir(filePath), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, data, 0o644)
+}
+
+func (lS *LocalStorage) DeleteAll(name string) error {
+	return os.RemoveAll(lS.filePath(name))
+}
+
+func (lS *LocalStorage) List(name string) ([]string, error) {
+	entries, err := os.ReadDir(lS.filePath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names, nil
+}
+
+func (lS *LocalStorage) URL(name string) string {
+	return lS.baseURL + "/" + cleanName(name)
+}
+
+func (lS *LocalStorage) filePath(name string) string {
+	return filepath.Join(lS.root, filepath.FromSlash(cleanName(name)))
+}
+
+// cleanName keeps names inside the storage root
+func cleanName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStorage(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStorage(root, "/media/")
+
+	err := store.Save("films/1/poster/original.jpg", []byte("poster"))
+	assert.Nil(t, err)
+	data, err := os.ReadFile(filepath.Join(root, "films", "1", "poster", "original.jpg"))
+	assert.Nil(t, err)
+	assert.Equal(t, "poster", string(data))
+	assert.Equal(t, "/media/films/1/poster/original.jpg", store.URL("films/1/poster/original.jpg"))
+
+	names, err := store.List("films/1/poster")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"original.jpg"}, names)
+
+	err = store.DeleteAll("films/1")
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(root, "films", "1"))
+	assert.True(t, os.IsNotExist(err))
+	names, err = store.List("films/1/poster")
+	assert.Nil(t, err)
+	assert.Empty(t, names)
+}
+
+func TestLocalStorageKeepsFilesInsideRoot(t *testing.T) {
+	root := t.TempDir()
+	store := NewLocalStorage(filepath.Join(root, "media"), "/media")
+
+	err := store.Save("../../outside.jpg", []byte("image"))
+	assert.Nil(t, err)
+	_, err = os.Stat(filepath.Join(root, "media", "outside.jpg"))
+	assert.Nil(t, err)
+	assert.Equal(t, "/media/outside.jpg", store.URL("../../outside.jpg"))
+}
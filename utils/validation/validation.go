@@ -0,0 +1,400 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"vk-intern_test-case/models"
+	"vk-intern_test-case/utils/apperror"
+	"vk-intern_test-case/utils/slug"
+)
+
+// Коды ошибок валидации
+const (
+	CodeRequired      = "required"
+	CodeTooLong       = "too_long"
+	CodeOutOfRange    = "out_of_range"
+	CodeInvalidFormat = "invalid_format"
+	CodeInFuture      = "in_future"
+	CodeNotAllowed    = "not_allowed"
+)
+
+const (
+	MaxTitleLength         = 150
+	MaxDescriptionLength   = 1000
+	MinRating              = 0
+	MaxRating              = 10
+	MaxNameLength          = 100
+	MaxGenreNameLength     = 50
+	MaxFranchiseNameLength = 150
+	MaxRuntime             = 10000
+	MaxAwardNameLength     = 150
+	MaxBirthplaceLength    = 200
+	MaxBiographyLength     = 10000
+	MaxAliases             = 20
+	MinReviewRating        = 1
+	MaxReviewLength        = 5000
+	MaxReasonLength        = 500
+	MaxCollectionFilms     = 1000
+	// Первый год, за который можно добавить церемонию премии
+	MinCeremonyYear = 1900
+	// Насколько лет вперёд может быть назначен релиз фильма
+	MaxReleaseYearsAhead = 10
+)
+
+var ErrValidationFailed = apperror.Validation("validation_failed", "Request validation failed", nil)
+
+var AllowedGenders = []string{"Мужской", "Женский"}
+
+var AllowedCrewRoles = []string{"director", "writer", "composer", "producer"}
+
+var AllowedModerationActions = []string{"approve", "reject", "hide"}
+
+var AllowedFilmRelations = []string{"sequel", "prequel", "remake", "spin_off"}
+
+// Возрастные рейтинги по возрастанию, на порядке основан фильтр max_age_rating
+var AllowedAgeRatings = []string{"0+", "6+", "12+", "16+", "18+"}
+
+var (
+	countryCodePattern  = regexp.MustCompile(`^[A-Z]{2}$`)
+	languageCodePattern = regexp.MustCompile(`^[a-z]{2}$`)
+	currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+	imdbPersonIDPattern = regexp.MustCompile(`^nm[0-9]{7,8}$`)
+	slugPattern         = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+)
+
+// Errors collects field errors of a single request
+type Errors []models.FieldError
+
+func (e *Errors) Add(field, code, message string) {
+	*e = append(*e, models.FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err returns validation error with collected field errors or nil if there are none
+func (e Errors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return ErrValidationFailed.WithFields(e)
+}
+
+func ValidateFilm(film *models.FilmRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "title", film.Title, true, MaxTitleLength)
+	validateLength(&errs, "description", film.Description, false, MaxDescriptionLength)
+	if film.Rating < MinRating || film.Rating > MaxRating {
+		errs.Add("rating", CodeOutOfRange, fmt.Sprintf("rating must be between %d and %d", MinRating, MaxRating))
+	}
+
+	releaseDate, ok := validateDate(&errs, "release_date", film.ReleaseDate)
+	if ok && releaseDate.After(time.Now().AddDate(MaxReleaseYearsAhead, 0, 0)) {
+		errs.Add("release_date", CodeInFuture,
+			fmt.Sprintf("release_date must be not later than %d years from now", MaxReleaseYearsAhead))
+	}
+
+	for index, genre := range film.Genres {
+		validateLength(&errs, fmt.Sprintf("genres[%d]", index), genre, true, MaxGenreNameLength)
+	}
+
+	if film.Runtime < 0 || film.Runtime > MaxRuntime {
+		errs.Add("runtime", CodeOutOfRange, fmt.Sprintf("runtime must be between 0 and %d", MaxRuntime))
+	}
+	for index, country := range film.Countries {
+		validateCode(&errs, fmt.Sprintf("countries[%d]", index), country, countryCodePattern,
+			"ISO 3166-1 alpha-2 country code")
+	}
+	if film.OriginalLanguage != "" {
+		validateCode(&errs, "original_language", film.OriginalLanguage, languageCodePattern,
+			"ISO 639-1 language code")
+	}
+	for index, language := range film.SpokenLanguages {
+		validateCode(&errs, fmt.Sprintf("spoken_languages[%d]", index), language, languageCodePattern,
+			"ISO 639-1 language code")
+	}
+	if film.AgeRating != "" && !isAllowed(film.AgeRating, AllowedAgeRatings) {
+		errs.Add("age_rating", CodeNotAllowed, "age_rating must be one of: "+strings.Join(AllowedAgeRatings, ", "))
+	}
+
+	if film.Budget < 0 {
+		errs.Add("budget", CodeOutOfRange, "budget must not be negative")
+	}
+	if film.BoxOffice < 0 {
+		errs.Add("box_office", CodeOutOfRange, "box_office must not be negative")
+	}
+	if film.Currency == "" {
+		if film.Budget > 0 || film.BoxOffice > 0 {
+			errs.Add("currency", CodeRequired, "currency is required when budget or box_office is set")
+		}
+	} else {
+		validateCode(&errs, "currency", film.Currency, currencyCodePattern, "ISO 4217 currency code")
+	}
+	return errs
+}
+
+func ValidateFilmTranslation(translation *models.FilmTranslation) Errors {
+	errs := Errors{}
+	validateCode(&errs, "language", translation.Language, languageCodePattern, "ISO 639-1 language code")
+	validateLength(&errs, "title", translation.Title, true, MaxTitleLength)
+	validateLength(&errs, "description", translation.Description, false, MaxDescriptionLength)
+	return errs
+}
+
+func ValidateFilmWithActors(film *models.FilmWithActors) Errors {
+	errs := ValidateFilm(&film.FilmRequest)
+	for index, actor := range film.Actors {
+		validateLength(&errs, fmt.Sprintf("actors[%d]", index), actor, true, MaxNameLength)
+	}
+	return errs
+}
+
+func ValidateActor(actor *models.ActorRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "name", actor.Name, true, MaxNameLength)
+
+	if actor.Gender == "" {
+		errs.Add("gender", CodeRequired, "gender is required")
+	} else if !isAllowed(actor.Gender, AllowedGenders) {
+		errs.Add("gender", CodeNotAllowed, "gender must be one of: "+strings.Join(AllowedGenders, ", "))
+	}
+
+	dateOfBirth, ok := validateDate(&errs, "date_of_birth", actor.DateOfBirth)
+	if ok && dateOfBirth.After(time.Now()) {
+		errs.Add("date_of_birth", CodeInFuture, "date_of_birth must not be in the future")
+	}
+
+	if actor.DateOfDeath != "" {
+		dateOfDeath, deathOk := validateDate(&errs, "date_of_death", actor.DateOfDeath)
+		if deathOk && dateOfDeath.After(time.Now()) {
+			errs.Add("date_of_death", CodeInFuture, "date_of_death must not be in the future")
+		} else if deathOk && ok && dateOfDeath.Before(dateOfBirth) {
+			errs.Add("date_of_death", CodeOutOfRange, "date_of_death must not be before date_of_birth")
+		}
+	}
+
+	validateLength(&errs, "birthplace", actor.Birthplace, false, MaxBirthplaceLength)
+	validateLength(&errs, "biography", actor.Biography, false, MaxBiographyLength)
+	if len(actor.Aliases) > MaxAliases {
+		errs.Add("aliases", CodeTooLong, fmt.Sprintf("aliases must contain at most %d names", MaxAliases))
+	}
+	for index, alias := range actor.Aliases {
+		validateLength(&errs, fmt.Sprintf("aliases[%d]", index), alias, true, MaxNameLength)
+	}
+	if actor.ImdbID != "" {
+		validateCode(&errs, "imdb_id", actor.ImdbID, imdbPersonIDPattern, "IMDb person id like nm0000138")
+	}
+	if actor.KinopoiskID < 0 {
+		errs.Add("kinopoisk_id", CodeOutOfRange, "kinopoisk_id must be positive")
+	}
+	return errs
+}
+
+func ValidateCrewRequest(crewRequest *models.CrewRequest) Errors {
+	errs := Errors{}
+	if crewRequest.PersonID <= 0 {
+		errs.Add("person_id", CodeRequired, "person_id is required")
+	}
+	if crewRequest.Role == "" {
+		errs.Add("role", CodeRequired, "role is required")
+	} else if !isAllowed(crewRequest.Role, AllowedCrewRoles) {
+		errs.Add("role", CodeNotAllowed, "role must be one of: "+strings.Join(AllowedCrewRoles, ", "))
+	}
+	return errs
+}
+
+func ValidateGenre(genre *models.GenreRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "name", genre.Name, true, MaxGenreNameLength)
+	return errs
+}
+
+func ValidateFranchise(franchise *models.FranchiseRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "name", franchise.Name, true, MaxFranchiseNameLength)
+	validateLength(&errs, "description", franchise.Description, false, MaxDescriptionLength)
+	return errs
+}
+
+func ValidateFranchiseFilm(franchiseFilm *models.FranchiseFilmRequest) Errors {
+	errs := Errors{}
+	if franchiseFilm.FilmID <= 0 {
+		errs.Add("film_id", CodeRequired, "film_id is required")
+	}
+	return errs
+}
+
+// ValidateFilmRelation проверяет связь фильма filmID с другим фильмом
+func ValidateFilmRelation(filmID int, relation *models.FilmRelationRequest) Errors {
+	errs := Errors{}
+	if relation.FilmID <= 0 {
+		errs.Add("film_id", CodeRequired, "film_id is required")
+	} else if relation.FilmID == filmID {
+		errs.Add("film_id", CodeNotAllowed, "film can not be related to itself")
+	}
+	if relation.Type == "" {
+		errs.Add("type", CodeRequired, "type is required")
+	} else if !isAllowed(relation.Type, AllowedFilmRelations) {
+		errs.Add("type", CodeNotAllowed, "type must be one of: "+strings.Join(AllowedFilmRelations, ", "))
+	}
+	return errs
+}
+
+func ValidateAward(award *models.AwardRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "name", award.Name, true, MaxAwardNameLength)
+	return errs
+}
+
+func ValidateAwardCeremony(ceremony *models.AwardCeremonyRequest) Errors {
+	errs := Errors{}
+	maxYear := time.Now().Year() + 1
+	if ceremony.Year < MinCeremonyYear || ceremony.Year > maxYear {
+		errs.Add("year", CodeOutOfRange, fmt.Sprintf("year must be between %d and %d", MinCeremonyYear, maxYear))
+	}
+	return errs
+}
+
+func ValidateAwardCategory(category *models.AwardCategoryRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "name", category.Name, true, MaxAwardNameLength)
+	return errs
+}
+
+func ValidateNomination(nomination *models.NominationRequest) Errors {
+	errs := Errors{}
+	if nomination.CeremonyID <= 0 {
+		errs.Add("ceremony_id", CodeRequired, "ceremony_id is required")
+	}
+	if nomination.CategoryID <= 0 {
+		errs.Add("category_id", CodeRequired, "category_id is required")
+	}
+	if nomination.FilmID <= 0 {
+		errs.Add("film_id", CodeRequired, "film_id is required")
+	}
+	if nomination.PersonID < 0 {
+		errs.Add("person_id", CodeOutOfRange, "person_id must be positive")
+	}
+	return errs
+}
+
+func ValidateReview(review *models.ReviewRequest) Errors {
+	errs := Errors{}
+	if review.Rating < MinReviewRating || review.Rating > MaxRating {
+		errs.Add("rating", CodeOutOfRange, fmt.Sprintf("rating must be between %d and %d", MinReviewRating, MaxRating))
+	}
+	validateLength(&errs, "text", review.Text, false, MaxReviewLength)
+	return errs
+}
+
+func ValidateReviewFlag(flag *models.ReviewFlagRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "reason", flag.Reason, false, MaxReasonLength)
+	return errs
+}
+
+func ValidateReviewModeration(moderation *models.ReviewModerationRequest) Errors {
+	errs := Errors{}
+	if moderation.Action == "" {
+		errs.Add("action", CodeRequired, "action is required")
+	} else if !isAllowed(moderation.Action, AllowedModerationActions) {
+		errs.Add("action", CodeNotAllowed, "action must be one of: "+strings.Join(AllowedModerationActions, ", "))
+	}
+	validateLength(&errs, "reason", moderation.Reason, moderation.Action == "reject", MaxReasonLength)
+	return errs
+}
+
+// ValidateUserListRequest checks request to add a film to a personal list. watched_at
+// is accepted only by the watched list
+func ValidateUserListRequest(watchedList bool, request *models.UserListRequest) Errors {
+	errs := Errors{}
+	if request.WatchedAt == "" {
+		return errs
+	}
+	if !watchedList {
+		errs.Add("watched_at", CodeNotAllowed, "watched_at is allowed only in the watched list")
+		return errs
+	}
+	watchedAt, ok := validateDate(&errs, "watched_at", request.WatchedAt)
+	if ok && watchedAt.After(time.Now()) {
+		errs.Add("watched_at", CodeInFuture, "watched_at must not be in the future")
+	}
+	return errs
+}
+
+func ValidateCollection(collection *models.CollectionRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "name", collection.Name, true, MaxTitleLength)
+	validateLength(&errs, "description", collection.Description, false, MaxDescriptionLength)
+	if collection.Slug != "" {
+		validateLength(&errs, "slug", collection.Slug, false, slug.MaxLength)
+		if !slugPattern.MatchString(collection.Slug) {
+			errs.Add("slug", CodeInvalidFormat, "slug must contain only lowercase latin letters, digits and single hyphens")
+		}
+	}
+	return errs
+}
+
+func ValidateCollectionFilm(collectionFilm *models.CollectionFilmRequest) Errors {
+	errs := Errors{}
+	validateLength(&errs, "note", collectionFilm.Note, false, MaxDescriptionLength)
+	return errs
+}
+
+func ValidateCollectionOrder(order *models.CollectionOrderRequest) Errors {
+	errs := Errors{}
+	if len(order.FilmIDs) == 0 {
+		errs.Add("film_ids", CodeRequired, "film_ids is required")
+		return errs
+	}
+	if len(order.FilmIDs) > MaxCollectionFilms {
+		errs.Add("film_ids", CodeTooLong, fmt.Sprintf("film_ids must contain at most %d films", MaxCollectionFilms))
+		return errs
+	}
+	seen := make(map[int]bool, len(order.FilmIDs))
+	for index, filmID := range order.FilmIDs {
+		if seen[filmID] {
+			errs.Add(fmt.Sprintf("film_ids[%d]", index), CodeNotAllowed, "film_ids must not contain duplicates")
+		}
+		seen[filmID] = true
+	}
+	return errs
+}
+
+func validateLength(errs *Errors, field, value string, required bool, maxLength int) {
+	if required && strings.TrimSpace(value) == "" {
+		errs.Add(field, CodeRequired, field+" is required")
+		return
+	}
+	if utf8.RuneCountInString(value) > maxLength {
+		errs.Add(field, CodeTooLong, fmt.Sprintf("%s must be at most %d characters long", field, maxLength))
+	}
+}
+
+func validateDate(errs *Errors, field, value string) (time.Time, bool) {
+	if value == "" {
+		errs.Add(field, CodeRequired, field+" is required")
+		return time.Time{}, false
+	}
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		errs.Add(field, CodeInvalidFormat, field+" must be a date in YYYY-MM-DD format")
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+func validateCode(errs *Errors, field, value string, pattern *regexp.Regexp, description string) {
+	if !pattern.MatchString(value) {
+		errs.Add(field, CodeInvalidFormat, field+" must be an "+description)
+	}
+}
+
+func isAllowed(value string, allowed []string) bool {
+	for _, allowedValue := range allowed {
+		if value == allowedValue {
+			return true
+		}
+	}
+	return false
+}
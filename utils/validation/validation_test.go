@@ -0,0 +1,285 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"vk-intern_test-case/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type validateFilmTest struct {
+	name          string
+	film          models.FilmRequest
+	expectedCodes map[string]string
+}
+
+var validateFilmTests = []validateFilmTest{
+	{
+		"Valid film",
+		models.FilmRequest{Title: "Titanic", Description: "Cool film", ReleaseDate: "1997-12-19", Rating: 8},
+		map[string]string{},
+	},
+	{
+		"Too long title and description",
+		models.FilmRequest{
+			Title:       strings.Repeat("т", MaxTitleLength+1),
+			Description: strings.Repeat("d", MaxDescriptionLength+1),
+			ReleaseDate: "1997-12-19",
+			Rating:      8,
+		},
+		map[string]string{"title": CodeTooLong, "description": CodeTooLong},
+	},
+	{
+		"Blank title, negative rating and far future release",
+		models.FilmRequest{
+			Title:       "   ",
+			ReleaseDate: time.Now().AddDate(MaxReleaseYearsAhead+1, 0, 0).Format(time.DateOnly),
+			Rating:      -1,
+		},
+		map[string]string{"title": CodeRequired, "rating": CodeOutOfRange, "release_date": CodeInFuture},
+	},
+	{
+		"Missing release date",
+		models.FilmRequest{Title: "Titanic", Rating: 8},
+		map[string]string{"release_date": CodeRequired},
+	},
+	{
+		"Blank and too long genres",
+		models.FilmRequest{
+			Title:       "Titanic",
+			ReleaseDate: "1997-12-19",
+			Rating:      8,
+			Genres:      []string{"Драма", "", strings.Repeat("ж", MaxGenreNameLength+1)},
+		},
+		map[string]string{"genres[1]": CodeRequired, "genres[2]": CodeTooLong},
+	},
+	{
+		"Valid extended metadata",
+		models.FilmRequest{
+			Title:            "Titanic",
+			ReleaseDate:      "1997-12-19",
+			Rating:           8,
+			Runtime:          194,
+			Countries:        []string{"US"},
+			OriginalLanguage: "en",
+			SpokenLanguages:  []string{"en", "fr"},
+			AgeRating:        "12+",
+			Budget:           200000000,
+			BoxOffice:        2264743305,
+			Currency:         "USD",
+		},
+		map[string]string{},
+	},
+	{
+		"Invalid extended metadata",
+		models.FilmRequest{
+			Title:            "Titanic",
+			ReleaseDate:      "1997-12-19",
+			Rating:           8,
+			Runtime:          -1,
+			Countries:        []string{"US", "usa"},
+			OriginalLanguage: "eng",
+			SpokenLanguages:  []string{"EN"},
+			AgeRating:        "13+",
+			Budget:           -1,
+			BoxOffice:        100,
+		},
+		map[string]string{
+			"runtime":             CodeOutOfRange,
+			"countries[1]":        CodeInvalidFormat,
+			"original_language":   CodeInvalidFormat,
+			"spoken_languages[0]": CodeInvalidFormat,
+			"age_rating":          CodeNotAllowed,
+			"budget":              CodeOutOfRange,
+			"currency":            CodeRequired,
+		},
+	},
+}
+
+func TestValidateFilm(t *testing.T) {
+	for _, test := range validateFilmTests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedCodes, codesByField(ValidateFilm(&test.film)))
+		})
+	}
+}
+
+type validateActorTest struct {
+	name          string
+	actor         models.ActorRequest
+	expectedCodes map[string]string
+}
+
+var validateActorTests = []validateActorTest{
+	{
+		"Valid actor",
+		models.ActorRequest{Name: "Марго Робби", Gender: "Женский", DateOfBirth: "1990-07-02"},
+		map[string]string{},
+	},
+	{
+		"Unknown gender and birth in future",
+		models.ActorRequest{
+			Name:        "Марго Робби",
+			Gender:      "female",
+			DateOfBirth: time.Now().AddDate(1, 0, 0).Format(time.DateOnly),
+		},
+		map[string]string{"gender": CodeNotAllowed, "date_of_birth": CodeInFuture},
+	},
+	{
+		"Empty actor",
+		models.ActorRequest{},
+		map[string]string{"name": CodeRequired, "gender": CodeRequired, "date_of_birth": CodeRequired},
+	},
+	{
+		"Valid actor with biography",
+		models.ActorRequest{
+			Name:        "Андрей Миронов",
+			Gender:      "Мужской",
+			DateOfBirth: "1941-03-07",
+			DateOfDeath: "1987-08-16",
+			Birthplace:  "Москва",
+			Aliases:     []string{"Andrei Mironov"},
+			ImdbID:      "nm0592379",
+			KinopoiskID: 40585,
+		},
+		map[string]string{},
+	},
+	{
+		"Death before birth, blank alias and invalid external ids",
+		models.ActorRequest{
+			Name:        "Андрей Миронов",
+			Gender:      "Мужской",
+			DateOfBirth: "1941-03-07",
+			DateOfDeath: "1940-01-01",
+			Aliases:     []string{" "},
+			ImdbID:      "tt0592379",
+			KinopoiskID: -1,
+		},
+		map[string]string{"date_of_death": CodeOutOfRange, "aliases[0]": CodeRequired,
+			"imdb_id": CodeInvalidFormat, "kinopoisk_id": CodeOutOfRange},
+	},
+}
+
+func TestValidateActor(t *testing.T) {
+	for _, test := range validateActorTests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedCodes, codesByField(ValidateActor(&test.actor)))
+		})
+	}
+}
+
+func TestValidateFilmWithActors(t *testing.T) {
+	film := &models.FilmWithActors{
+		Film: models.Film{
+			FilmRequest: models.FilmRequest{Title: "Titanic", ReleaseDate: "1997-12-19", Rating: 8},
+		},
+		Actors: []string{"Леонардо Ди Каприо", ""},
+	}
+
+	assert.Equal(t, map[string]string{"actors[1]": CodeRequired}, codesByField(ValidateFilmWithActors(film)))
+}
+
+func TestValidateCrewRequest(t *testing.T) {
+	assert.Equal(t, map[string]string{}, codesByField(ValidateCrewRequest(&models.CrewRequest{PersonID: 1, Role: "director"})))
+	assert.Equal(t, map[string]string{"person_id": CodeRequired, "role": CodeNotAllowed},
+		codesByField(ValidateCrewRequest(&models.CrewRequest{Role: "actor"})))
+}
+
+func codesByField(errs Errors) map[string]string {
+	codes := map[string]string{}
+	for _, fieldError := range errs {
+		codes[fieldError.Field] = fieldError.Code
+	}
+	return codes
+}
+
+func TestValidateFilmTranslation(t *testing.T) {
+	valid := &models.FilmTranslation{
+		Language:               "ru",
+		FilmTranslationRequest: models.FilmTranslationRequest{Title: "Титаник"},
+	}
+	assert.Empty(t, ValidateFilmTranslation(valid))
+
+	invalid := &models.FilmTranslation{
+		Language:               "rus",
+		FilmTranslationRequest: models.FilmTranslationRequest{Title: " "},
+	}
+	assert.Equal(t, map[string]string{"language": CodeInvalidFormat, "title": CodeRequired},
+		codesByField(ValidateFilmTranslation(invalid)))
+}
+
+func TestValidateFranchise(t *testing.T) {
+	assert.Empty(t, ValidateFranchise(&models.FranchiseRequest{Name: "Звёздные войны"}))
+	assert.Equal(t, map[string]string{"name": CodeRequired, "description": CodeTooLong},
+		codesByField(ValidateFranchise(&models.FranchiseRequest{Description: strings.Repeat("d", MaxDescriptionLength+1)})))
+}
+
+func TestValidateFilmRelation(t *testing.T) {
+	assert.Empty(t, ValidateFilmRelation(1, &models.FilmRelationRequest{FilmID: 2, Type: "sequel"}))
+	assert.Equal(t, map[string]string{"film_id": CodeNotAllowed, "type": CodeNotAllowed},
+		codesByField(ValidateFilmRelation(1, &models.FilmRelationRequest{FilmID: 1, Type: "reboot"})))
+	assert.Equal(t, map[string]string{"film_id": CodeRequired, "type": CodeRequired},
+		codesByField(ValidateFilmRelation(1, &models.FilmRelationRequest{})))
+}
+
+func TestValidateAwardCeremony(t *testing.T) {
+	assert.Empty(t, ValidateAwardCeremony(&models.AwardCeremonyRequest{Year: 1998}))
+	assert.Equal(t, map[string]string{"year": CodeOutOfRange},
+		codesByField(ValidateAwardCeremony(&models.AwardCeremonyRequest{Year: 1800})))
+	assert.Equal(t, map[string]string{"year": CodeOutOfRange},
+		codesByField(ValidateAwardCeremony(&models.AwardCeremonyRequest{Year: time.Now().Year() + 2})))
+}
+
+func TestValidateNomination(t *testing.T) {
+	assert.Empty(t, ValidateNomination(&models.NominationRequest{CeremonyID: 1, CategoryID: 2, FilmID: 3}))
+	assert.Equal(t, map[string]string{"ceremony_id": CodeRequired, "category_id": CodeRequired, "film_id": CodeRequired},
+		codesByField(ValidateNomination(&models.NominationRequest{})))
+}
+
+func TestValidateReview(t *testing.T) {
+	assert.Empty(t, ValidateReview(&models.ReviewRequest{Rating: 1}))
+	assert.Equal(t, map[string]string{"rating": CodeOutOfRange},
+		codesByField(ValidateReview(&models.ReviewRequest{Rating: 0})))
+	assert.Equal(t, map[string]string{"rating": CodeOutOfRange, "text": CodeTooLong},
+		codesByField(ValidateReview(&models.ReviewRequest{Rating: 11, Text: strings.Repeat("a", MaxReviewLength+1)})))
+}
+
+func TestValidateReviewModeration(t *testing.T) {
+	assert.Empty(t, ValidateReviewModeration(&models.ReviewModerationRequest{Action: "approve"}))
+	assert.Equal(t, map[string]string{"reason": CodeRequired},
+		codesByField(ValidateReviewModeration(&models.ReviewModerationRequest{Action: "reject"})))
+	assert.Equal(t, map[string]string{"action": CodeNotAllowed},
+		codesByField(ValidateReviewModeration(&models.ReviewModerationRequest{Action: "delete"})))
+}
+
+func TestValidateReviewFlag(t *testing.T) {
+	assert.Empty(t, ValidateReviewFlag(&models.ReviewFlagRequest{}))
+	assert.Equal(t, map[string]string{"reason": CodeTooLong},
+		codesByField(ValidateReviewFlag(&models.ReviewFlagRequest{Reason: strings.Repeat("a", MaxReasonLength+1)})))
+}
+
+func TestValidateUserListRequest(t *testing.T) {
+	assert.Empty(t, ValidateUserListRequest(false, &models.UserListRequest{}))
+	assert.Empty(t, ValidateUserListRequest(true, &models.UserListRequest{WatchedAt: "2024-03-18"}))
+	assert.Equal(t, map[string]string{"watched_at": CodeNotAllowed},
+		codesByField(ValidateUserListRequest(false, &models.UserListRequest{WatchedAt: "2024-03-18"})))
+	assert.Equal(t, map[string]string{"watched_at": CodeInFuture},
+		codesByField(ValidateUserListRequest(true, &models.UserListRequest{WatchedAt: "2999-01-01"})))
+}
+
+func TestValidateCollection(t *testing.T) {
+	assert.Empty(t, ValidateCollection(&models.CollectionRequest{Name: "Лучшие советские комедии"}))
+	assert.Empty(t, ValidateCollection(&models.CollectionRequest{Name: "Комедии", Slug: "best-comedies-2024"}))
+	assert.Equal(t, map[string]string{"name": CodeRequired, "slug": CodeInvalidFormat},
+		codesByField(ValidateCollection(&models.CollectionRequest{Slug: "Best--Comedies"})))
+}
+
+func TestValidateCollectionOrder(t *testing.T) {
+	assert.Empty(t, ValidateCollectionOrder(&models.CollectionOrderRequest{FilmIDs: []int{3, 1, 2}}))
+	assert.Equal(t, map[string]string{"film_ids": CodeRequired},
+		codesByField(ValidateCollectionOrder(&models.CollectionOrderRequest{})))
+	assert.Equal(t, map[string]string{"film_ids[2]": CodeNotAllowed},
+		codesByField(ValidateCollectionOrder(&models.CollectionOrderRequest{FilmIDs: []int{3, 1, 3}})))
+}